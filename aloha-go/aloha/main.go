@@ -0,0 +1,34 @@
+// Command aloha runs the Dice Agent and talks to A2A agents: aloha serve starts the
+// agent, aloha mcp offers its tools to MCP clients, and aloha send, chat, card, task and
+// the other client commands connect to one.
+// Every command reads the shared config file, ~/.aloha/config.yaml by default.
+package main
+
+import (
+	"os"
+
+	"github.com/aloha/a2a-go/client"
+	"github.com/aloha/a2a-go/pkg/cli"
+	"github.com/aloha/a2a-go/server"
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	root := &cobra.Command{
+		Use:   "aloha",
+		Short: "Run the Dice Agent and talk to A2A agents over gRPC, JSON-RPC and REST",
+		Long: `Run the Dice Agent and talk to A2A agents over gRPC, JSON-RPC and REST.
+
+Flags given on the command line win over their environment variables, which win over
+the config file: the serve section for aloha serve, the selected profile for the client
+commands.`,
+		SilenceUsage: true,
+	}
+	cli.AddConfigFlag(root.PersistentFlags())
+	root.AddCommand(server.Command(), server.MCPCommand())
+	root.AddCommand(client.Commands()...)
+
+	if err := root.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/hostclient"
+)
+
+// agentClient is a host client for one agent, wired to the connection's network settings
+type agentClient struct {
+	*hostclient.Client
+	transport string
+	conn      *connection
+	// card is the card shown to the user, merged with the extended card once it is fetched
+	card *a2a.AgentCard
+}
+
+// connectAgent creates a client for an agent whose card is already resolved.
+// transport is a --transport name; "auto" negotiates it from the card.
+func connectAgent(ctx context.Context, conn *connection, card *a2a.AgentCard, transport string) (*agentClient, error) {
+	var endpoint string
+	if transport == "auto" {
+		var err error
+		if transport, endpoint, err = negotiateTransport(ctx, card); err != nil {
+			return nil, err
+		}
+	}
+	return newAgentClient(ctx, conn, card, transport, endpoint)
+}
+
+// newAgentClient creates a client for a --transport name other than "auto".
+// A non-empty endpoint overrides the REST endpoint the card declares.
+func newAgentClient(ctx context.Context, conn *connection, card *a2a.AgentCard, transport, endpoint string) (*agentClient, error) {
+	protocol, ok := transportProtocol(transport)
+	if !ok {
+		return nil, fmt.Errorf("unsupported transport: %s", transport)
+	}
+	opts := hostclient.Options{Transport: protocol, Endpoint: endpoint, HTTPClient: conn.httpClient}
+	if protocol == a2a.TransportProtocolGRPC {
+		opts.GRPCDialOptions = conn.GRPCDialOptions()
+	}
+	client, err := hostclient.New(ctx, card, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &agentClient{Client: client, transport: transport, conn: conn, card: card}, nil
+}
+
+// SendStreamingMessage sends a streaming message and yields its events,
+// reconnecting to the task if the stream drops
+func (c *agentClient) SendStreamingMessage(ctx context.Context, params *a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return c.conn.ReconnectStream(ctx, c.Stream(ctx, params), c.Resubscribe)
+}
+
+// sendAndPrint sends a message, streaming or not, displays the reply and returns the resulting task info
+func sendAndPrint(ctx context.Context, client *agentClient, params *a2a.MessageSendParams, stream bool, out *outputWriter) a2a.TaskInfo {
+	if stream {
+		return printEventStream(client.SendStreamingMessage(ctx, params), out)
+	}
+	result, err := client.SendMessage(ctx, params)
+	if err != nil {
+		clientLogger.Fatal("Failed to send message: %v", err)
+	}
+	inflight.Observe(result)
+	return printResult(result, out)
+}
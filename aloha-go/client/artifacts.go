@@ -0,0 +1,69 @@
+package client
+
+import (
+	"slices"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// artifactAssembler reassembles artifacts streamed in append/lastChunk chunks
+type artifactAssembler struct {
+	artifacts map[a2a.ArtifactID]*a2a.Artifact
+	order     []a2a.ArtifactID
+}
+
+// newArtifactAssembler creates an empty assembler
+func newArtifactAssembler() *artifactAssembler {
+	return &artifactAssembler{artifacts: make(map[a2a.ArtifactID]*a2a.Artifact)}
+}
+
+// Add applies an artifact update event and returns the complete artifact once its last chunk arrived
+func (as *artifactAssembler) Add(event *a2a.TaskArtifactUpdateEvent) (*a2a.Artifact, bool) {
+	id := event.Artifact.ID
+	current, exists := as.artifacts[id]
+
+	if !exists || !event.Append {
+		copied := *event.Artifact
+		copied.Parts = slices.Clone(event.Artifact.Parts)
+		if !exists {
+			as.order = append(as.order, id)
+		}
+		as.artifacts[id] = &copied
+		current = &copied
+	} else {
+		current.Parts = appendParts(current.Parts, event.Artifact.Parts)
+	}
+
+	if !event.LastChunk {
+		return nil, false
+	}
+	delete(as.artifacts, id)
+	as.order = slices.DeleteFunc(as.order, func(candidate a2a.ArtifactID) bool { return candidate == id })
+	return current, true
+}
+
+// Flush returns artifacts whose last chunk never arrived, in arrival order
+func (as *artifactAssembler) Flush() []*a2a.Artifact {
+	var pending []*a2a.Artifact
+	for _, id := range as.order {
+		pending = append(pending, as.artifacts[id])
+	}
+	as.artifacts = make(map[a2a.ArtifactID]*a2a.Artifact)
+	as.order = nil
+	return pending
+}
+
+// appendParts appends chunk parts, merging consecutive text parts into one
+func appendParts(parts, chunk a2a.ContentParts) a2a.ContentParts {
+	for _, part := range chunk {
+		if text, ok := part.(a2a.TextPart); ok && len(parts) > 0 {
+			if last, ok := parts[len(parts)-1].(a2a.TextPart); ok {
+				last.Text += text.Text
+				parts[len(parts)-1] = last
+				continue
+			}
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// defaultAPIKeyHeader carries --api-key until the agent card names a different header
+const defaultAPIKeyHeader = "X-API-Key"
+
+// authOptions holds the credentials given by flags or environment variables
+type authOptions struct {
+	apiKey       string
+	apiKeyHeader string
+	bearerToken  string
+}
+
+// Empty reports whether no credentials were given
+func (o authOptions) Empty() bool {
+	return o.apiKey == "" && o.bearerToken == ""
+}
+
+// Headers returns the request headers carrying the credentials
+func (o authOptions) Headers() http.Header {
+	headers := make(http.Header)
+	if o.bearerToken != "" {
+		headers.Set("Authorization", "Bearer "+o.bearerToken)
+	}
+	if o.apiKey != "" {
+		name := o.apiKeyHeader
+		if name == "" {
+			name = defaultAPIKeyHeader
+		}
+		headers.Set(name, o.apiKey)
+	}
+	return headers
+}
+
+// matchSecuritySchemes adapts the credentials to the security schemes declared by the agent card.
+// An API key is moved to the header named by the card's apiKey scheme; schemes the client
+// cannot satisfy are reported as warnings.
+func (o authOptions) matchSecuritySchemes(card *a2a.AgentCard) authOptions {
+	if len(card.SecuritySchemes) == 0 {
+		return o
+	}
+
+	names := make([]string, 0, len(card.SecuritySchemes))
+	for name := range card.SecuritySchemes {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	matched := false
+	for _, name := range names {
+		switch scheme := card.SecuritySchemes[a2a.SecuritySchemeName(name)].(type) {
+		case a2a.HTTPAuthSecurityScheme:
+			if strings.EqualFold(scheme.Scheme, "bearer") && o.bearerToken != "" {
+				clientLogger.Info("Using bearer token for security scheme %s", name)
+				matched = true
+			}
+		case a2a.APIKeySecurityScheme:
+			if o.apiKey == "" {
+				continue
+			}
+			if scheme.In != a2a.APIKeySecuritySchemeInHeader {
+				clientLogger.Warn("Security scheme %s expects an API key in %s, only headers are supported", name, scheme.In)
+				continue
+			}
+			o.apiKeyHeader = scheme.Name
+			clientLogger.Info("Using API key in header %s for security scheme %s", scheme.Name, name)
+			matched = true
+		}
+	}
+
+	if !matched {
+		if o.Empty() {
+			clientLogger.Warn("Agent declares security schemes (%s) but no --api-key or --bearer-token was given", strings.Join(names, ", "))
+		} else {
+			clientLogger.Warn("Given credentials match none of the agent's security schemes (%s)", strings.Join(names, ", "))
+		}
+	}
+	return o
+}
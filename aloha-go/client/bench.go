@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// benchReport summarizes a benchmark run
+type benchReport struct {
+	Transport   string         `json:"transport"`
+	Streaming   bool           `json:"streaming"`
+	Concurrency int            `json:"concurrency"`
+	Requests    int            `json:"requests"`
+	Succeeded   int            `json:"succeeded"`
+	Failed      int            `json:"failed"`
+	DurationMs  int64          `json:"durationMs"`
+	Throughput  float64        `json:"throughputPerSec"`
+	LatencyMs   benchLatency   `json:"latencyMs"`
+	Errors      map[string]int `json:"errors,omitempty"`
+}
+
+// benchLatency holds latency statistics of the successful requests in milliseconds
+type benchLatency struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// runBench sends requests messages using concurrency workers and reports latency and throughput
+func runBench(ctx context.Context, client *agentClient, parts []protocol.Part, contextID string, stream bool, concurrency, requests int, out *outputWriter) {
+	if concurrency < 1 || requests < 1 {
+		clientLogger.Fatal("--concurrency and --requests must be at least 1")
+	}
+	concurrency = min(concurrency, requests)
+	clientLogger.Info("Benchmarking %s: %d requests with %d workers (streaming=%v)", client.transport, requests, concurrency, stream)
+
+	var (
+		mu        sync.Mutex
+		latencies []time.Duration
+		errors    = make(map[string]int)
+		next      atomic.Int64
+		wg        sync.WaitGroup
+	)
+
+	start := time.Now()
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for next.Add(1) <= int64(requests) {
+				msg := protocol.NewUserMessage(parts...).InContext(contextID).SDK()
+				latency, err := benchRequest(ctx, client, &a2a.MessageSendParams{Message: msg}, stream)
+
+				mu.Lock()
+				if err != nil {
+					errors[err.Error()]++
+				} else {
+					latencies = append(latencies, latency)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	report := benchReport{
+		Transport:   client.transport,
+		Streaming:   stream,
+		Concurrency: concurrency,
+		Requests:    requests,
+		Succeeded:   len(latencies),
+		Failed:      requests - len(latencies),
+		DurationMs:  elapsed.Milliseconds(),
+		Throughput:  float64(requests) / elapsed.Seconds(),
+		LatencyMs:   latencyStats(latencies),
+		Errors:      errors,
+	}
+
+	if !out.Text() {
+		if err := out.Write(report); err != nil {
+			clientLogger.Fatal("Failed to write output: %v", err)
+		}
+		return
+	}
+	printBenchReport(report)
+}
+
+// benchRequest sends one message and returns the time until the exchange finished
+func benchRequest(ctx context.Context, client *agentClient, params *a2a.MessageSendParams, stream bool) (time.Duration, error) {
+	start := time.Now()
+
+	var state a2a.TaskState
+	if stream {
+		for event, err := range client.SendStreamingMessage(ctx, params) {
+			if err != nil {
+				return 0, err
+			}
+			if s := resultState(event); s != "" {
+				state = s
+			}
+		}
+	} else {
+		result, err := client.SendMessage(ctx, params)
+		if err != nil {
+			return 0, err
+		}
+		state = resultState(result)
+	}
+
+	latency := time.Since(start)
+	if state != a2a.TaskStateCompleted {
+		return 0, &taskStateError{state: state}
+	}
+	return latency, nil
+}
+
+// latencyStats computes min, mean, max and nearest-rank percentiles in milliseconds
+func latencyStats(latencies []time.Duration) benchLatency {
+	if len(latencies) == 0 {
+		return benchLatency{}
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		return millis(sorted[max(rank, 0)])
+	}
+
+	return benchLatency{
+		Min:  millis(sorted[0]),
+		Mean: millis(total / time.Duration(len(sorted))),
+		P50:  percentile(50),
+		P95:  percentile(95),
+		P99:  percentile(99),
+		Max:  millis(sorted[len(sorted)-1]),
+	}
+}
+
+// millis converts a duration to fractional milliseconds
+func millis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// printBenchReport prints the benchmark results
+func printBenchReport(r benchReport) {
+	fmt.Println("\n============================================================")
+	fmt.Println("Benchmark Results:")
+	fmt.Println("============================================================")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Transport:\t%s (streaming=%v)\n", r.Transport, r.Streaming)
+	fmt.Fprintf(w, "Concurrency:\t%d\n", r.Concurrency)
+	fmt.Fprintf(w, "Requests:\t%d (%d succeeded, %d failed)\n", r.Requests, r.Succeeded, r.Failed)
+	fmt.Fprintf(w, "Duration:\t%dms\n", r.DurationMs)
+	fmt.Fprintf(w, "Throughput:\t%.1f req/s\n", r.Throughput)
+	fmt.Fprintf(w, "Latency:\tmin %.1fms  mean %.1fms  p50 %.1fms  p95 %.1fms  p99 %.1fms  max %.1fms\n",
+		r.LatencyMs.Min, r.LatencyMs.Mean, r.LatencyMs.P50, r.LatencyMs.P95, r.LatencyMs.P99, r.LatencyMs.Max)
+	w.Flush()
+
+	if len(r.Errors) > 0 {
+		fmt.Println("\nErrors:")
+		printCounts(r.Errors)
+	}
+	fmt.Println("============================================================")
+}
@@ -0,0 +1,281 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// wellKnownCardPath is where agents publish their card, relative to the card base URL
+const wellKnownCardPath = "/.well-known/agent-card.json"
+
+// cardInspection is the structured result of --card
+type cardInspection struct {
+	URL        string            `json:"url"`
+	Card       any               `json:"card"`
+	Issues     []string          `json:"issues,omitempty"`
+	Signatures []signatureResult `json:"signatures,omitempty"`
+}
+
+// fetchCardJSON downloads the raw agent card. Base URLs get the well-known path appended;
+// URLs that already point at a .json document are used as is.
+func fetchCardJSON(ctx context.Context, conn *connection, cardURL string) (string, []byte, error) {
+	target := cardURL
+	if !strings.HasSuffix(strings.ToLower(cardURL), ".json") {
+		var err error
+		if target, err = url.JoinPath(cardURL, wellKnownCardPath); err != nil {
+			return "", nil, fmt.Errorf("invalid card URL %q: %w", cardURL, err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := conn.httpClient.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("card request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read card: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("card request to %s returned %s", target, resp.Status)
+	}
+	return target, data, nil
+}
+
+// runCardInspection handles --card: prints the agent card, optionally validates it, and verifies its signatures
+func runCardInspection(ctx context.Context, conn *connection, cardURL string, validate bool, keys signatureKeys, out *outputWriter) {
+	target, raw, err := fetchCardJSON(ctx, conn, cardURL)
+	if err != nil {
+		clientLogger.Fatal("Failed to fetch agent card: %v", err)
+	}
+
+	var card a2a.AgentCard
+	if err := json.Unmarshal(raw, &card); err != nil {
+		clientLogger.Fatal("Failed to parse agent card: %v", err)
+	}
+	var generic any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		clientLogger.Fatal("Failed to parse agent card: %v", err)
+	}
+
+	inspection := cardInspection{URL: target, Card: generic}
+	if validate {
+		inspection.Issues = validateCard(&card)
+	}
+	if len(card.Signatures) > 0 {
+		inspection.Signatures = verifyCardSignatures(ctx, conn, target, raw, card.Signatures, keys)
+	}
+
+	if out.Text() {
+		printCard(&card, target)
+		printCardChecks(inspection, validate)
+	} else if err := out.Write(inspection); err != nil {
+		clientLogger.Fatal("Failed to write output: %v", err)
+	}
+
+	failed := len(inspection.Issues) > 0
+	for _, sig := range inspection.Signatures {
+		failed = failed || sig.Status == signatureInvalid
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// validateCard checks the fields the A2A specification requires and internal consistency
+func validateCard(card *a2a.AgentCard) []string {
+	var issues []string
+	require := func(value, field string) {
+		if strings.TrimSpace(value) == "" {
+			issues = append(issues, fmt.Sprintf("missing required field %q", field))
+		}
+	}
+
+	require(card.Name, "name")
+	require(card.Description, "description")
+	require(card.URL, "url")
+	require(card.Version, "version")
+	require(card.ProtocolVersion, "protocolVersion")
+	if card.ProtocolVersion != "" {
+		if _, err := protocol.NegotiateVersion(protocol.SupportedProtocolVersions, card.ProtocolVersion); err != nil {
+			issues = append(issues, err.Error())
+		}
+	}
+	if len(card.DefaultInputModes) == 0 {
+		issues = append(issues, `"defaultInputModes" must list at least one media type`)
+	}
+	if len(card.DefaultOutputModes) == 0 {
+		issues = append(issues, `"defaultOutputModes" must list at least one media type`)
+	}
+	if len(card.Skills) == 0 {
+		issues = append(issues, `"skills" must declare at least one skill`)
+	}
+
+	skillIDs := make(map[string]bool)
+	for i, skill := range card.Skills {
+		require(skill.ID, fmt.Sprintf("skills[%d].id", i))
+		require(skill.Name, fmt.Sprintf("skills[%d].name", i))
+		require(skill.Description, fmt.Sprintf("skills[%d].description", i))
+		if skill.Tags == nil {
+			issues = append(issues, fmt.Sprintf("missing required field %q", fmt.Sprintf("skills[%d].tags", i)))
+		}
+		if skill.ID != "" && skillIDs[skill.ID] {
+			issues = append(issues, fmt.Sprintf("duplicate skill id %q", skill.ID))
+		}
+		skillIDs[skill.ID] = true
+	}
+
+	if card.PreferredTransport != "" {
+		if _, known := transportNames[card.PreferredTransport]; !known {
+			issues = append(issues, fmt.Sprintf("unknown preferredTransport %q", card.PreferredTransport))
+		}
+	}
+	for i, iface := range card.AdditionalInterfaces {
+		require(iface.URL, fmt.Sprintf("additionalInterfaces[%d].url", i))
+		require(string(iface.Transport), fmt.Sprintf("additionalInterfaces[%d].transport", i))
+	}
+
+	for _, requirement := range card.Security {
+		for name := range requirement {
+			if _, declared := card.SecuritySchemes[name]; !declared {
+				issues = append(issues, fmt.Sprintf("security requirement references undeclared scheme %q", name))
+			}
+		}
+	}
+	return issues
+}
+
+// printCard pretty-prints the agent card
+func printCard(card *a2a.AgentCard, source string) {
+	fmt.Println("\n============================================================")
+	fmt.Println("Agent Card:")
+	fmt.Println("============================================================")
+	fmt.Printf("Source: %s\n", source)
+	fmt.Printf("Name: %s (v%s)\n", card.Name, card.Version)
+	fmt.Printf("Description: %s\n", card.Description)
+	fmt.Printf("Protocol Version: %s\n", card.ProtocolVersion)
+	if card.Provider != nil {
+		fmt.Printf("Provider: %s (%s)\n", card.Provider.Org, card.Provider.URL)
+	}
+	if card.DocumentationURL != "" {
+		fmt.Printf("Documentation: %s\n", card.DocumentationURL)
+	}
+
+	fmt.Println("\n--- Interfaces ---")
+	preferred := card.PreferredTransport
+	if preferred == "" {
+		preferred = a2a.TransportProtocolJSONRPC
+	}
+	fmt.Printf("  %-10s %s (preferred)\n", preferred, card.URL)
+	for _, iface := range card.AdditionalInterfaces {
+		fmt.Printf("  %-10s %s\n", iface.Transport, iface.URL)
+	}
+
+	fmt.Println("\n--- Capabilities ---")
+	fmt.Printf("  Streaming: %v\n", card.Capabilities.Streaming)
+	fmt.Printf("  Push Notifications: %v\n", card.Capabilities.PushNotifications)
+	fmt.Printf("  State Transition History: %v\n", card.Capabilities.StateTransitionHistory)
+	fmt.Printf("  Authenticated Extended Card: %v\n", card.SupportsAuthenticatedExtendedCard)
+	for _, ext := range card.Capabilities.Extensions {
+		fmt.Printf("  Extension: %s (required=%v)\n", ext.URI, ext.Required)
+	}
+	fmt.Printf("  Input Modes: %s\n", strings.Join(card.DefaultInputModes, ", "))
+	fmt.Printf("  Output Modes: %s\n", strings.Join(card.DefaultOutputModes, ", "))
+
+	fmt.Printf("\n--- Skills (%d) ---\n", len(card.Skills))
+	for _, skill := range card.Skills {
+		fmt.Printf("  %s: %s\n", skill.ID, skill.Name)
+		fmt.Printf("    %s\n", skill.Description)
+		if len(skill.Tags) > 0 {
+			fmt.Printf("    Tags: %s\n", strings.Join(skill.Tags, ", "))
+		}
+		for _, example := range skill.Examples {
+			fmt.Printf("    Example: %s\n", example)
+		}
+	}
+
+	if len(card.SecuritySchemes) > 0 {
+		fmt.Println("\n--- Security Schemes ---")
+		names := make([]string, 0, len(card.SecuritySchemes))
+		for name := range card.SecuritySchemes {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("  %s: %s\n", name, describeSecurityScheme(card.SecuritySchemes[a2a.SecuritySchemeName(name)]))
+		}
+		for _, requirement := range card.Security {
+			var required []string
+			for name, scopes := range requirement {
+				required = append(required, fmt.Sprintf("%s%v", name, scopes))
+			}
+			sort.Strings(required)
+			fmt.Printf("  Required: %s\n", strings.Join(required, " + "))
+		}
+	}
+	fmt.Println("============================================================")
+}
+
+// describeSecurityScheme summarizes a security scheme in one line
+func describeSecurityScheme(scheme a2a.SecurityScheme) string {
+	switch s := scheme.(type) {
+	case a2a.HTTPAuthSecurityScheme:
+		if s.BearerFormat != "" {
+			return fmt.Sprintf("http %s (%s)", s.Scheme, s.BearerFormat)
+		}
+		return "http " + s.Scheme
+	case a2a.APIKeySecurityScheme:
+		return fmt.Sprintf("apiKey in %s %q", s.In, s.Name)
+	case a2a.OAuth2SecurityScheme:
+		return "oauth2"
+	case a2a.OpenIDConnectSecurityScheme:
+		return "openIdConnect " + s.OpenIDConnectURL
+	case a2a.MutualTLSSecurityScheme:
+		return "mutualTLS"
+	default:
+		return fmt.Sprintf("%T", scheme)
+	}
+}
+
+// printCardChecks prints the validation and signature results
+func printCardChecks(inspection cardInspection, validate bool) {
+	if validate {
+		if len(inspection.Issues) == 0 {
+			fmt.Println("Validation: OK")
+		} else {
+			fmt.Printf("Validation: %d issue(s)\n", len(inspection.Issues))
+			for _, issue := range inspection.Issues {
+				fmt.Printf("  - %s\n", issue)
+			}
+		}
+	}
+	for i, sig := range inspection.Signatures {
+		fmt.Printf("Signature %d: %s", i+1, sig.Status)
+		if sig.Algorithm != "" {
+			fmt.Printf(" (alg=%s", sig.Algorithm)
+			if sig.KeyID != "" {
+				fmt.Printf(", kid=%s", sig.KeyID)
+			}
+			fmt.Print(")")
+		}
+		if sig.Detail != "" {
+			fmt.Printf(": %s", sig.Detail)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,437 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Signature verification outcomes
+const (
+	signatureValid      = "valid"
+	signatureInvalid    = "invalid"
+	signatureUnverified = "unverified"
+)
+
+// signatureResult is the verification outcome of one agent card signature
+type signatureResult struct {
+	Status    string `json:"status"`
+	Algorithm string `json:"alg,omitempty"`
+	KeyID     string `json:"kid,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// jwsHeader holds the JWS header parameters used for verification
+type jwsHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Jku string `json:"jku"`
+}
+
+// signatureKeys is where card signature verification takes its keys from
+type signatureKeys struct {
+	file string   // --card-key: PEM public key or certificate
+	jwks []string // --card-jwks: JWK set URLs trusted besides those on the card's origin
+}
+
+// verifyCardSignatures checks each detached JWS signature over the canonical card without its
+// "signatures" field (RFC 8785 JSON canonicalization). The key comes from the --card-key file
+// when given, otherwise from a trusted JWK set: the protected "jku" header when it is
+// same-origin with cardURL or listed in --card-jwks, or the --card-jwks sets when there is no jku.
+func verifyCardSignatures(ctx context.Context, conn *connection, cardURL string, raw []byte, signatures []a2a.AgentCardSignature, keys signatureKeys) []signatureResult {
+	var card map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&card); err != nil {
+		return []signatureResult{{Status: signatureInvalid, Detail: err.Error()}}
+	}
+	delete(card, "signatures")
+	payload, err := canonicalJSON(card)
+	if err != nil {
+		return []signatureResult{{Status: signatureInvalid, Detail: err.Error()}}
+	}
+
+	var fileKey crypto.PublicKey
+	if keys.file != "" {
+		if fileKey, err = loadPublicKey(keys.file); err != nil {
+			clientLogger.Fatal("Failed to load --card-key: %v", err)
+		}
+	}
+
+	results := make([]signatureResult, 0, len(signatures))
+	for _, sig := range signatures {
+		results = append(results, verifyCardSignature(ctx, conn, cardURL, payload, sig, fileKey, keys.jwks))
+	}
+	return results
+}
+
+// verifyCardSignature verifies one signature against the canonical payload. Only the
+// protected header is read: the unprotected one is not covered by the signature, so
+// anyone could point its jku at their own key.
+func verifyCardSignature(ctx context.Context, conn *connection, cardURL string, payload []byte, sig a2a.AgentCardSignature, key crypto.PublicKey, trustedJWKS []string) signatureResult {
+	headerJSON, err := base64.RawURLEncoding.DecodeString(sig.Protected)
+	if err != nil {
+		return signatureResult{Status: signatureInvalid, Detail: "protected header is not base64url"}
+	}
+	var header jwsHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return signatureResult{Status: signatureInvalid, Detail: "protected header is not JSON"}
+	}
+	result := signatureResult{Algorithm: header.Alg, KeyID: header.Kid}
+
+	signature, err := base64.RawURLEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		result.Status, result.Detail = signatureInvalid, "signature is not base64url"
+		return result
+	}
+
+	if key == nil {
+		urls, err := jwksURLs(cardURL, header.Jku, trustedJWKS)
+		if err != nil {
+			result.Status, result.Detail = signatureUnverified, err.Error()
+			return result
+		}
+		if len(urls) == 0 {
+			result.Status, result.Detail = signatureUnverified, "no key available; pass --card-key or --card-jwks, or publish a jku on the card's origin"
+			return result
+		}
+		for _, jku := range urls {
+			if key, err = fetchJWKSKey(ctx, conn, jku, header.Kid); err == nil {
+				break
+			}
+		}
+		if key == nil {
+			result.Status, result.Detail = signatureUnverified, err.Error()
+			return result
+		}
+	}
+
+	input := sig.Protected + "." + base64.RawURLEncoding.EncodeToString(payload)
+	if err := verifyJWS(header.Alg, key, []byte(input), signature); err != nil {
+		result.Status, result.Detail = signatureInvalid, err.Error()
+		return result
+	}
+	result.Status = signatureValid
+	return result
+}
+
+// jwksURLs returns the JWK sets a signature's key may be taken from: its jku when that is
+// same-origin with the card or listed in trusted, or the trusted sets when it has no jku.
+// A jku from anywhere else could name a key of the forger's choosing, and is refused.
+func jwksURLs(cardURL, jku string, trusted []string) ([]string, error) {
+	if jku == "" {
+		return trusted, nil
+	}
+	if slices.Contains(trusted, jku) || sameOrigin(cardURL, jku) {
+		return []string{jku}, nil
+	}
+	return nil, fmt.Errorf("jku %s is not on the card's origin nor listed in --card-jwks", jku)
+}
+
+// sameOrigin reports whether two URLs share their scheme, host and port
+func sameOrigin(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil || ua.Host == "" {
+		return false
+	}
+	return strings.EqualFold(ua.Scheme, ub.Scheme) && strings.EqualFold(ua.Host, ub.Host)
+}
+
+// verifyJWS verifies a JWS signature for the supported algorithms
+func verifyJWS(alg string, key crypto.PublicKey, input, signature []byte) error {
+	hashes := map[string]crypto.Hash{"256": crypto.SHA256, "384": crypto.SHA384, "512": crypto.SHA512}
+
+	if alg == "EdDSA" || alg == "Ed25519" {
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s requires an Ed25519 key, got %T", alg, key)
+		}
+		if !ed25519.Verify(pub, input, signature) {
+			return fmt.Errorf("signature mismatch")
+		}
+		return nil
+	}
+
+	if len(alg) != 5 {
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	hash, ok := hashes[alg[2:]]
+	if !ok {
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	h := hash.New()
+	h.Write(input)
+	digest := h.Sum(nil)
+
+	switch alg[:2] {
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s requires an EC key, got %T", alg, key)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("signature has %d bytes, want %d", len(signature), 2*size)
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return fmt.Errorf("signature mismatch")
+		}
+		return nil
+	case "RS", "PS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s requires an RSA key, got %T", alg, key)
+		}
+		if alg[:2] == "RS" {
+			return rsa.VerifyPKCS1v15(pub, hash, digest, signature)
+		}
+		return rsa.VerifyPSS(pub, hash, digest, signature, nil)
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+}
+
+// loadPublicKey reads a PEM public key or certificate
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found in %s", path)
+	}
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		return cert.PublicKey, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	return key, nil
+}
+
+// jwk is a JSON Web Key with the members needed for EC, RSA and OKP public keys
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// fetchJWKSKey downloads a JWK set and returns the key matching kid (or the only key when kid is empty)
+func fetchJWKSKey(ctx context.Context, conn *connection, jku, kid string) (crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jku, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jku %q: %w", jku, err)
+	}
+	resp, err := conn.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS request to %s returned %s", jku, resp.Status)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+	for _, key := range set.Keys {
+		if key.Kid == kid || (kid == "" && len(set.Keys) == 1) {
+			return key.publicKey()
+		}
+	}
+	return nil, fmt.Errorf("no key with kid %q in %s", kid, jku)
+}
+
+// publicKey converts the JWK to a crypto public key
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	decode := func(s string) ([]byte, error) { return base64.RawURLEncoding.DecodeString(s) }
+
+	switch k.Kty {
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+		curve, ok := curves[k.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported EC curve %q", k.Crv)
+		}
+		x, errX := decode(k.X)
+		y, errY := decode(k.Y)
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("invalid EC key coordinates")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "RSA":
+		n, errN := decode(k.N)
+		e, errE := decode(k.E)
+		if errN != nil || errE != nil {
+			return nil, fmt.Errorf("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported OKP curve %q", k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// canonicalJSON serializes a value decoded with UseNumber using the
+// JSON Canonicalization Scheme (RFC 8785)
+func canonicalJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCanonical writes one value in canonical form
+func writeCanonical(buf *bytes.Buffer, v any) error {
+	switch value := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(value))
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return fmt.Errorf("invalid number %s: %w", value, err)
+		}
+		buf.WriteString(canonicalNumber(f))
+	case string:
+		writeCanonicalString(buf, value)
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]any:
+		// Members are ordered by their UTF-16 code units
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return lessUTF16(keys[i], keys[j]) })
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, key)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, value[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unsupported JSON value %T", v)
+	}
+	return nil
+}
+
+// canonicalNumber formats a number like ECMAScript's Number.prototype.toString
+func canonicalNumber(f float64) string {
+	if f == 0 {
+		return "0"
+	}
+	if abs := math.Abs(f); abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exponent, _ := bytes.Cut([]byte(s), []byte("e"))
+	sign, digits := exponent[0], bytes.TrimLeft(exponent[1:], "0")
+	return fmt.Sprintf("%se%c%s", mantissa, sign, digits)
+}
+
+// writeCanonicalString writes a string with the minimal escaping RFC 8785 prescribes
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// lessUTF16 compares strings by their UTF-16 code units
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
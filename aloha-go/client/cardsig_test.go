@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// cardRaw is the signed card, without its signatures
+const cardRaw = `{"name":"Dice Agent","url":"http://localhost:12001","version":"1.0.0","skills":[{"id":"roll-dice","name":"Roll dice"}]}`
+
+// jwksServer serves a JWK set holding key under kid at /jwks.json
+func jwksServer(t *testing.T, kid string, key ed25519.PublicKey) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []jwk{{
+			Kty: "OKP", Crv: "Ed25519", Kid: kid, X: base64.RawURLEncoding.EncodeToString(key),
+		}}})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// signCard signs cardRaw with key, putting header in the protected header and
+// unprotected in the unprotected one
+func signCard(t *testing.T, key ed25519.PrivateKey, header, unprotected map[string]any) a2a.AgentCardSignature {
+	t.Helper()
+	var card map[string]any
+	if err := json.Unmarshal([]byte(cardRaw), &card); err != nil {
+		t.Fatal(err)
+	}
+	payload, err := canonicalJSON(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	protected := base64.RawURLEncoding.EncodeToString(headerJSON)
+	input := protected + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return a2a.AgentCardSignature{
+		Protected: protected,
+		Signature: base64.RawURLEncoding.EncodeToString(ed25519.Sign(key, []byte(input))),
+		Header:    unprotected,
+	}
+}
+
+func TestVerifyCardSignatureTrust(t *testing.T) {
+	agentPub, agentKey, _ := ed25519.GenerateKey(rand.Reader)
+	forgerPub, forgerKey, _ := ed25519.GenerateKey(rand.Reader)
+	// The agent serves its card and its JWK set from the same origin
+	agent := jwksServer(t, "agent", agentPub)
+	forger := jwksServer(t, "forger", forgerPub)
+	cardURL := agent.URL + "/.well-known/agent-card.json"
+
+	tests := []struct {
+		name    string
+		sig     a2a.AgentCardSignature
+		trusted []string
+		want    string
+	}{
+		{
+			name: "jku on the card's origin",
+			sig:  signCard(t, agentKey, map[string]any{"alg": "EdDSA", "kid": "agent", "jku": agent.URL + "/jwks.json"}, nil),
+			want: signatureValid,
+		},
+		{
+			name: "forged card with a foreign jku",
+			sig:  signCard(t, forgerKey, map[string]any{"alg": "EdDSA", "kid": "forger", "jku": forger.URL + "/jwks.json"}, nil),
+			want: signatureUnverified,
+		},
+		{
+			name: "jku only in the unprotected header",
+			sig: signCard(t, forgerKey, map[string]any{"alg": "EdDSA"},
+				map[string]any{"kid": "forger", "jku": forger.URL + "/jwks.json"}),
+			want: signatureUnverified,
+		},
+		{
+			name: "forged card with the agent's jku",
+			sig:  signCard(t, forgerKey, map[string]any{"alg": "EdDSA", "kid": "agent", "jku": agent.URL + "/jwks.json"}, nil),
+			want: signatureInvalid,
+		},
+		{
+			name:    "foreign jku listed in --card-jwks",
+			sig:     signCard(t, forgerKey, map[string]any{"alg": "EdDSA", "kid": "forger", "jku": forger.URL + "/jwks.json"}, nil),
+			trusted: []string{forger.URL + "/jwks.json"},
+			want:    signatureValid,
+		},
+		{
+			name:    "no jku, key in --card-jwks",
+			sig:     signCard(t, agentKey, map[string]any{"alg": "EdDSA", "kid": "agent"}, nil),
+			trusted: []string{agent.URL + "/jwks.json"},
+			want:    signatureValid,
+		},
+	}
+
+	proxy, err := newProxySettings("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := newConnection(connectionOptions{proxy: proxy})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := verifyCardSignatures(context.Background(), conn, cardURL, []byte(cardRaw),
+				[]a2a.AgentCardSignature{tt.sig}, signatureKeys{jwks: tt.trusted})
+			if got := results[0]; got.Status != tt.want {
+				t.Errorf("status = %s (%s), want %s", got.Status, got.Detail, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/hostclient"
+)
+
+// chatHelp lists the commands understood by the --chat console
+const chatHelp = `Commands:
+  /reset  Start a new conversation with a fresh context ID
+  /help   Show this help
+  /exit   Leave the chat (Ctrl-D also works)`
+
+// chatRegistryHelp lists the extra commands available with --agents-file
+const chatRegistryHelp = `  /agents       List the agents in the registry
+  /use <name>   Talk to another agent from now on
+  @<name> text  Send one message to another agent`
+
+// chatAgents is who a --chat console talks to: the connected agent, or with
+// --agents-file any agent of the registry, picked by name
+type chatAgents struct {
+	single   *agentClient
+	registry *agentRegistry
+	current  string // registry name of the agent messages go to
+}
+
+// client returns the client for the named registry agent, or the connected agent
+func (a *chatAgents) client(ctx context.Context, name string) (*agentClient, error) {
+	if a.registry == nil {
+		return a.single, nil
+	}
+	return a.registry.Client(ctx, name)
+}
+
+// help returns the commands available in this console
+func (a *chatAgents) help() string {
+	if a.registry == nil {
+		return chatHelp
+	}
+	return chatHelp + "\n" + chatRegistryHelp
+}
+
+// runChat handles --chat: an interactive console that sends each line read from stdin
+// as a message in the same conversation. first, if not empty, is sent as the opening turn.
+// Each agent has its own conversation; contextID continues the first agent's.
+// Each turn gets its own timeout; a failed turn is reported and the console carries on.
+// It returns the context ID of the current agent's conversation when the console ends.
+func runChat(agents *chatAgents, contextID string, first []a2a.Part, stream bool, timeout time.Duration, out *outputWriter) string {
+	// Conversations start on an agent's first turn; resume holds the context IDs they continue
+	conversations := map[string]*hostclient.Session{}
+	resume := map[string]string{agents.current: contextID}
+	conversation := func(name string, client *agentClient) *hostclient.Session {
+		if conversations[name] == nil {
+			conversations[name] = hostclient.NewSession(client.Client, resume[name])
+		}
+		return conversations[name]
+	}
+	currentContextID := func() string {
+		if c := conversations[agents.current]; c != nil {
+			return c.ContextID()
+		}
+		return resume[agents.current]
+	}
+
+	prompt := !stdinPiped()
+	if prompt {
+		name := agents.current
+		if agents.single != nil {
+			name = agents.single.card.Name
+		}
+		fmt.Printf("Chatting with %s. Type /help for commands.\n", name)
+	}
+
+	turn := func(name string, parts []a2a.Part) {
+		ctx, cancel := context.WithCancel(context.Background())
+		if timeout > 0 {
+			ctx, cancel = context.WithTimeout(context.Background(), timeout)
+		}
+		defer cancel()
+
+		client, err := agents.client(ctx, name)
+		if err != nil {
+			clientLogger.Error("%v", err)
+			return
+		}
+		c := conversation(name, client)
+		if err := chatTurn(ctx, client, c, parts, stream, out); err != nil {
+			clientLogger.Error("Turn failed: %v", err)
+			return
+		}
+		if taskID := c.TaskID(); taskID != "" {
+			clientLogger.Info("Task %s is %s; the next message continues it", taskID, inflight.State())
+		}
+	}
+
+	if len(first) > 0 {
+		turn(agents.current, first)
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		if prompt {
+			fmt.Print("> ")
+		}
+		if !scanner.Scan() {
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		command, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+		switch {
+		case line == "":
+			continue
+		case line == "/exit" || line == "/quit":
+			return currentContextID()
+		case line == "/help":
+			fmt.Println(agents.help())
+			continue
+		case line == "/reset":
+			delete(conversations, agents.current)
+			delete(resume, agents.current)
+			fmt.Println("Started a new conversation")
+			continue
+		case agents.registry != nil && line == "/agents":
+			agents.registry.Print(out)
+			continue
+		case agents.registry != nil && command == "/use":
+			if _, err := agents.registry.Get(arg); err != nil {
+				clientLogger.Error("%v", err)
+				continue
+			}
+			agents.current = arg
+			fmt.Printf("Now talking to %s\n", arg)
+			continue
+		case agents.registry != nil && strings.HasPrefix(command, "@"):
+			if arg == "" {
+				fmt.Printf("Nothing to send to %s\n", command)
+				continue
+			}
+			turn(strings.TrimPrefix(command, "@"), []a2a.Part{a2a.TextPart{Text: arg}})
+			continue
+		case strings.HasPrefix(line, "/"):
+			fmt.Printf("Unknown command %s\n%s\n", line, agents.help())
+			continue
+		}
+		turn(agents.current, []a2a.Part{a2a.TextPart{Text: line}})
+	}
+	if err := scanner.Err(); err != nil {
+		clientLogger.Error("Failed to read input: %v", err)
+	}
+	if prompt {
+		fmt.Println()
+	}
+	return currentContextID()
+}
+
+// chatTurn sends the parts as the conversation's next message and displays the reply
+func chatTurn(ctx context.Context, client *agentClient, conversation *hostclient.Session, parts []a2a.Part, stream bool, out *outputWriter) error {
+	params := conversation.Message(parts...)
+	if stream {
+		_, err := renderEventStream(conversation.Track(client.SendStreamingMessage(ctx, params)), out)
+		return err
+	}
+	result, err := client.SendMessage(ctx, params)
+	if err != nil {
+		return err
+	}
+	inflight.Observe(result)
+	conversation.Observe(result)
+	printResult(result, out)
+	return nil
+}
@@ -0,0 +1,492 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/cli"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// options are the flag values of a client command. Each command registers the flags
+// it accepts and sets the mode fields that select what run does.
+type options struct {
+	// Connection to the agent, shared by the commands talking to one
+	profile        string
+	transport      string
+	host           string
+	port           int
+	cardURL        string
+	timeout        time.Duration
+	tls            tlsOptions
+	authority      string
+	proxy          string
+	auth           authOptions
+	retries        int
+	retryBackoff   time.Duration
+	retryStatus    string
+	retryCodes     string
+	retrySends     bool
+	connectTimeout time.Duration
+	ignoreVersion  bool
+	reconnect      int
+	output         string
+	verbose        bool
+	quiet          bool
+	recordPath     string
+
+	// Message
+	parts          messageParts
+	stream         bool
+	follow         bool
+	nonBlocking    bool
+	historyLength  int
+	accept         string
+	contextID      string
+	session        string
+	saveTranscript string
+	pushListen     string
+	pushURL        string
+
+	// Agents to send to instead of --host
+	agents          string
+	agentsFile      string
+	agentName       string
+	discover        bool
+	discoverTimeout time.Duration
+	registryURL     string
+	tags            string
+	route           string
+
+	// Modes, set by the command
+	chat        bool
+	inspectCard bool
+	conformance bool
+	interop     bool
+	bench       bool
+	loadtest    bool
+	listAgents  bool
+	taskList    bool
+	taskGet     string
+	taskCancel  string
+	resubscribe string
+	replayPath  string
+
+	// Mode options
+	validateCard   bool
+	cardKey        string
+	cardJWKS       []string
+	taskState      string
+	concurrency    int
+	requests       int
+	rps            float64
+	ramp           time.Duration
+	duration       time.Duration
+	reportInterval time.Duration
+	maxInflight    int
+	interopOpts    interopOptions
+}
+
+// newOptions returns the options of a command with the defaults of the flags it may not register
+func newOptions() *options {
+	return &options{transport: "jsonrpc", historyLength: -1}
+}
+
+// Commands returns the client commands of the aloha binary
+func Commands() []*cobra.Command {
+	return []*cobra.Command{
+		sendCommand(),
+		chatCommand(),
+		cardCommand(),
+		taskCommand(),
+		agentsCommand(),
+		benchCommand(),
+		loadtestCommand(),
+		conformanceCommand(),
+		interopCommand(),
+		replayCommand(),
+	}
+}
+
+// newCommand creates a client command running o once its flags are parsed: flags not
+// given fall back to their environment variables, then to the selected profile
+func newCommand(o *options, cmd *cobra.Command) *cobra.Command {
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := cli.ApplyEnv(cmd.Flags()); err != nil {
+			return err
+		}
+		if cmd.Flags().Lookup("profile") == nil {
+			return nil
+		}
+		name, agentProfile, err := loadProfile(o.profile)
+		if err != nil {
+			return fmt.Errorf("failed to load profile: %w", err)
+		}
+		if agentProfile != nil {
+			o.profile = name
+			return agentProfile.apply(cmd.Flags(), name)
+		}
+		o.profile = ""
+		return nil
+	}
+	if cmd.RunE == nil {
+		cmd.Run = func(cmd *cobra.Command, args []string) { o.run() }
+	}
+	return cmd
+}
+
+// connectionFlags registers the flags connecting to an agent
+func connectionFlags(fs *pflag.FlagSet, o *options) {
+	fs.StringVar(&o.profile, "profile", "", "Named profile from the config file")
+	fs.StringVar(&o.transport, "transport", "jsonrpc", "Transport protocol to use (jsonrpc, grpc, rest, auto)")
+	fs.StringVar(&o.host, "host", "localhost", "Agent hostname")
+	fs.IntVar(&o.port, "port", 0, "Agent port (default: 12000 for gRPC, 12001 for JSON-RPC, 12002 for REST)")
+	fs.StringVar(&o.cardURL, "card-url", "", "Agent card URL (auto-resolved from host:port if empty)")
+	fs.DurationVar(&o.timeout, "timeout", 60*time.Second, "Overall request timeout (0 disables)")
+	fs.BoolVar(&o.tls.enabled, "tls", false, "Connect to the agent over TLS")
+	fs.StringVar(&o.tls.caCert, "ca-cert", "", "PEM file with CA certificates used to verify the agent (implies --tls)")
+	fs.StringVar(&o.tls.clientCert, "client-cert", "", "PEM client certificate for mutual TLS (implies --tls)")
+	fs.StringVar(&o.tls.clientKey, "client-key", "", "PEM private key for --client-cert")
+	fs.BoolVar(&o.tls.insecure, "insecure", false, "Skip TLS certificate verification (implies --tls)")
+	fs.StringVar(&o.tls.serverName, "server-name", "", "Name to verify the agent certificate against instead of the host (implies --tls)")
+	fs.StringVar(&o.authority, "authority", "", "Override the :authority header of gRPC calls")
+	fs.StringVar(&o.proxy, "proxy", "", "Proxy URL for all transports (default: HTTP_PROXY/HTTPS_PROXY/NO_PROXY)")
+	fs.StringVar(&o.auth.apiKey, "api-key", "", "API key sent with every request")
+	fs.StringVar(&o.auth.bearerToken, "bearer-token", "", "Bearer token sent with every request")
+	fs.IntVar(&o.retries, "retries", 2, "Number of retries for failed requests (0 disables retry)")
+	fs.DurationVar(&o.retryBackoff, "retry-backoff", 500*time.Millisecond, "Initial backoff between retries, doubled after each attempt")
+	fs.StringVar(&o.retryStatus, "retry-status", defaultRetryStatuses, "Comma-separated HTTP statuses to retry")
+	fs.StringVar(&o.retryCodes, "retry-codes", defaultRetryGRPCCodes, "Comma-separated gRPC codes to retry")
+	fs.BoolVar(&o.retrySends, "retry-sends", false, "Also retry message sends that may have reached the agent, at the risk of duplicate tasks")
+	fs.DurationVar(&o.connectTimeout, "connect-timeout", 10*time.Second, "Timeout for establishing a connection to the agent (0 keeps the system default)")
+	fs.BoolVar(&o.ignoreVersion, "ignore-version", false, "Connect to agents declaring an incompatible A2A protocol version")
+	fs.IntVar(&o.reconnect, "reconnect", 3, "Times to resubscribe when a stream drops before its task ends (0 disables)")
+	outputFlags(fs, o)
+	cli.BindEnv(fs, "profile", "ALOHA_PROFILE")
+	cli.BindEnv(fs, "api-key", "ALOHA_API_KEY")
+	cli.BindEnv(fs, "bearer-token", "ALOHA_BEARER_TOKEN")
+}
+
+// outputFlags registers the flags choosing how results and wire traffic are shown
+func outputFlags(fs *pflag.FlagSet, o *options) {
+	fs.StringVar(&o.output, "output", outputText, "Output format (text, json, yaml)")
+	fs.BoolVar(&o.verbose, "verbose", false, "Dump every request and response on the wire to stderr")
+}
+
+// quietFlags registers --quiet and --record, for the commands printing one result
+func quietFlags(fs *pflag.FlagSet, o *options) {
+	fs.BoolVar(&o.quiet, "quiet", false, "Print only the agent's final text to stdout; only warnings and errors are logged")
+	fs.StringVar(&o.recordPath, "record", "", "Capture every request and event on the wire to this JSON file")
+}
+
+// messageFlags registers the flags building the message sent
+func messageFlags(fs *pflag.FlagSet, o *options) {
+	fs.Var(o.parts.Flag(partText), "message", "Text to send to the agent as a TextPart (repeatable; - reads it from stdin)")
+	fs.Var(o.parts.Flag(partFile), "file", "File path or URI to attach as a FilePart (repeatable)")
+	fs.Var(o.parts.Flag(partData), "data", "JSON object (or @file.json) to send as a DataPart (repeatable)")
+	fs.BoolVar(&o.stream, "stream", false, "Enable streaming response")
+	fs.StringVar(&o.contextID, "context-id", "", "Context ID of an existing conversation to continue")
+}
+
+// sessionFlags registers --session
+func sessionFlags(fs *pflag.FlagSet, o *options) {
+	fs.StringVar(&o.session, "session", "", "Named session whose context ID is persisted across runs")
+}
+
+// namedAgentFlags registers the flags picking an agent by name from a file or the local network
+func namedAgentFlags(fs *pflag.FlagSet, o *options) {
+	fs.StringVar(&o.agentsFile, "agents-file", "", "YAML registry of known agents; their cards are resolved at startup")
+	fs.StringVar(&o.agentName, "agent", "", "With --agents-file or --discover, name of the agent to talk to")
+	fs.BoolVar(&o.discover, "discover", false, "Discover the agents advertised with mDNS on the local network")
+	fs.DurationVar(&o.discoverTimeout, "discover-timeout", 2*time.Second, "How long --discover waits for agents to answer")
+}
+
+// sendCommand returns aloha send, which sends one message and prints the reply
+func sendCommand() *cobra.Command {
+	o := newOptions()
+	cmd := newCommand(o, &cobra.Command{
+		Use:   "send [text...]",
+		Short: "Send a message to an agent and print the reply",
+		Long: `Send a message to an agent and print the reply. The arguments, --message, --file and
+--data build the message parts in order; without any, the message is read from stdin
+when it is piped. The exit status reflects how the task ended.`,
+		Example: `  aloha send "Roll a 20-sided dice"
+  aloha send --transport rest --stream --message "Is 17 prime?"
+  aloha send --message "Describe this image" --file ./photo.png
+  aloha send --session dice "Roll a dice"
+  aloha send --agents http://localhost:12001,http://localhost:13001 --route skills "Is 17 prime?"
+  aloha send --registry http://localhost:12100 --tags dice --route skills "Roll a dice"
+  aloha send --discover --agent "Dice Agent" "Roll a dice"
+  aloha send --push-listen :9000 "Roll a dice"
+  echo "Roll a 20-sided dice" | aloha send --quiet`,
+	})
+	// The arguments are text parts following those of the flags
+	preRun := cmd.PreRunE
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		for _, text := range args {
+			o.parts.Flag(partText).Set(text)
+		}
+		return preRun(cmd, args)
+	}
+
+	fs := cmd.Flags()
+	connectionFlags(fs, o)
+	quietFlags(fs, o)
+	messageFlags(fs, o)
+	sessionFlags(fs, o)
+	namedAgentFlags(fs, o)
+	fs.BoolVar(&o.follow, "follow", false, "Without --stream, poll a running task until it finishes before printing it")
+	fs.BoolVar(&o.nonBlocking, "non-blocking", false, "Ask the agent to answer a non-streaming send as soon as the task is created")
+	fs.IntVar(&o.historyLength, "history-length", -1, "Ask the agent to return at most this many history messages with the task (-1 returns all)")
+	fs.StringVar(&o.accept, "accept", "", "Comma-separated output modes the client accepts, e.g. text/plain")
+	fs.StringVar(&o.saveTranscript, "save-transcript", "", "Write the full exchange to this file (.md for Markdown, otherwise JSON)")
+	fs.StringVar(&o.pushListen, "push-listen", "", "Receive task updates as push notifications on this address, e.g. :9000")
+	fs.StringVar(&o.pushURL, "push-url", "", "Callback URL registered with the agent for --push-listen (default: derived from the listen address)")
+	fs.StringVar(&o.agents, "agents", "", "Comma-separated agent URLs to send the same message to concurrently")
+	fs.StringVar(&o.registryURL, "registry", "", "With --route, agent registry service to discover the candidate agents from")
+	fs.StringVar(&o.tags, "tags", "", "With --registry, only agents having a skill with each of these comma-separated tags")
+	fs.StringVar(&o.route, "route", "", "With --agents, --registry or --discover, send the message only to the best suited agent, chosen by skills or llm")
+	cli.BindEnv(fs, "registry", "ALOHA_REGISTRY")
+	return cmd
+}
+
+// chatCommand returns aloha chat, an interactive multi-turn conversation
+func chatCommand() *cobra.Command {
+	o := newOptions()
+	o.chat = true
+	cmd := newCommand(o, &cobra.Command{
+		Use:   "chat",
+		Short: "Talk to an agent interactively, each line read from stdin in the same conversation",
+		Long: `Talk to an agent interactively: each line read from stdin is sent in the same
+conversation. /reset starts a new conversation and /exit quits; with --agents-file or
+--discover, /agents lists the agents and /use <name> switches to one. --message, --file
+and --data are sent first.`,
+		Example: `  aloha chat --stream
+  aloha chat --session dice
+  aloha chat --agents-file agents.yaml --agent java-dice`,
+		Args: cobra.NoArgs,
+	})
+	fs := cmd.Flags()
+	connectionFlags(fs, o)
+	messageFlags(fs, o)
+	sessionFlags(fs, o)
+	namedAgentFlags(fs, o)
+	return cmd
+}
+
+// cardCommand returns aloha card, which displays the agent card
+func cardCommand() *cobra.Command {
+	o := newOptions()
+	o.inspectCard = true
+	cmd := newCommand(o, &cobra.Command{
+		Use:   "card",
+		Short: "Display the agent card: skills, capabilities, interfaces and security",
+		Example: `  aloha card
+  aloha card --validate --card-key card-signer.pem
+  aloha card --card-jwks https://keys.example.com/jwks.json
+  aloha card --output json --card-url https://agent.example.com`,
+		Args: cobra.NoArgs,
+	})
+	fs := cmd.Flags()
+	connectionFlags(fs, o)
+	fs.BoolVar(&o.validateCard, "validate", false, "Check the card's required fields; exits 1 on problems")
+	fs.StringVar(&o.cardKey, "card-key", "", "PEM public key or certificate to verify card signatures")
+	fs.StringArrayVar(&o.cardJWKS, "card-jwks", nil, "JWK set URL trusted for card signatures besides the card's origin (repeatable)")
+	return cmd
+}
+
+// taskCommand returns aloha task, whose subcommands get, cancel, list and watch tasks
+func taskCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "task",
+		Short: "Get, cancel, list or watch the agent's tasks",
+	}
+
+	get := newOptions()
+	getCmd := newCommand(get, &cobra.Command{
+		Use:   "get <task-id>",
+		Short: "Fetch a task",
+		Example: `  aloha task get <task-id>
+  aloha task get <task-id> --history-length 2`,
+		Args: cobra.ExactArgs(1),
+	})
+	getCmd.PreRunE = withArg(getCmd.PreRunE, &get.taskGet)
+	connectionFlags(getCmd.Flags(), get)
+	quietFlags(getCmd.Flags(), get)
+	getCmd.Flags().IntVar(&get.historyLength, "history-length", -1, "Return at most this many history messages with the task (-1 returns all)")
+
+	cancel := newOptions()
+	cancelCmd := newCommand(cancel, &cobra.Command{
+		Use:     "cancel <task-id>",
+		Short:   "Cancel a task",
+		Example: `  aloha task cancel --transport rest <task-id>`,
+		Args:    cobra.ExactArgs(1),
+	})
+	cancelCmd.PreRunE = withArg(cancelCmd.PreRunE, &cancel.taskCancel)
+	connectionFlags(cancelCmd.Flags(), cancel)
+	quietFlags(cancelCmd.Flags(), cancel)
+
+	list := newOptions()
+	list.taskList = true
+	listCmd := newCommand(list, &cobra.Command{
+		Use:   "list",
+		Short: "List tasks, newest first",
+		Example: `  aloha task list
+  aloha task list --session dice --state working`,
+		Args: cobra.NoArgs,
+	})
+	connectionFlags(listCmd.Flags(), list)
+	quietFlags(listCmd.Flags(), list)
+	sessionFlags(listCmd.Flags(), list)
+	listCmd.Flags().StringVar(&list.contextID, "context-id", "", "Only list the tasks of this context")
+	listCmd.Flags().StringVar(&list.taskState, "state", "", "Only list tasks in this state, e.g. working")
+
+	watch := newOptions()
+	watchCmd := newCommand(watch, &cobra.Command{
+		Use:     "watch <task-id>",
+		Short:   "Reattach to a running task and stream its events until it ends",
+		Example: `  aloha task watch <task-id>`,
+		Args:    cobra.ExactArgs(1),
+	})
+	watchCmd.PreRunE = withArg(watchCmd.PreRunE, &watch.resubscribe)
+	connectionFlags(watchCmd.Flags(), watch)
+	quietFlags(watchCmd.Flags(), watch)
+
+	cmd.AddCommand(getCmd, cancelCmd, listCmd, watchCmd)
+	return cmd
+}
+
+// withArg wraps a PreRunE to store the command's single argument in target first
+func withArg(preRun func(*cobra.Command, []string) error, target *string) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		*target = args[0]
+		return preRun(cmd, args)
+	}
+}
+
+// agentsCommand returns aloha agents, which lists the agents of a registry, a file or the local network
+func agentsCommand() *cobra.Command {
+	o := newOptions()
+	o.listAgents = true
+	cmd := newCommand(o, &cobra.Command{
+		Use:   "agents",
+		Short: "List the agents of a registry service, an agents file or the local network",
+		Example: `  aloha agents --registry http://localhost:12100 --tags dice
+  aloha agents --agents-file agents.yaml
+  aloha agents --discover`,
+		Args: cobra.NoArgs,
+	})
+	fs := cmd.Flags()
+	connectionFlags(fs, o)
+	fs.StringVar(&o.agentsFile, "agents-file", "", "YAML registry of known agents; their cards are resolved")
+	fs.BoolVar(&o.discover, "discover", false, "Discover the agents advertised with mDNS on the local network")
+	fs.DurationVar(&o.discoverTimeout, "discover-timeout", 2*time.Second, "How long --discover waits for agents to answer")
+	fs.StringVar(&o.registryURL, "registry", "", "Agent registry service to list the live agents of")
+	fs.StringVar(&o.tags, "tags", "", "With --registry, only agents having a skill with each of these comma-separated tags")
+	cli.BindEnv(fs, "registry", "ALOHA_REGISTRY")
+	return cmd
+}
+
+// benchCommand returns aloha bench, which benchmarks the agent
+func benchCommand() *cobra.Command {
+	o := newOptions()
+	o.bench = true
+	cmd := newCommand(o, &cobra.Command{
+		Use:     "bench",
+		Short:   "Benchmark the agent: latency percentiles, throughput and errors",
+		Example: `  aloha bench --concurrency 8 --requests 500 --message "Roll a dice"`,
+		Args:    cobra.NoArgs,
+	})
+	fs := cmd.Flags()
+	connectionFlags(fs, o)
+	messageFlags(fs, o)
+	fs.IntVar(&o.concurrency, "concurrency", 1, "Number of concurrent workers")
+	fs.IntVar(&o.requests, "requests", 100, "Total number of messages to send")
+	return cmd
+}
+
+// loadtestCommand returns aloha loadtest, which load tests the agent at a target rate
+func loadtestCommand() *cobra.Command {
+	o := newOptions()
+	o.loadtest = true
+	cmd := newCommand(o, &cobra.Command{
+		Use:   "loadtest",
+		Short: "Load test the agent at a target request rate: latency percentiles and errors overall and per interval",
+		Long: `Load test the agent at a target request rate. Requests are started on schedule
+whether or not earlier ones have returned, so a slow agent shows up as growing latency
+instead of a lower rate. --timeout bounds each request.`,
+		Example: `  aloha loadtest --rps 50 --ramp 30s --duration 2m --message "Roll a dice"
+  aloha loadtest --rps 20 --duration 1m --stream --output json --message "Roll a dice"`,
+		Args: cobra.NoArgs,
+	})
+	fs := cmd.Flags()
+	connectionFlags(fs, o)
+	messageFlags(fs, o)
+	fs.Float64Var(&o.rps, "rps", 10, "Target requests per second")
+	fs.DurationVar(&o.ramp, "ramp", 0, "Time to ramp linearly from 0 to --rps")
+	fs.DurationVar(&o.duration, "duration", 30*time.Second, "Time to hold --rps after the ramp")
+	fs.DurationVar(&o.reportInterval, "report-interval", 5*time.Second, "Width of the intervals reported")
+	fs.IntVar(&o.maxInflight, "max-inflight", 1000, "Requests in flight beyond which new ones are dropped")
+	return cmd
+}
+
+// conformanceCommand returns aloha conformance, which runs the conformance scenarios
+func conformanceCommand() *cobra.Command {
+	o := newOptions()
+	o.conformance = true
+	cmd := newCommand(o, &cobra.Command{
+		Use:     "conformance",
+		Short:   "Run the same scenarios over gRPC, JSON-RPC and REST and report pass/fail per transport",
+		Example: `  aloha conformance --output json`,
+		Args:    cobra.NoArgs,
+	})
+	fs := cmd.Flags()
+	connectionFlags(fs, o)
+	fs.Var(o.parts.Flag(partText), "message", "Text of the message the scenarios send")
+	return cmd
+}
+
+// interopCommand returns aloha interop, which checks the client against the agents of every language
+func interopCommand() *cobra.Command {
+	o := newOptions()
+	o.interop = true
+	cmd := newCommand(o, &cobra.Command{
+		Use:   "interop",
+		Short: "Check wire compatibility with the Java, Go, Python, JS and C# agents: cards, tasks, errors and SSE",
+		Long: `Check that this client and the agents of every language understand each other. Each
+agent gets the conformance scenarios over every transport its card declares, then raw
+checks of the JSON of its card, JSON-RPC responses and errors, and the framing of its SSE
+streams. Agents are expected on their JSON-RPC ports (11001 Java, 12001 Go, 13001 Python,
+14001 JS, 15001 C#); with --launch, those not running are started with their
+jsonrpc_server script and stopped afterwards. Agents not running are skipped.`,
+		Example: `  aloha interop
+  aloha interop --agents python,java --launch
+  aloha interop --target gateway=http://localhost:12400/agents/dice --output json`,
+		Args: cobra.NoArgs,
+	})
+	fs := cmd.Flags()
+	connectionFlags(fs, o)
+	fs.Var(o.parts.Flag(partText), "message", "Text of the message the checks send")
+	fs.StringSliceVar(&o.interopOpts.agents, "agents", nil, "Known agents to check: java, go, python, js, csharp (default: all, unless --target is given)")
+	fs.StringArrayVar(&o.interopOpts.targets, "target", nil, "Other agent to check, as name=card-url (repeatable)")
+	fs.BoolVar(&o.interopOpts.launch, "launch", false, "Start the known agents that are not running, and stop them afterwards")
+	fs.StringVar(&o.interopOpts.repoDir, "repo-dir", "", "Repository root holding the agents' scripts (default: found from the working directory)")
+	fs.DurationVar(&o.interopOpts.startupTimeout, "startup-timeout", 2*time.Minute, "How long a launched agent has to serve its card")
+	return cmd
+}
+
+// replayCommand returns aloha replay, which renders a recorded session offline
+func replayCommand() *cobra.Command {
+	o := newOptions()
+	cmd := newCommand(o, &cobra.Command{
+		Use:   "replay <file>",
+		Short: "Render a session captured with --record offline, without contacting an agent",
+		Example: `  aloha send --stream --record session.json "Roll a dice"
+  aloha replay session.json --verbose`,
+		Args: cobra.ExactArgs(1),
+	})
+	cmd.PreRunE = withArg(cmd.PreRunE, &o.replayPath)
+	outputFlags(cmd.Flags(), o)
+	return cmd
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"os"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/conformance"
+)
+
+// conformanceScenarioTimeout bounds each conformance scenario on each transport
+const conformanceScenarioTimeout = 30 * time.Second
+
+// conformanceTransports are the transports the conformance suite runs against, in report order
+var conformanceTransports = []string{"grpc", "jsonrpc", "rest"}
+
+// conformanceAgent adapts an agentClient to the conformance suite
+type conformanceAgent struct {
+	*agentClient
+}
+
+func (a *conformanceAgent) Card(ctx context.Context) (*a2a.AgentCard, error) {
+	return fetchExtendedCard(ctx, a.conn, a.agentClient), nil
+}
+
+func (a *conformanceAgent) GetTask(ctx context.Context, taskID a2a.TaskID) (*a2a.Task, error) {
+	return a.Client.GetTask(ctx, taskID, nil)
+}
+
+// runConformance handles --conformance: runs the conformance scenarios against every
+// transport the agent card declares and prints the pass/fail matrix. A transport the
+// client cannot connect to fails all its scenarios. Exits 1 if any scenario failed.
+func runConformance(ctx context.Context, conn *connection, cardURL, message string, out *outputWriter) {
+	card, err := conn.ResolveCard(ctx, cardURL)
+	if err != nil {
+		clientLogger.Fatal("Failed to resolve agent card from %s: %v", cardURL, err)
+	}
+	clientLogger.Info("Running conformance scenarios against %s (v%s)", card.Name, card.Version)
+
+	var agents []conformance.Agent
+	for _, transport := range conformanceTransports {
+		protocol, _ := transportProtocol(transport)
+		client, err := connectAgent(ctx, conn, card, transport)
+		if err != nil {
+			clientLogger.Warn("Cannot connect over %s: %v", transport, err)
+			agents = append(agents, unreachableAgent{protocol: protocol, err: err})
+			continue
+		}
+		defer client.Close()
+		agents = append(agents, &conformanceAgent{agentClient: client})
+	}
+
+	report := conformance.Run(ctx, agents, conformance.Scenarios(message), conformanceScenarioTimeout)
+	if !out.Text() {
+		if err := out.Write(report); err != nil {
+			clientLogger.Fatal("Failed to write output: %v", err)
+		}
+	} else {
+		report.Write(os.Stdout)
+	}
+
+	if failed := report.Failed(); failed > 0 {
+		clientLogger.Error("%d conformance checks failed", failed)
+		os.Exit(exitError)
+	}
+}
+
+// unreachableAgent stands for a transport the client could not connect to: every call fails
+type unreachableAgent struct {
+	protocol a2a.TransportProtocol
+	err      error
+}
+
+func (a unreachableAgent) Transport() a2a.TransportProtocol {
+	return a.protocol
+}
+
+func (a unreachableAgent) Card(ctx context.Context) (*a2a.AgentCard, error) {
+	return nil, a.err
+}
+
+func (a unreachableAgent) SendMessage(ctx context.Context, params *a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	return nil, a.err
+}
+
+func (a unreachableAgent) SendStreamingMessage(ctx context.Context, params *a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		yield(nil, a.err)
+	}
+}
+
+func (a unreachableAgent) GetTask(ctx context.Context, taskID a2a.TaskID) (*a2a.Task, error) {
+	return nil, a.err
+}
+
+func (a unreachableAgent) CancelTask(ctx context.Context, taskID a2a.TaskID) (*a2a.Task, error) {
+	return nil, a.err
+}
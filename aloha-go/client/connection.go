@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient/agentcard"
+	"github.com/aloha/a2a-go/pkg/protocol"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// connectionOptions configures the network behaviour of a connection
+type connectionOptions struct {
+	tlsConfig      *tls.Config // nil means plaintext
+	auth           authOptions
+	retry          retryPolicy
+	connectTimeout time.Duration // bounds establishing a connection; 0 keeps the defaults
+	reconnects     int           // resubscribe attempts when a stream drops mid-task
+	ignoreVersion  bool          // connect to agents with an incompatible protocol version
+	grpcAuthority  string        // overrides the gRPC :authority header when set
+	proxy          *proxySettings
+	verbose        bool // dump every request and response on the wire
+	record         bool // add every request and response to activeRecording
+}
+
+// connection holds the network settings shared by the agent card resolver and all transports
+type connection struct {
+	tlsConfig      *tls.Config
+	auth           authOptions
+	retry          retryPolicy
+	connectTimeout time.Duration
+	reconnects     int
+	ignoreVersion  bool
+	grpcAuthority  string
+	proxy          *proxySettings
+	verbose        bool
+	record         bool
+	httpClient     *http.Client
+}
+
+// newConnection creates the shared HTTP client and settings for all transports
+func newConnection(opts connectionOptions) *connection {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = opts.tlsConfig
+	transport.Proxy = opts.proxy.HTTPProxy
+	if opts.connectTimeout > 0 {
+		dialer := &net.Dialer{Timeout: opts.connectTimeout, KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+		transport.TLSHandshakeTimeout = opts.connectTimeout
+	}
+
+	conn := &connection{
+		tlsConfig:      opts.tlsConfig,
+		auth:           opts.auth,
+		retry:          opts.retry,
+		connectTimeout: opts.connectTimeout,
+		reconnects:     opts.reconnects,
+		ignoreVersion:  opts.ignoreVersion,
+		grpcAuthority:  opts.grpcAuthority,
+		proxy:          opts.proxy,
+		verbose:        opts.verbose,
+		record:         opts.record,
+	}
+	// Each retry attempt is observed separately, with the credential headers already attached
+	var base http.RoundTripper = transport
+	if opts.verbose || opts.record {
+		base = &wireTransport{base: transport, conn: conn, dump: opts.verbose}
+	}
+	conn.httpClient = &http.Client{Transport: &headerTransport{
+		base: &retryTransport{base: base, policy: opts.retry},
+		conn: conn,
+	}}
+	return conn
+}
+
+// Scheme returns the URL scheme for HTTP-based endpoints
+func (c *connection) Scheme() string {
+	if c.tlsConfig != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// ResolveCard fetches the agent card with the connection's credentials, checks that the
+// agent speaks a compatible protocol version and adapts the credentials to the security
+// schemes the card declares
+func (c *connection) ResolveCard(ctx context.Context, cardURL string) (*a2a.AgentCard, error) {
+	card, err := agentcard.NewResolver(c.httpClient).Resolve(ctx, cardURL)
+	if err != nil {
+		return nil, err
+	}
+	negotiation, err := protocol.NegotiateVersion(protocol.SupportedProtocolVersions, card.ProtocolVersion)
+	switch {
+	case err != nil && !c.ignoreVersion:
+		return nil, fmt.Errorf("agent %s: %w (use --ignore-version to connect anyway)", card.Name, err)
+	case err != nil:
+		clientLogger.Warn("Agent %s: %v", card.Name, err)
+	case negotiation.Warning != "":
+		clientLogger.Warn("Agent %s: %s", card.Name, negotiation.Warning)
+	}
+	c.auth = c.auth.matchSecuritySchemes(card)
+	return card, nil
+}
+
+// GRPCDialOptions returns the gRPC dial options matching the TLS, authentication, retry and timeout settings
+func (c *connection) GRPCDialOptions() []grpc.DialOption {
+	creds := insecure.NewCredentials()
+	if c.tlsConfig != nil {
+		creds = credentials.NewTLS(c.tlsConfig)
+	} else if !c.auth.Empty() {
+		clientLogger.Warn("Sending gRPC credentials over a plaintext channel; use --tls for production agents")
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(callCredentials{conn: c}),
+		grpc.WithChainUnaryInterceptor(c.retry.unaryInterceptor),
+	}
+	if c.grpcAuthority != "" {
+		opts = append(opts, grpc.WithAuthority(c.grpcAuthority))
+	}
+	if c.connectTimeout > 0 {
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{Backoff: backoff.DefaultConfig, MinConnectTimeout: c.connectTimeout}))
+	}
+	if c.verbose || c.record {
+		opts = append(opts, wireDialOptions(c.verbose)...)
+	}
+	return append(opts, c.proxy.GRPCDialOptions()...)
+}
+
+// callCredentials attaches the connection's credential headers to every gRPC call as metadata.
+// The headers are read per call, so an API key moved by card resolution is picked up.
+type callCredentials struct {
+	conn *connection
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+func (c callCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	headers := c.conn.auth.Headers()
+	md := make(map[string]string, len(headers))
+	for name := range headers {
+		md[strings.ToLower(name)] = headers.Get(name)
+	}
+	return md, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.
+// Plaintext is allowed so local development agents keep working; GRPCDialOptions warns instead.
+func (c callCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// headerTransport adds the connection's credential headers to every HTTP request
+type headerTransport struct {
+	base http.RoundTripper
+	conn *connection
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	headers := t.conn.auth.Headers()
+	if len(headers) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	for name, values := range headers {
+		if req.Header.Get(name) == "" {
+			req.Header[name] = values
+		}
+	}
+	return t.base.RoundTrip(req)
+}
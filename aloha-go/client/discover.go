@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/mdns"
+	"github.com/aloha/a2a-go/pkg/registry"
+)
+
+// localNetwork names the source of the agents found by --discover
+const localNetwork = "the local network"
+
+// discoverAgents looks up the live agents having every tag in the registry service,
+// returning their card URLs with the registrations
+func discoverAgents(ctx context.Context, registryURL string, tags []string) ([]string, []registry.Registration) {
+	// The registry is a separate service: agent credentials are not sent to it
+	agents, err := registry.NewClient(registryURL, &http.Client{}).Find(ctx, tags...)
+	if err != nil {
+		clientLogger.Fatal("Failed to query the agent registry at %s: %v", registryURL, err)
+	}
+	urls := make([]string, len(agents))
+	for i, agent := range agents {
+		urls[i] = agent.URL
+	}
+	clientLogger.Info("Found %d agents in the registry", len(agents))
+	return urls, agents
+}
+
+// printRegistrations lists the registered agents with their skill tags
+func printRegistrations(agents []registry.Registration, out *outputWriter) {
+	if !out.Text() {
+		if err := out.Write(agents); err != nil {
+			clientLogger.Fatal("Failed to write output: %v", err)
+		}
+		return
+	}
+	if len(agents) == 0 {
+		fmt.Println("No registered agents")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "AGENT\tURL\tTAGS\tEXPIRES")
+	for _, agent := range agents {
+		fmt.Fprintf(w, "%s\t%s\t%s\tin %s\n", agent.Card.Name, agent.URL, strings.Join(registry.Tags(agent.Card), ", "), time.Until(agent.ExpiresAt).Round(time.Second))
+	}
+	w.Flush()
+}
+
+// discoverLocalAgents browses the local network for agents advertised with mDNS during
+// wait, and returns them named by their instance names
+func discoverLocalAgents(ctx context.Context, wait time.Duration) []registryEntry {
+	found, err := mdns.Browse(ctx, wait)
+	if err != nil {
+		clientLogger.Fatal("mDNS discovery failed: %v", err)
+	}
+	if len(found) == 0 {
+		clientLogger.Warn("No agents found on %s within %s", localNetwork, wait)
+	}
+
+	entries := make([]registryEntry, 0, len(found))
+	seen := make(map[string]bool)
+	for _, agent := range found {
+		// Instance names are unique per host, so the host tells same-named agents apart
+		name := agent.Instance
+		if seen[name] {
+			name = fmt.Sprintf("%s (%s)", agent.Instance, agent.Host)
+		}
+		seen[name] = true
+		entries = append(entries, registryEntry{Name: name, URL: agent.CardURL()})
+	}
+	clientLogger.Info("Discovered %d agents on %s", len(entries), localNetwork)
+	return entries
+}
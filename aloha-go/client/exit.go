@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Exit statuses, so scripts can branch on how a request ended.
+// Only a completed task (or a direct message reply) exits with exitOK.
+const (
+	exitOK             = 0
+	exitError          = 1 // usage, configuration and agent-reported errors
+	exitTaskFailed     = 2
+	exitTaskCanceled   = 3
+	exitTaskRejected   = 4
+	exitTaskIncomplete = 5 // the task stopped in input-required, auth-required or a non-terminal state
+	exitTransport      = 6 // the agent could not be reached or the connection broke
+	exitTimeout        = 7 // --timeout or a transport deadline expired
+)
+
+// exitCodeForState maps the last observed task state to an exit status.
+// An empty state means the agent replied with a message instead of a task.
+func exitCodeForState(state a2a.TaskState) int {
+	switch state {
+	case "", a2a.TaskStateCompleted:
+		return exitOK
+	case a2a.TaskStateFailed:
+		return exitTaskFailed
+	case a2a.TaskStateCanceled:
+		return exitTaskCanceled
+	case a2a.TaskStateRejected:
+		return exitTaskRejected
+	default:
+		return exitTaskIncomplete
+	}
+}
+
+// exitCodeForError classifies the error that ended the run
+func exitCodeForError(err error) int {
+	if err == nil {
+		return exitError
+	}
+	if errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded {
+		return exitTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return exitTimeout
+		}
+		return exitTransport
+	}
+	if status.Code(err) == codes.Unavailable {
+		return exitTransport
+	}
+	return exitError
+}
+
+// firstError returns the first error among log arguments, or nil
+func firstError(args []interface{}) error {
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// exitForTaskState exits with the status for the last observed task state.
+// It returns only when that status is exitOK, so the caller can finish normally.
+func exitForTaskState() {
+	state := inflight.State()
+	if code := exitCodeForState(state); code != exitOK {
+		clientLogger.Info("Task ended in state %s, exiting with status %d", state, code)
+		os.Exit(code)
+	}
+}
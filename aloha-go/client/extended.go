@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// fetchExtendedCard retrieves the authenticated extended card when credentials were given
+// and the public card advertises one, and merges it into the public card.
+// The public card is returned unchanged otherwise, or if the extended card cannot be fetched.
+func fetchExtendedCard(ctx context.Context, conn *connection, client *agentClient) *a2a.AgentCard {
+	public := client.card
+	if conn.auth.Empty() || !public.SupportsAuthenticatedExtendedCard {
+		return public
+	}
+
+	clientLogger.Info("Fetching authenticated extended agent card")
+	extended, err := client.GetExtendedCard(ctx)
+	if err != nil {
+		clientLogger.Warn("Could not fetch extended agent card, using the public card: %v", err)
+		return public
+	}
+	return mergeExtendedCard(public, extended)
+}
+
+// mergeExtendedCard overlays the extended card on the public one. Fields set in the
+// extended card win; skills are the union of both, with the extended definition
+// replacing a public skill of the same ID.
+func mergeExtendedCard(public, extended *a2a.AgentCard) *a2a.AgentCard {
+	merged := *extended
+	if merged.Name == "" {
+		merged.Name = public.Name
+	}
+	if merged.Version == "" {
+		merged.Version = public.Version
+	}
+	if merged.URL == "" {
+		merged.URL, merged.PreferredTransport = public.URL, public.PreferredTransport
+	}
+	if len(merged.AdditionalInterfaces) == 0 {
+		merged.AdditionalInterfaces = public.AdditionalInterfaces
+	}
+	if len(merged.SecuritySchemes) == 0 {
+		merged.SecuritySchemes, merged.Security = public.SecuritySchemes, public.Security
+	}
+
+	overridden := make(map[string]bool, len(extended.Skills))
+	for _, skill := range extended.Skills {
+		overridden[skill.ID] = true
+	}
+	merged.Skills = nil
+	for _, skill := range public.Skills {
+		if !overridden[skill.ID] {
+			merged.Skills = append(merged.Skills, skill)
+		}
+	}
+	merged.Skills = append(merged.Skills, extended.Skills...)
+	return &merged
+}
+
+// extendedSkillIDs returns the IDs of skills in merged that the public card does not list
+func extendedSkillIDs(public, merged *a2a.AgentCard) map[string]bool {
+	known := make(map[string]bool, len(public.Skills))
+	for _, skill := range public.Skills {
+		known[skill.ID] = true
+	}
+	extended := make(map[string]bool)
+	for _, skill := range merged.Skills {
+		if !known[skill.ID] {
+			extended[skill.ID] = true
+		}
+	}
+	return extended
+}
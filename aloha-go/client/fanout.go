@@ -0,0 +1,232 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// fanoutResult summarizes one agent's answer in fan-out mode
+type fanoutResult struct {
+	Agent     string        `json:"agent"`
+	URL       string        `json:"url"`
+	Transport string        `json:"transport,omitempty"`
+	State     a2a.TaskState `json:"state,omitempty"`
+	LatencyMs int64         `json:"latencyMs"`
+	Events    int           `json:"events,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// fanoutEvent labels a streamed event with the agent it came from in structured output
+type fanoutEvent struct {
+	Agent string    `json:"agent"`
+	Event a2a.Event `json:"event"`
+}
+
+// fanoutPrinter serializes output from concurrent agents, one labeled line at a time
+type fanoutPrinter struct {
+	mu  sync.Mutex
+	out *outputWriter
+}
+
+// Print writes one line prefixed with the agent label
+func (p *fanoutPrinter) Print(label, line string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, l := range strings.Split(strings.TrimRight(line, "\n"), "\n") {
+		fmt.Printf("[%s] %s\n", label, l)
+	}
+}
+
+// Event renders a received event for the agent
+func (p *fanoutPrinter) Event(label string, event a2a.Event) {
+	if !p.out.Text() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if err := p.out.WriteEvent(fanoutEvent{Agent: label, Event: event}); err != nil {
+			clientLogger.Warn("Failed to write output: %v", err)
+		}
+		return
+	}
+	p.Print(label, describeEvent(event))
+}
+
+// runFanout sends the same message to several agents concurrently and prints a summary
+func runFanout(ctx context.Context, conn *connection, urls []string, transport string, params *a2a.MessageSendParams, stream bool, out *outputWriter) {
+	// Cards are resolved up front and in order: resolution adapts the shared credentials
+	cards := make([]*a2a.AgentCard, len(urls))
+	cardErrs := make([]error, len(urls))
+	for i, url := range urls {
+		cards[i], cardErrs[i] = conn.ResolveCard(ctx, url)
+	}
+
+	labels := fanoutLabels(urls, cards)
+	results := make([]fanoutResult, len(urls))
+	printer := &fanoutPrinter{out: out}
+
+	var wg sync.WaitGroup
+	for i, url := range urls {
+		results[i] = fanoutResult{Agent: labels[i], URL: url}
+		if cardErrs[i] != nil {
+			results[i].Error = fmt.Sprintf("failed to resolve agent card: %v", cardErrs[i])
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *fanoutResult, card *a2a.AgentCard) {
+			defer wg.Done()
+			fanoutSend(ctx, conn, card, transport, params, stream, result, printer)
+		}(&results[i], cards[i])
+	}
+	wg.Wait()
+
+	if !out.Text() {
+		if err := out.Write(results); err != nil {
+			clientLogger.Fatal("Failed to write output: %v", err)
+		}
+		return
+	}
+	printFanoutSummary(results)
+}
+
+// fanoutSend runs the exchange with one agent and fills in its result
+func fanoutSend(ctx context.Context, conn *connection, card *a2a.AgentCard, transport string, params *a2a.MessageSendParams, stream bool, result *fanoutResult, printer *fanoutPrinter) {
+	start := time.Now()
+	defer func() { result.LatencyMs = time.Since(start).Milliseconds() }()
+
+	client, err := connectAgent(ctx, conn, card, transport)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	defer client.Close()
+	result.Transport = client.transport
+
+	// Each agent gets its own copy; transports may fill in request fields
+	request := *params
+	if !stream {
+		response, err := client.SendMessage(ctx, &request)
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		result.Events = 1
+		result.State = resultState(response)
+		printer.Event(result.Agent, response)
+		return
+	}
+
+	for event, err := range client.SendStreamingMessage(ctx, &request) {
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		result.Events++
+		if state := resultState(event); state != "" {
+			result.State = state
+		}
+		printer.Event(result.Agent, event)
+	}
+}
+
+// fanoutLabels names each agent by its card name, falling back to the URL and de-duplicating
+func fanoutLabels(urls []string, cards []*a2a.AgentCard) []string {
+	labels := make([]string, len(urls))
+	seen := make(map[string]int)
+	for i, url := range urls {
+		label := url
+		if cards[i] != nil && cards[i].Name != "" {
+			label = cards[i].Name
+		}
+		seen[label]++
+		if n := seen[label]; n > 1 {
+			label = fmt.Sprintf("%s#%d", label, n)
+		}
+		labels[i] = label
+	}
+	return labels
+}
+
+// resultState returns the task state carried by an event, or "" if it has none
+func resultState(event a2a.Event) a2a.TaskState {
+	switch e := event.(type) {
+	case *a2a.Task:
+		return e.Status.State
+	case *a2a.TaskStatusUpdateEvent:
+		return e.Status.State
+	case *a2a.Message:
+		// A direct message reply completes the exchange without a task
+		return a2a.TaskStateCompleted
+	}
+	return ""
+}
+
+// describeEvent renders an event as a short single-line summary (multi-line text is kept)
+func describeEvent(event a2a.Event) string {
+	switch e := event.(type) {
+	case *a2a.Task:
+		var texts []string
+		for _, artifact := range e.Artifacts {
+			texts = append(texts, partsText(artifact.Parts))
+		}
+		if len(texts) == 0 {
+			return string(e.Status.State)
+		}
+		return fmt.Sprintf("%s: %s", e.Status.State, strings.Join(texts, " "))
+	case *a2a.TaskStatusUpdateEvent:
+		if e.Status.Message != nil {
+			return fmt.Sprintf("%s: %s", e.Status.State, partsText(e.Status.Message.Parts))
+		}
+		return string(e.Status.State)
+	case *a2a.TaskArtifactUpdateEvent:
+		return "artifact: " + partsText(e.Artifact.Parts)
+	case *a2a.Message:
+		return "message: " + partsText(e.Parts)
+	default:
+		return fmt.Sprintf("%T", event)
+	}
+}
+
+// partsText joins the text of text parts and names the other part kinds
+func partsText(parts []a2a.Part) string {
+	var texts []string
+	for _, part := range parts {
+		switch p := part.(type) {
+		case a2a.TextPart:
+			texts = append(texts, p.Text)
+		case a2a.FilePart:
+			texts = append(texts, "<file>")
+		case a2a.DataPart:
+			texts = append(texts, "<data>")
+		}
+	}
+	return strings.Join(texts, "")
+}
+
+// printFanoutSummary prints a table of latency and final state per agent
+func printFanoutSummary(results []fanoutResult) {
+	fmt.Println("\n============================================================")
+	fmt.Println("Fan-out Summary:")
+	fmt.Println("============================================================")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "AGENT\tTRANSPORT\tSTATE\tLATENCY\tEVENTS\tERROR")
+	for _, r := range results {
+		state := string(r.State)
+		if state == "" {
+			state = "-"
+		}
+		transport := r.Transport
+		if transport == "" {
+			transport = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%dms\t%d\t%s\n", r.Agent, transport, state, r.LatencyMs, r.Events, r.Error)
+	}
+	w.Flush()
+	fmt.Println("============================================================")
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Polling intervals for --follow: the delay doubles after each poll up to the maximum
+const (
+	followInitialInterval = 500 * time.Millisecond
+	followMaxInterval     = 5 * time.Second
+)
+
+// followTask polls tasks/get with backoff until a non-streaming send's task stops running.
+// Messages and tasks that are already settled are returned unchanged.
+func followTask(ctx context.Context, client *agentClient, result a2a.SendMessageResult) a2a.SendMessageResult {
+	task, ok := result.(*a2a.Task)
+	if !ok || settled(task.Status.State) {
+		return result
+	}
+
+	// Let Ctrl-C cancel the task while it is being followed
+	inflight.Observe(task)
+	interval := followInitialInterval
+	for !settled(task.Status.State) {
+		clientLogger.Info("Task %s is %s, polling again in %v", task.ID, task.Status.State, interval)
+		select {
+		case <-ctx.Done():
+			clientLogger.Fatal("Stopped following task %s: %v", task.ID, ctx.Err())
+		case <-time.After(interval):
+		}
+
+		polled, err := client.GetTask(ctx, task.ID, nil)
+		if err != nil {
+			clientLogger.Fatal("Failed to poll task %s: %v", task.ID, err)
+		}
+		task = polled
+		interval = min(interval*2, followMaxInterval)
+	}
+	clientLogger.Info("Task %s is %s", task.ID, task.Status.State)
+	return task
+}
+
+// settled reports whether a task will not progress without the client: it either reached
+// a terminal state or is waiting for input or authentication
+func settled(state a2a.TaskState) bool {
+	return state.Terminal() || state == a2a.TaskStateInputRequired || state == a2a.TaskStateAuthRequired
+}
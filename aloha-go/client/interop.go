@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/conformance"
+	"github.com/aloha/a2a-go/pkg/hostclient"
+	"github.com/aloha/a2a-go/pkg/interop"
+)
+
+// interopOptions selects the agents aloha interop checks and how it starts them
+type interopOptions struct {
+	agents         []string // names of known agents; empty checks them all unless targets are given
+	targets        []string // extra agents as name=card-url
+	launch         bool     // start the known agents that are not running
+	repoDir        string   // repository root holding the agents' scripts
+	startupTimeout time.Duration
+}
+
+// interopTargets returns the agents to check: the known agents named by --agents, or all
+// of them when neither --agents nor --target is given, followed by the --target agents
+func (o interopOptions) interopTargets() ([]interop.Target, error) {
+	known := interop.KnownTargets()
+	var targets []interop.Target
+	if len(o.agents) == 0 && len(o.targets) == 0 {
+		targets = known
+	}
+	for _, name := range o.agents {
+		i := slices.IndexFunc(known, func(t interop.Target) bool { return t.Name == name })
+		if i < 0 {
+			names := make([]string, len(known))
+			for j, t := range known {
+				names[j] = t.Name
+			}
+			return nil, fmt.Errorf("unknown agent %q (known: %s)", name, strings.Join(names, ", "))
+		}
+		targets = append(targets, known[i])
+	}
+	for _, target := range o.targets {
+		name, cardURL, ok := strings.Cut(target, "=")
+		if !ok || name == "" || cardURL == "" {
+			return nil, fmt.Errorf("invalid --target %q: expected name=card-url", target)
+		}
+		targets = append(targets, interop.Target{Name: name, CardURL: cardURL})
+	}
+	return targets, nil
+}
+
+// runInterop handles aloha interop: checks the Go client against the agents of every
+// language, launching those that are not running with --launch, and prints the check
+// matrix. An agent that is neither running nor launched is skipped. Exits 1 if any check
+// failed or no agent could be checked.
+func runInterop(conn *connection, opts interopOptions, message string, out *outputWriter) {
+	targets, err := opts.interopTargets()
+	if err != nil {
+		clientLogger.Fatal("%v", err)
+	}
+	if message == "" {
+		message = conformance.DefaultMessage
+	}
+
+	// Launched agents are stopped on the way out, including on an interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var launched []*interop.Process
+	stopAgents := func() {
+		for _, p := range launched {
+			p.Stop()
+		}
+		launched = nil
+	}
+	defer stopAgents()
+	OnFatal(func(string) { stopAgents() })
+
+	root := opts.repoDir
+	if opts.launch && root == "" {
+		if root, err = interop.FindRepoRoot("."); err != nil {
+			clientLogger.Fatal("Cannot launch agents: %v (use --repo-dir)", err)
+		}
+	}
+
+	report := &interop.Report{}
+	for _, target := range targets {
+		if ctx.Err() != nil {
+			break
+		}
+		start := time.Now()
+		if !interop.Reachable(ctx, target.CardURL) {
+			if !opts.launch || target.Script == "" {
+				clientLogger.Warn("Agent %s is not running at %s, skipping it", target.Name, target.CardURL)
+				report.Skip(target.Name, "not running at "+target.CardURL)
+				continue
+			}
+			clientLogger.Info("Launching agent %s...", target.Name)
+			p, err := interop.Launch(ctx, root, target, opts.startupTimeout)
+			if err != nil {
+				clientLogger.Error("%v", err)
+				report.Add(interop.Result{Agent: target.Name, Check: "reachable", Error: err.Error(), Duration: time.Since(start)})
+				continue
+			}
+			launched = append(launched, p)
+			clientLogger.Info("Agent %s is up, output in %s", target.Name, p.LogPath())
+		}
+		report.Add(interop.Result{Agent: target.Name, Check: "reachable", Passed: true, Duration: time.Since(start)})
+
+		clientLogger.Info("Checking agent %s at %s", target.Name, target.CardURL)
+		for _, result := range checkInteropAgent(ctx, conn, target, message) {
+			report.Add(result)
+		}
+	}
+	stopAgents()
+
+	if !out.Text() {
+		if err := out.Write(report); err != nil {
+			clientLogger.Fatal("Failed to write output: %v", err)
+		}
+	} else {
+		report.Write(os.Stdout)
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		clientLogger.Error("Interrupted")
+		os.Exit(exitInterrupted)
+	case report.Failed() > 0:
+		clientLogger.Error("%d interop checks failed", report.Failed())
+		os.Exit(exitError)
+	case report.Ran() == 0:
+		clientLogger.Error("No agent was checked: start them or use --launch")
+		os.Exit(exitError)
+	}
+}
+
+// checkInteropAgent runs the conformance scenarios over every transport the agent's card
+// declares, then the wire checks, and returns their results under the agent's name
+func checkInteropAgent(ctx context.Context, conn *connection, target interop.Target, message string) []interop.Result {
+	var results []interop.Result
+	start := time.Now()
+	card, err := conn.ResolveCard(ctx, target.CardURL)
+	if err != nil {
+		results = append(results, interop.Result{Agent: target.Name, Check: "card resolved", Error: err.Error(), Duration: time.Since(start)})
+	} else {
+		results = append(results, interop.Result{Agent: target.Name, Check: "card resolved", Passed: true, Duration: time.Since(start)})
+
+		// Transports the card does not declare are left out, and shown as "-"
+		var agents []conformance.Agent
+		for _, transport := range conformanceTransports {
+			protocol, _ := transportProtocol(transport)
+			if _, ok := hostclient.Endpoint(card, protocol); !ok {
+				continue
+			}
+			client, err := connectAgent(ctx, conn, card, transport)
+			if err != nil {
+				clientLogger.Warn("Cannot connect to %s over %s: %v", target.Name, transport, err)
+				agents = append(agents, unreachableAgent{protocol: protocol, err: err})
+				continue
+			}
+			defer client.Close()
+			agents = append(agents, &conformanceAgent{agentClient: client})
+		}
+		for _, result := range conformance.Run(ctx, agents, conformance.Scenarios(message), conformanceScenarioTimeout).Results {
+			results = append(results, interop.Result{
+				Agent:    target.Name,
+				Check:    interopCheckName(result.Scenario, result.Transport),
+				Passed:   result.Passed,
+				Error:    result.Error,
+				Duration: result.Duration,
+			})
+		}
+	}
+
+	wire := &interop.Wire{HTTP: conn.httpClient, CardURL: target.CardURL, Message: message}
+	return append(results, interop.RunWireChecks(ctx, wire, target.Name, conformanceScenarioTimeout)...)
+}
+
+// interopCheckName names a conformance scenario run over one transport, e.g. "send [JSONRPC]"
+func interopCheckName(scenario string, transport a2a.TransportProtocol) string {
+	return fmt.Sprintf("%s [%s]", scenario, transport)
+}
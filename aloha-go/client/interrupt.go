@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// interruptCancelTimeout bounds the tasks/cancel request sent when the client is interrupted
+const interruptCancelTimeout = 5 * time.Second
+
+// exitInterrupted is the conventional exit status after SIGINT
+const exitInterrupted = 130
+
+// inflightTask tracks the task the agent is working on for the current request
+type inflightTask struct {
+	mu    sync.Mutex
+	id    a2a.TaskID
+	state a2a.TaskState // last observed state, kept after the task ends
+}
+
+// inflight is the task an interrupt should cancel
+var inflight inflightTask
+
+// Observe updates the tracked task from a received event, forgetting it once it reaches a terminal state
+func (t *inflightTask) Observe(event a2a.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var state a2a.TaskState
+	switch e := event.(type) {
+	case *a2a.Task:
+		state = e.Status.State
+	case *a2a.TaskStatusUpdateEvent:
+		state = e.Status.State
+	}
+
+	if state != "" {
+		t.state = state
+	}
+	switch {
+	case state.Terminal():
+		t.id = ""
+	case event.TaskInfo().TaskID != "":
+		t.id = event.TaskInfo().TaskID
+	}
+}
+
+// ID returns the tracked task, or "" if none is in flight
+func (t *inflightTask) ID() a2a.TaskID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.id
+}
+
+// State returns the last observed task state, or "" if no task was seen
+func (t *inflightTask) State() a2a.TaskState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state
+}
+
+// handleInterrupts cancels the in-flight task on SIGINT/SIGTERM, then exits.
+// A second signal while the cancel is pending exits immediately.
+// The returned function, deferred by main, blocks until a pending interrupt has exited the process.
+func handleInterrupts(cancel func(ctx context.Context, taskID a2a.TaskID) error) func() {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	var interrupted atomic.Bool
+	go func() {
+		sig := <-signals
+		interrupted.Store(true)
+		clientLogger.Warn("Received %v, stopping", sig)
+		go func() {
+			<-signals
+			os.Exit(exitInterrupted)
+		}()
+
+		taskID := inflight.ID()
+		if taskID == "" {
+			clientLogger.Info("No task in flight to cancel")
+			os.Exit(exitInterrupted)
+		}
+
+		ctx, stop := context.WithTimeout(context.Background(), interruptCancelTimeout)
+		defer stop()
+		clientLogger.Info("Canceling task %s...", taskID)
+		if err := cancel(ctx, taskID); err != nil {
+			clientLogger.Warn("Failed to cancel task %s: %v", taskID, err)
+		} else {
+			clientLogger.Info("Task %s canceled", taskID)
+		}
+		os.Exit(exitInterrupted)
+	}()
+
+	return func() {
+		if interrupted.Load() {
+			select {}
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/protocol"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loadtestOptions configures an open-loop load test
+type loadtestOptions struct {
+	rps         float64       // target request rate once ramped up
+	ramp        time.Duration // time to ramp linearly from 0 to rps
+	duration    time.Duration // time spent at rps after the ramp
+	interval    time.Duration // width of the report intervals
+	maxInflight int           // requests in flight beyond which new ones are dropped
+	timeout     time.Duration // bounds each request; 0 leaves them unbounded
+}
+
+// loadtestReport summarizes a load test
+type loadtestReport struct {
+	Transport   string             `json:"transport"`
+	Streaming   bool               `json:"streaming"`
+	TargetRPS   float64            `json:"targetRps"`
+	RampMs      int64              `json:"rampMs"`
+	DurationMs  int64              `json:"durationMs"`
+	Sent        int                `json:"sent"`
+	Succeeded   int                `json:"succeeded"`
+	Failed      int                `json:"failed"`
+	Dropped     int                `json:"dropped"`
+	AchievedRPS float64            `json:"achievedRps"`
+	LatencyMs   benchLatency       `json:"latencyMs"`
+	ErrorKinds  map[string]int     `json:"errorKinds,omitempty"`
+	Errors      map[string]int     `json:"errors,omitempty"`
+	Intervals   []loadtestInterval `json:"intervals"`
+}
+
+// loadtestInterval summarizes the requests sent during one report interval
+type loadtestInterval struct {
+	StartMs    int64        `json:"startMs"`
+	TargetRPS  float64      `json:"targetRps"`
+	Sent       int          `json:"sent"`
+	Succeeded  int          `json:"succeeded"`
+	Failed     int          `json:"failed"`
+	Dropped    int          `json:"dropped"`
+	Throughput float64      `json:"throughputPerSec"`
+	LatencyMs  benchLatency `json:"latencyMs"`
+}
+
+// loadtestSample is the outcome of one scheduled request
+type loadtestSample struct {
+	sentAt  time.Duration // since the start of the test
+	latency time.Duration
+	err     error
+	dropped bool
+}
+
+// rateAt returns the target request rate at elapsed time t
+func (o loadtestOptions) rateAt(t time.Duration) float64 {
+	if o.ramp > 0 && t < o.ramp {
+		return o.rps * t.Seconds() / o.ramp.Seconds()
+	}
+	return o.rps
+}
+
+// sendTime returns when the n-th request (from 0) is due, following a linear ramp from 0 to rps
+// and then a constant rate, and false once the test is over
+func (o loadtestOptions) sendTime(n int) (time.Duration, bool) {
+	rampRequests := o.rps * o.ramp.Seconds() / 2
+	var seconds float64
+	if float64(n) < rampRequests {
+		// The ramp sends rps*t²/(2*ramp) requests by time t
+		seconds = math.Sqrt(2 * float64(n) * o.ramp.Seconds() / o.rps)
+	} else {
+		seconds = o.ramp.Seconds() + (float64(n)-rampRequests)/o.rps
+	}
+	at := time.Duration(seconds * float64(time.Second))
+	return at, at < o.ramp+o.duration
+}
+
+// runLoadtest sends messages at a target rate, independently of how fast the agent answers,
+// ramping up to it first, and reports latency and errors overall and per interval
+func runLoadtest(ctx context.Context, client *agentClient, parts []protocol.Part, contextID string, stream bool, opts loadtestOptions, out *outputWriter) {
+	if opts.rps <= 0 || opts.duration <= 0 || opts.ramp < 0 || opts.interval <= 0 || opts.maxInflight < 1 {
+		clientLogger.Fatal("--rps, --duration, --report-interval and --max-inflight must be positive and --ramp must not be negative")
+	}
+	clientLogger.Info("Load testing %s: %.1f req/s for %s after a %s ramp (streaming=%v)", client.transport, opts.rps, opts.duration, opts.ramp, stream)
+
+	var (
+		mu       sync.Mutex
+		samples  []loadtestSample
+		inflight = make(chan struct{}, opts.maxInflight)
+		wg       sync.WaitGroup
+	)
+	record := func(sample loadtestSample) {
+		mu.Lock()
+		samples = append(samples, sample)
+		mu.Unlock()
+	}
+
+	start := time.Now()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+schedule:
+	for n := 0; ; n++ {
+		at, ok := opts.sendTime(n)
+		if !ok {
+			break
+		}
+		timer.Reset(time.Until(start.Add(at)))
+		select {
+		case <-ctx.Done():
+			break schedule
+		case <-timer.C:
+		}
+
+		// Open loop: a request that would exceed the in-flight limit is dropped, not delayed
+		select {
+		case inflight <- struct{}{}:
+		default:
+			record(loadtestSample{sentAt: at, dropped: true})
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-inflight }()
+			reqCtx, cancel := ctx, context.CancelFunc(func() {})
+			if opts.timeout > 0 {
+				reqCtx, cancel = context.WithTimeout(ctx, opts.timeout)
+			}
+			defer cancel()
+			msg := protocol.NewUserMessage(parts...).InContext(contextID).SDK()
+			latency, err := benchRequest(reqCtx, client, &a2a.MessageSendParams{Message: msg}, stream)
+			record(loadtestSample{sentAt: at, latency: latency, err: err})
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	report := loadtestReport{
+		Transport:  client.transport,
+		Streaming:  stream,
+		TargetRPS:  opts.rps,
+		RampMs:     opts.ramp.Milliseconds(),
+		DurationMs: opts.duration.Milliseconds(),
+		ErrorKinds: make(map[string]int),
+		Errors:     make(map[string]int),
+	}
+	var latencies []time.Duration
+	for _, sample := range samples {
+		switch {
+		case sample.dropped:
+			report.Dropped++
+		case sample.err != nil:
+			report.Failed++
+			report.ErrorKinds[loadtestErrorKind(sample.err)]++
+			report.Errors[sample.err.Error()]++
+		default:
+			report.Succeeded++
+			latencies = append(latencies, sample.latency)
+		}
+	}
+	report.Sent = report.Succeeded + report.Failed
+	report.AchievedRPS = float64(report.Succeeded) / elapsed.Seconds()
+	report.LatencyMs = latencyStats(latencies)
+	report.Intervals = loadtestIntervals(samples, opts)
+
+	if !out.Text() {
+		if err := out.Write(report); err != nil {
+			clientLogger.Fatal("Failed to write output: %v", err)
+		}
+		return
+	}
+	printLoadtestReport(report)
+}
+
+// loadtestIntervals groups the samples by the interval their request was due in
+func loadtestIntervals(samples []loadtestSample, opts loadtestOptions) []loadtestInterval {
+	count := int(math.Ceil(float64(opts.ramp+opts.duration) / float64(opts.interval)))
+	intervals := make([]loadtestInterval, count)
+	latencies := make([][]time.Duration, count)
+	for i := range intervals {
+		intervals[i].StartMs = (time.Duration(i) * opts.interval).Milliseconds()
+		intervals[i].TargetRPS = opts.rateAt(time.Duration(i+1)*opts.interval - 1)
+	}
+	for _, sample := range samples {
+		i := min(int(sample.sentAt/opts.interval), count-1)
+		switch {
+		case sample.dropped:
+			intervals[i].Dropped++
+		case sample.err != nil:
+			intervals[i].Sent++
+			intervals[i].Failed++
+		default:
+			intervals[i].Sent++
+			intervals[i].Succeeded++
+			latencies[i] = append(latencies[i], sample.latency)
+		}
+	}
+	for i := range intervals {
+		intervals[i].Throughput = float64(intervals[i].Succeeded) / opts.interval.Seconds()
+		intervals[i].LatencyMs = latencyStats(latencies[i])
+	}
+	return intervals
+}
+
+// taskStateError reports a request whose task ended in a state other than completed
+type taskStateError struct {
+	state a2a.TaskState
+}
+
+func (e *taskStateError) Error() string {
+	return fmt.Sprintf("task ended in state %q", e.state)
+}
+
+// loadtestErrorKinds names the agent errors counted in the load test error breakdown
+var loadtestErrorKinds = []struct {
+	err  error
+	kind string
+}{
+	{a2a.ErrUnauthenticated, "unauthenticated"},
+	{a2a.ErrUnauthorized, "unauthorized"},
+	{a2a.ErrInvalidParams, "invalid params"},
+	{a2a.ErrInvalidRequest, "invalid request"},
+	{a2a.ErrInternalError, "internal error"},
+	{a2a.ErrUnsupportedOperation, "unsupported operation"},
+	{a2a.ErrTaskNotFound, "task not found"},
+}
+
+// loadtestErrorKind classifies a failed request: timeout, transport, an agent error
+// or the state its task ended in
+func loadtestErrorKind(err error) string {
+	var stateErr *taskStateError
+	if errors.As(err, &stateErr) {
+		return "task " + string(stateErr.state)
+	}
+	switch exitCodeForError(err) {
+	case exitTimeout:
+		return "timeout"
+	case exitTransport:
+		return "transport"
+	}
+	for _, known := range loadtestErrorKinds {
+		if errors.Is(err, known.err) {
+			return known.kind
+		}
+	}
+	if s, ok := status.FromError(err); ok && s.Code() != codes.Unknown {
+		return "grpc " + s.Code().String()
+	}
+	return "other"
+}
+
+// printLoadtestReport prints the load test results
+func printLoadtestReport(r loadtestReport) {
+	fmt.Println("\n============================================================")
+	fmt.Println("Load Test Results:")
+	fmt.Println("============================================================")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Transport:\t%s (streaming=%v)\n", r.Transport, r.Streaming)
+	fmt.Fprintf(w, "Target:\t%.1f req/s for %dms after a %dms ramp\n", r.TargetRPS, r.DurationMs, r.RampMs)
+	fmt.Fprintf(w, "Requests:\t%d sent (%d succeeded, %d failed), %d dropped over --max-inflight\n", r.Sent, r.Succeeded, r.Failed, r.Dropped)
+	fmt.Fprintf(w, "Achieved:\t%.1f req/s\n", r.AchievedRPS)
+	fmt.Fprintf(w, "Latency:\tmin %.1fms  mean %.1fms  p50 %.1fms  p95 %.1fms  p99 %.1fms  max %.1fms\n",
+		r.LatencyMs.Min, r.LatencyMs.Mean, r.LatencyMs.P50, r.LatencyMs.P95, r.LatencyMs.P99, r.LatencyMs.Max)
+	w.Flush()
+
+	fmt.Println("\nIntervals:")
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "START\tTARGET/s\tSENT\tOK\tFAILED\tDROPPED\tOK/s\tP50ms\tP95ms\tP99ms\t")
+	for _, i := range r.Intervals {
+		fmt.Fprintf(w, "%.1fs\t%.1f\t%d\t%d\t%d\t%d\t%.1f\t%.1f\t%.1f\t%.1f\t\n",
+			float64(i.StartMs)/1000, i.TargetRPS, i.Sent, i.Succeeded, i.Failed, i.Dropped, i.Throughput, i.LatencyMs.P50, i.LatencyMs.P95, i.LatencyMs.P99)
+	}
+	w.Flush()
+
+	if len(r.ErrorKinds) > 0 {
+		fmt.Println("\nErrors by kind:")
+		printCounts(r.ErrorKinds)
+		fmt.Println("\nErrors:")
+		printCounts(r.Errors)
+	}
+	fmt.Println("============================================================")
+}
+
+// printCounts prints counts, largest first
+func printCounts(counts map[string]int) {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return counts[keys[i]] > counts[keys[j]] })
+	for _, key := range keys {
+		fmt.Printf("  %6d  %s\n", counts[key], key)
+	}
+}
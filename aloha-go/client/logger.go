@@ -1,4 +1,4 @@
-package main
+package client
 
 import (
 	"fmt"
@@ -12,6 +12,17 @@ import (
 // logFile holds the open log file handle (if any) so all loggers share the same file.
 var logFile *os.File
 
+// quietLogs keeps DEBUG and INFO messages off stderr (--quiet); they still reach the log file.
+var quietLogs bool
+
+// fatalHooks run with the error message before Fatal exits.
+var fatalHooks []func(msg string)
+
+// OnFatal registers a function to run before Fatal exits, e.g. to save partial results.
+func OnFatal(hook func(msg string)) {
+	fatalHooks = append(fatalHooks, hook)
+}
+
 // InitLogFile sets up file-based logging for the Go client.
 // It writes to D:\coding\aloha-a2a\aloha-log\go-client-{transport}.log (on Windows)
 // Output goes to both stderr and the log file.
@@ -30,7 +41,7 @@ func InitLogFile(transport string) {
 	}
 	logFile = f
 	log.SetOutput(io.MultiWriter(os.Stderr, f))
-	log.Printf("client - INFO - Log file: %s", filename)
+	clientLogger.Info("Log file: %s", filename)
 }
 
 // resolveLogDir returns the aloha-log directory path.
@@ -62,30 +73,47 @@ func (l *Logger) format(level, msg string) string {
 
 // Debug logs a DEBUG level message.
 func (l *Logger) Debug(format string, args ...interface{}) {
-	log.Printf(l.format("DEBUG", fmt.Sprintf(format, args...)))
+	l.printQuietable(l.format("DEBUG", fmt.Sprintf(format, args...)))
 }
 
 // Info logs an INFO level message.
 func (l *Logger) Info(format string, args ...interface{}) {
-	log.Printf(l.format("INFO", fmt.Sprintf(format, args...)))
+	l.printQuietable(l.format("INFO", fmt.Sprintf(format, args...)))
+}
+
+// printQuietable logs a message that --quiet sends to the log file only
+func (l *Logger) printQuietable(msg string) {
+	if !quietLogs {
+		log.Print(msg)
+		return
+	}
+	if logFile != nil {
+		log.New(logFile, "", log.LstdFlags).Print(msg)
+	}
 }
 
 // Warn logs a WARN level message.
 func (l *Logger) Warn(format string, args ...interface{}) {
-	log.Printf(l.format("WARN", fmt.Sprintf(format, args...)))
+	log.Print(l.format("WARN", fmt.Sprintf(format, args...)))
 }
 
 // Error logs an ERROR level message.
 func (l *Logger) Error(format string, args ...interface{}) {
-	log.Printf(l.format("ERROR", fmt.Sprintf(format, args...)))
+	log.Print(l.format("ERROR", fmt.Sprintf(format, args...)))
 }
 
-// Fatal logs an ERROR level message and exits.
+// Fatal logs an ERROR level message, runs the OnFatal hooks and exits.
+// The exit status is derived from the first error argument (see exitCodeForError).
 func (l *Logger) Fatal(format string, args ...interface{}) {
-	log.Fatalf(l.format("ERROR", fmt.Sprintf(format, args...)))
+	msg := fmt.Sprintf(format, args...)
+	for _, hook := range fatalHooks {
+		hook(msg)
+	}
+	log.Print(l.format("ERROR", msg))
+	os.Exit(exitCodeForError(firstError(args)))
 }
 
 // Println logs an INFO level message.
 func (l *Logger) Println(msg string) {
-	log.Printf(l.format("INFO", msg))
+	l.printQuietable(l.format("INFO", msg))
 }
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// probeTimeout bounds each reachability check during transport negotiation
+const probeTimeout = 2 * time.Second
+
+// transportNames maps agent card transport protocols to --transport values
+var transportNames = map[a2a.TransportProtocol]string{
+	a2a.TransportProtocolGRPC:     "grpc",
+	a2a.TransportProtocolJSONRPC:  "jsonrpc",
+	a2a.TransportProtocolHTTPJSON: "rest",
+}
+
+// transportProtocol returns the agent card protocol for a --transport name
+func transportProtocol(name string) (a2a.TransportProtocol, bool) {
+	for protocol, n := range transportNames {
+		if n == name {
+			return protocol, true
+		}
+	}
+	return "", false
+}
+
+// negotiateTransport picks the first reachable interface declared by the agent card,
+// trying the preferred transport first and then the additional interfaces in order.
+// It returns the --transport name and the endpoint URL to use.
+func negotiateTransport(ctx context.Context, card *a2a.AgentCard) (string, string, error) {
+	preferred := card.PreferredTransport
+	if preferred == "" {
+		preferred = a2a.TransportProtocolJSONRPC
+	}
+	candidates := append([]a2a.AgentInterface{{Transport: preferred, URL: card.URL}}, card.AdditionalInterfaces...)
+
+	var errs []string
+	for _, candidate := range candidates {
+		name, supported := transportNames[candidate.Transport]
+		if !supported {
+			clientLogger.Debug("Skipping unsupported transport %s at %s", candidate.Transport, candidate.URL)
+			continue
+		}
+		if err := probeEndpoint(ctx, candidate.URL); err != nil {
+			clientLogger.Warn("Transport %s at %s is unreachable: %v", candidate.Transport, candidate.URL, err)
+			errs = append(errs, fmt.Sprintf("%s (%s): %v", candidate.Transport, candidate.URL, err))
+			continue
+		}
+		clientLogger.Info("Negotiated transport %s at %s", candidate.Transport, candidate.URL)
+		return name, candidate.URL, nil
+	}
+
+	return "", "", fmt.Errorf("no reachable transport in agent card: %s", strings.Join(errs, "; "))
+}
+
+// probeEndpoint checks that a TCP connection can be opened to the endpoint host
+func probeEndpoint(ctx context.Context, endpoint string) error {
+	address, err := endpointAddress(endpoint)
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: probeTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// endpointAddress converts an interface URL ("http://host:port/path" or gRPC "host:port") to host:port
+func endpointAddress(endpoint string) (string, error) {
+	if !strings.Contains(endpoint, "://") {
+		return endpoint, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint URL %q: %w", endpoint, err)
+	}
+	if u.Port() != "" {
+		return u.Host, nil
+	}
+	if u.Scheme == "https" {
+		return net.JoinHostPort(u.Hostname(), "443"), nil
+	}
+	return net.JoinHostPort(u.Hostname(), "80"), nil
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported --output formats
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+	// outputQuiet is selected by --quiet: only the agent's final text is written
+	outputQuiet = "quiet"
+)
+
+// outputWriter renders agent responses in the selected output format.
+// Structured formats write the A2A wire representation to stdout; logs stay on stderr.
+type outputWriter struct {
+	format string
+	w      io.Writer
+	events int
+	final  *finalText // collects streamed events in quiet mode
+}
+
+// newOutputWriter creates a writer for the given format
+func newOutputWriter(format string) (*outputWriter, error) {
+	switch format {
+	case outputText, outputJSON, outputYAML:
+		return &outputWriter{format: format, w: os.Stdout}, nil
+	case outputQuiet:
+		return &outputWriter{format: format, w: os.Stdout, final: newFinalText()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q (use text, json, or yaml)", format)
+	}
+}
+
+// Text reports whether the human-readable format is selected
+func (o *outputWriter) Text() bool {
+	return o.format == outputText
+}
+
+// Structured reports whether a machine-readable format, JSON or YAML, is selected
+func (o *outputWriter) Structured() bool {
+	return o.format == outputJSON || o.format == outputYAML
+}
+
+// Write renders a complete result as one document
+func (o *outputWriter) Write(v any) error {
+	switch o.format {
+	case outputJSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal output: %w", err)
+		}
+		_, err = fmt.Fprintln(o.w, string(data))
+		return err
+	case outputYAML:
+		return o.writeYAML(v)
+	case outputQuiet:
+		if event, ok := v.(a2a.Event); ok {
+			o.final.Add(event)
+		}
+		return o.Flush()
+	default:
+		_, err := fmt.Fprintln(o.w, v)
+		return err
+	}
+}
+
+// WriteEvent renders one streaming event: a line of NDJSON, or a YAML document
+func (o *outputWriter) WriteEvent(v any) error {
+	defer func() { o.events++ }()
+
+	switch o.format {
+	case outputJSON:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+		_, err = fmt.Fprintln(o.w, string(data))
+		return err
+	case outputYAML:
+		if o.events > 0 {
+			if _, err := fmt.Fprintln(o.w, "---"); err != nil {
+				return err
+			}
+		}
+		return o.writeYAML(v)
+	case outputQuiet:
+		if event, ok := v.(a2a.Event); ok {
+			o.final.Add(event)
+		}
+		return nil
+	default:
+		_, err := fmt.Fprintln(o.w, v)
+		return err
+	}
+}
+
+// Flush writes the final text collected in quiet mode; other formats write as they go
+func (o *outputWriter) Flush() error {
+	if o.final == nil {
+		return nil
+	}
+	text := o.final.Text()
+	o.final = newFinalText()
+	if text == "" {
+		return nil
+	}
+	_, err := fmt.Fprintln(o.w, text)
+	return err
+}
+
+// writeYAML converts v through its JSON form so the YAML mirrors the A2A wire format
+func (o *outputWriter) writeYAML(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return fmt.Errorf("failed to convert output: %w", err)
+	}
+	encoder := yaml.NewEncoder(o.w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(generic); err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return encoder.Close()
+}
+
+// finalText tracks the agent's answer across events: the text of the task's artifacts,
+// or of its latest status message (or a direct message reply) when there are none
+type finalText struct {
+	assembler *artifactAssembler
+	artifacts []*a2a.Artifact
+	message   *a2a.Message
+}
+
+// newFinalText creates an empty collector
+func newFinalText() *finalText {
+	return &finalText{assembler: newArtifactAssembler()}
+}
+
+// Add records one result or streamed event
+func (f *finalText) Add(event a2a.Event) {
+	switch e := event.(type) {
+	case *a2a.Task:
+		f.artifacts = e.Artifacts
+		if e.Status.Message != nil {
+			f.message = e.Status.Message
+		}
+	case *a2a.Message:
+		f.message = e
+	case *a2a.TaskStatusUpdateEvent:
+		if e.Status.Message != nil {
+			f.message = e.Status.Message
+		}
+	case *a2a.TaskArtifactUpdateEvent:
+		if artifact, complete := f.assembler.Add(e); complete {
+			f.artifacts = append(f.artifacts, artifact)
+		}
+	}
+}
+
+// Text returns the collected answer
+func (f *finalText) Text() string {
+	artifacts := append(f.artifacts, f.assembler.Flush()...)
+	var texts []string
+	for _, artifact := range artifacts {
+		texts = append(texts, joinTextParts(artifact.Parts))
+	}
+	if len(artifacts) == 0 && f.message != nil {
+		texts = append(texts, joinTextParts(f.message.Parts))
+	}
+	return strings.TrimSpace(strings.Join(texts, "\n"))
+}
+
+// joinTextParts joins the text parts of a message or artifact, merging chunked text
+func joinTextParts(parts a2a.ContentParts) string {
+	var texts []string
+	for _, part := range appendParts(nil, parts) {
+		if text, ok := part.(a2a.TextPart); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+	"github.com/spf13/pflag"
+)
+
+// Kinds of message part given on the command line
+const (
+	partText = "text"
+	partFile = "file"
+	partData = "data"
+)
+
+// partSpec is one --message, --file or --data value
+type partSpec struct {
+	kind  string
+	value string
+}
+
+// messageParts collects --message, --file and --data values in command-line order,
+// so the parts of the sent message follow the order the flags were given in
+type messageParts []partSpec
+
+// Flag returns the pflag.Value that appends values of the given kind
+func (m *messageParts) Flag(kind string) pflag.Value {
+	return &partFlag{kind: kind, parts: m}
+}
+
+// Empty reports whether no part was given
+func (m messageParts) Empty() bool {
+	return len(m) == 0
+}
+
+// Text returns the text parts joined by newlines, for the startup banner
+func (m messageParts) Text() string {
+	var texts []string
+	for _, spec := range m {
+		if spec.kind == partText {
+			texts = append(texts, spec.value)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// ReadsStdin reports whether a --message - part reads its text from stdin
+func (m messageParts) ReadsStdin() bool {
+	for _, spec := range m {
+		if spec.kind == partText && spec.value == stdinMessage {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveStdin replaces the --message - part with text, or appends text when no part was given.
+// stdin can only be read once, so at most one --message - is accepted.
+func (m *messageParts) ResolveStdin(text string) error {
+	resolved := false
+	for i, spec := range *m {
+		if spec.kind != partText || spec.value != stdinMessage {
+			continue
+		}
+		if resolved {
+			return fmt.Errorf("--message - can only be given once")
+		}
+		(*m)[i].value = text
+		resolved = true
+	}
+	if !resolved {
+		*m = append(*m, partSpec{kind: partText, value: text})
+	}
+	return nil
+}
+
+// Build turns the collected values into A2A parts, in order
+func (m messageParts) Build() ([]protocol.Part, error) {
+	parts := make([]protocol.Part, 0, len(m))
+	for _, spec := range m {
+		switch spec.kind {
+		case partText:
+			parts = append(parts, protocol.NewTextPart(spec.value))
+		case partFile:
+			part, err := newFilePart(spec.value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to attach file: %w", err)
+			}
+			parts = append(parts, part)
+		case partData:
+			part, err := newDataPart(spec.value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --data: %w", err)
+			}
+			parts = append(parts, part)
+		}
+	}
+	return parts, nil
+}
+
+// partFlag is the pflag.Value behind --message, --file and --data
+type partFlag struct {
+	kind  string
+	parts *messageParts
+}
+
+// String implements pflag.Value
+func (f *partFlag) String() string {
+	if f.parts == nil {
+		return ""
+	}
+	var values []string
+	for _, spec := range *f.parts {
+		if spec.kind == f.kind {
+			values = append(values, spec.value)
+		}
+	}
+	return strings.Join(values, ",")
+}
+
+// Set implements pflag.Value
+func (f *partFlag) Set(value string) error {
+	*f.parts = append(*f.parts, partSpec{kind: f.kind, value: value})
+	return nil
+}
+
+// Type implements pflag.Value, naming the value in help
+func (f *partFlag) Type() string {
+	return f.kind
+}
+
+// stdinMessage is the --message value that reads the message text from stdin
+const stdinMessage = "-"
+
+// readStdinMessage reads the whole message text from stdin, keeping inner newlines
+// but dropping the trailing newline most tools append
+func readStdinMessage() (string, error) {
+	if !stdinPiped() {
+		clientLogger.Info("Reading message from stdin, end with Ctrl-D")
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read message from stdin: %w", err)
+	}
+	text := strings.TrimSuffix(string(data), "\n")
+	return strings.TrimSuffix(text, "\r"), nil
+}
+
+// stdinPiped reports whether stdin is a pipe or a file rather than a terminal
+func stdinPiped() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
+
+// newFilePart builds a FilePart from a local path or a remote URI.
+// Local files are inlined as base64 bytes; http(s) and other URIs are passed by reference.
+func newFilePart(source string) (protocol.Part, error) {
+	if isRemoteURI(source) {
+		u, _ := url.Parse(source)
+		name := path.Base(u.Path)
+		return protocol.NewFileURIPart(name, mime.TypeByExtension(path.Ext(name)), source), nil
+	}
+
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return protocol.Part{}, fmt.Errorf("failed to read file %s: %w", source, err)
+	}
+	name := filepath.Base(source)
+	clientLogger.Info("Attaching file %s (%d bytes)", name, len(data))
+
+	return protocol.NewFileBytesPart(name, detectMimeType(name, data), data), nil
+}
+
+// newDataPart builds a DataPart from a JSON object given inline or as @path to a JSON file
+func newDataPart(raw string) (protocol.Part, error) {
+	data := []byte(raw)
+	if source, ok := strings.CutPrefix(raw, "@"); ok {
+		var err error
+		if data, err = os.ReadFile(source); err != nil {
+			return protocol.Part{}, fmt.Errorf("failed to read data file %s: %w", source, err)
+		}
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal(data, &object); err != nil {
+		return protocol.Part{}, fmt.Errorf("data must be a JSON object: %w", err)
+	}
+	if object == nil {
+		return protocol.Part{}, fmt.Errorf("data must be a JSON object, got null")
+	}
+	return protocol.NewDataPart(object), nil
+}
+
+// isRemoteURI reports whether source is a URI with a scheme rather than a local path
+func isRemoteURI(source string) bool {
+	u, err := url.Parse(source)
+	// Single-letter schemes are Windows drive letters such as C:\
+	return err == nil && len(u.Scheme) > 1 && u.Host != ""
+}
+
+// detectMimeType guesses the MIME type from the file extension, falling back to content sniffing
+func detectMimeType(name string, data []byte) string {
+	if mimeType := mime.TypeByExtension(filepath.Ext(name)); mimeType != "" {
+		return mimeType
+	}
+	return http.DetectContentType(data)
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aloha/a2a-go/pkg/cli"
+	"github.com/spf13/pflag"
+)
+
+// profile is a named agent connection in the client config file.
+// Auth values and TLS paths may reference environment variables as $VAR or ${VAR};
+// TLS paths may also start with ~/.
+type profile struct {
+	URL       string `yaml:"url"`
+	Transport string `yaml:"transport"`
+	CardURL   string `yaml:"cardUrl"`
+	Authority string `yaml:"authority"`
+	Proxy     string `yaml:"proxy"`
+	Auth      struct {
+		APIKey      string `yaml:"apiKey"`
+		BearerToken string `yaml:"bearerToken"`
+	} `yaml:"auth"`
+	TLS struct {
+		CACert     string `yaml:"caCert"`
+		ClientCert string `yaml:"clientCert"`
+		ClientKey  string `yaml:"clientKey"`
+		ServerName string `yaml:"serverName"`
+		Insecure   bool   `yaml:"insecure"`
+	} `yaml:"tls"`
+}
+
+// clientConfig is the client config file: named profiles and the one used when --profile is omitted
+type clientConfig struct {
+	Default  string              `yaml:"default"`
+	Profiles map[string]*profile `yaml:"profiles"`
+}
+
+// loadProfile reads the named profile, or the config's default profile when name is empty.
+// It returns nil without error when no profile is requested and none is configured.
+func loadProfile(name string) (string, *profile, error) {
+	var config clientConfig
+	path, found, err := cli.ReadConfig(&config)
+	if err != nil {
+		return "", nil, err
+	}
+	if !found && name != "" {
+		return "", nil, fmt.Errorf("profile %q requested but config file %s does not exist", name, path)
+	}
+
+	if name == "" {
+		name = config.Default
+		if name == "" {
+			return "", nil, nil
+		}
+	}
+	p, ok := config.Profiles[name]
+	if !ok || p == nil {
+		names := make([]string, 0, len(config.Profiles))
+		for n := range config.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return "", nil, fmt.Errorf("profile %q not found in %s (available: %s)", name, path, strings.Join(names, ", "))
+	}
+	return name, p, nil
+}
+
+// apply sets the flags the profile defines, leaving flags given on the command line or
+// by the environment untouched
+func (p *profile) apply(fs *pflag.FlagSet, name string) error {
+	values := map[string]string{
+		"transport":    p.Transport,
+		"card-url":     p.CardURL,
+		"authority":    p.Authority,
+		"proxy":        os.ExpandEnv(p.Proxy),
+		"api-key":      os.ExpandEnv(p.Auth.APIKey),
+		"bearer-token": os.ExpandEnv(p.Auth.BearerToken),
+		"ca-cert":      expandPath(p.TLS.CACert),
+		"client-cert":  expandPath(p.TLS.ClientCert),
+		"client-key":   expandPath(p.TLS.ClientKey),
+		"server-name":  p.TLS.ServerName,
+	}
+	if p.TLS.Insecure {
+		values["insecure"] = "true"
+	}
+
+	if p.URL != "" {
+		u, err := url.Parse(p.URL)
+		if err != nil || u.Hostname() == "" {
+			return fmt.Errorf("invalid profile url %q", p.URL)
+		}
+		values["host"] = u.Hostname()
+		if u.Port() != "" {
+			values["port"] = u.Port()
+		} else if u.Scheme == "https" {
+			values["port"] = strconv.Itoa(443)
+		} else {
+			values["port"] = strconv.Itoa(80)
+		}
+		if u.Scheme == "https" {
+			values["tls"] = "true"
+		}
+	}
+
+	return cli.ApplyDefaults(fs, values, "profile "+name)
+}
+
+// expandPath expands environment variables and a leading ~/ in a file path
+func expandPath(path string) string {
+	path = os.ExpandEnv(path)
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+	"google.golang.org/grpc"
+)
+
+// proxySettings selects the proxy for each request. Without --proxy the standard
+// HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables apply; with it, the given
+// proxy is used for every scheme while NO_PROXY still exempts hosts.
+type proxySettings struct {
+	explicit *url.URL // from --proxy; nil means environment only
+	resolve  func(*url.URL) (*url.URL, error)
+}
+
+// newProxySettings parses the --proxy flag value
+func newProxySettings(proxy string) (*proxySettings, error) {
+	config := httpproxy.FromEnvironment()
+	settings := &proxySettings{}
+	if proxy != "" {
+		u, err := url.Parse(proxy)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", proxy)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme %q (use http, https or socks5)", u.Scheme)
+		}
+		settings.explicit = u
+		config.HTTPProxy = proxy
+		config.HTTPSProxy = proxy
+	}
+	settings.resolve = config.ProxyFunc()
+	return settings, nil
+}
+
+// HTTPProxy implements http.Transport.Proxy
+func (p *proxySettings) HTTPProxy(req *http.Request) (*url.URL, error) {
+	return p.resolve(req.URL)
+}
+
+// String describes the proxy configuration for the startup banner
+func (p *proxySettings) String() string {
+	if p.explicit != nil {
+		return p.explicit.Redacted()
+	}
+	for _, name := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"} {
+		if os.Getenv(name) != "" {
+			return "from environment"
+		}
+	}
+	return "none"
+}
+
+// GRPCDialOptions returns the dial options that route gRPC through --proxy.
+// gRPC honors the proxy environment variables by itself, so none are needed without --proxy.
+func (p *proxySettings) GRPCDialOptions() []grpc.DialOption {
+	if p.explicit == nil {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		// The scheme only selects HTTPS_PROXY; NO_PROXY matching uses the host
+		proxyURL, err := p.resolve(&url.URL{Scheme: "https", Host: addr})
+		if err != nil {
+			return nil, err
+		}
+		var dialer net.Dialer
+		if proxyURL == nil {
+			return dialer.DialContext(ctx, "tcp", addr)
+		}
+		if proxyURL.Scheme != "http" {
+			return nil, fmt.Errorf("gRPC only supports http:// proxies, got %s", proxyURL.Scheme)
+		}
+		return dialHTTPConnect(ctx, &dialer, proxyURL, addr)
+	})}
+}
+
+// dialHTTPConnect opens a tunnel to addr through an HTTP proxy with the CONNECT method
+func dialHTTPConnect(ctx context.Context, dialer *net.Dialer, proxyURL *url.URL, addr string) (net.Conn, error) {
+	conn, err := dialer.DialContext(ctx, "tcp", proxyURL.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy %s: %w", proxyURL.Host, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if user := proxyURL.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send CONNECT to proxy: %w", err)
+	}
+
+	// The proxy replies before the tunnel carries any data, so nothing is buffered past the response
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNECT response from proxy: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy refused CONNECT to %s: %s", addr, resp.Status)
+	}
+	return conn, nil
+}
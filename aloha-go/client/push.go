@@ -0,0 +1,233 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Push notification webhook settings
+const (
+	pushCallbackPath    = "/a2a/push"
+	pushTokenHeader     = "X-A2A-Notification-Token"
+	pushMaxBodyBytes    = 10 << 20
+	pushShutdownTimeout = 2 * time.Second
+)
+
+// pushReceiver is a local webhook server the agent posts task snapshots to.
+// Callbacks must carry the random token registered with the push config.
+type pushReceiver struct {
+	server  *http.Server
+	url     string
+	token   string
+	updates chan *a2a.Task
+}
+
+// newPushReceiver listens on listenAddr; callbackURL overrides the URL registered with the agent
+func newPushReceiver(listenAddr, callbackURL string) (*pushReceiver, error) {
+	token, err := randomHex(16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate push token: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", listenAddr, err)
+	}
+
+	if callbackURL == "" {
+		host, port, _ := net.SplitHostPort(listener.Addr().String())
+		if ip := net.ParseIP(host); ip == nil || ip.IsUnspecified() {
+			host = "localhost"
+		}
+		callbackURL = "http://" + net.JoinHostPort(host, port) + pushCallbackPath
+	}
+
+	r := &pushReceiver{
+		url:     callbackURL,
+		token:   token,
+		updates: make(chan *a2a.Task, 64),
+	}
+	mux := http.NewServeMux()
+	mux.Handle(pushCallbackPath, r)
+	r.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := r.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			clientLogger.Error("Push receiver stopped: %v", err)
+		}
+	}()
+	clientLogger.Info("Push receiver listening on %s (callback %s)", listener.Addr(), callbackURL)
+	return r, nil
+}
+
+// Config returns the push notification config to register with the agent
+func (r *pushReceiver) Config() (*a2a.PushConfig, error) {
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate push config ID: %w", err)
+	}
+	return &a2a.PushConfig{ID: id, URL: r.url, Token: r.token}, nil
+}
+
+// ServeHTTP accepts authenticated task snapshots posted by the agent
+func (r *pushReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token := req.Header.Get(pushTokenHeader)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(r.token)) != 1 {
+		clientLogger.Warn("Rejected push notification from %s: invalid or missing %s", req.RemoteAddr, pushTokenHeader)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var task a2a.Task
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, pushMaxBodyBytes)).Decode(&task); err != nil {
+		clientLogger.Warn("Rejected push notification from %s: %v", req.RemoteAddr, err)
+		http.Error(w, "Invalid task payload", http.StatusBadRequest)
+		return
+	}
+	clientLogger.Debug("Push notification for task %s: %s", task.ID, task.Status.State)
+
+	select {
+	case r.updates <- &task:
+		w.WriteHeader(http.StatusNoContent)
+	case <-req.Context().Done():
+	}
+}
+
+// Close stops the webhook server
+func (r *pushReceiver) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), pushShutdownTimeout)
+	defer cancel()
+	if err := r.server.Shutdown(ctx); err != nil {
+		clientLogger.Warn("Failed to stop push receiver: %v", err)
+	}
+}
+
+// runPushListen handles --push-listen: it sends the message without blocking, registers the
+// local webhook as the task's push config and prints task updates as the agent posts them
+func runPushListen(ctx context.Context, client *agentClient, listenAddr, callbackURL string, params *a2a.MessageSendParams, out *outputWriter) a2a.TaskInfo {
+	if client.card != nil && !client.card.Capabilities.PushNotifications {
+		clientLogger.Warn("Agent %s does not advertise push notification support", client.card.Name)
+	}
+
+	receiver, err := newPushReceiver(listenAddr, callbackURL)
+	if err != nil {
+		clientLogger.Fatal("Failed to start push receiver: %v", err)
+	}
+	defer receiver.Close()
+
+	pushConfig, err := receiver.Config()
+	if err != nil {
+		clientLogger.Fatal("%v", err)
+	}
+	if params.Config == nil {
+		params.Config = &a2a.MessageSendConfig{}
+	}
+	blocking := false
+	params.Config.Blocking = &blocking
+	params.Config.PushConfig = pushConfig
+
+	clientLogger.Info("Sending message (non-blocking, push notifications to %s)...", pushConfig.URL)
+	result, err := client.SendMessage(ctx, params)
+	if err != nil {
+		clientLogger.Fatal("Failed to send message: %v", err)
+	}
+
+	task, ok := result.(*a2a.Task)
+	if !ok {
+		// The agent answered directly, so there is no task to receive updates for
+		activeTranscript.SetResult(result)
+		activeRecording.AddEvent(result)
+		if msg, isMessage := result.(*a2a.Message); isMessage && out.Text() {
+			fmt.Print("[Message] ")
+			printMessageParts(msg)
+		} else if err := out.Write(result); err != nil {
+			clientLogger.Fatal("Failed to write output: %v", err)
+		}
+		return result.TaskInfo()
+	}
+
+	if out.Text() {
+		fmt.Println("\n============================================================")
+		fmt.Println("Agent Response (Push Notifications):")
+		fmt.Println("============================================================")
+	}
+	label := "[Task]"
+	for {
+		inflight.Observe(task)
+		activeTranscript.AddEvent(task)
+		activeRecording.AddEvent(task)
+		writePushUpdate(task, label, out)
+
+		if task.Status.State.Terminal() {
+			activeTranscript.SetResult(task)
+			if out.Text() {
+				fmt.Println("============================================================")
+			} else if err := out.Flush(); err != nil {
+				clientLogger.Fatal("Failed to write output: %v", err)
+			}
+			return task.TaskInfo()
+		}
+
+		task = waitForPush(ctx, receiver, task.ID)
+		label = "[Push]"
+	}
+}
+
+// waitForPush blocks until the receiver delivers an update for taskID
+func waitForPush(ctx context.Context, receiver *pushReceiver, taskID a2a.TaskID) *a2a.Task {
+	for {
+		select {
+		case <-ctx.Done():
+			clientLogger.Fatal("Stopped waiting for push notifications for task %s: %v", taskID, ctx.Err())
+		case update := <-receiver.updates:
+			if update.ID == taskID {
+				return update
+			}
+			clientLogger.Warn("Ignoring push notification for unexpected task %s", update.ID)
+		}
+	}
+}
+
+// writePushUpdate displays one task snapshot from the send response or a push notification
+func writePushUpdate(task *a2a.Task, label string, out *outputWriter) {
+	if !out.Text() {
+		if err := out.WriteEvent(task); err != nil {
+			clientLogger.Fatal("Failed to write output: %v", err)
+		}
+		return
+	}
+
+	if !task.Status.State.Terminal() {
+		fmt.Printf("%s %s State: %s", label, task.ID, task.Status.State)
+		if task.Status.Message != nil {
+			fmt.Print(" | ")
+			printMessagePartsInline(task.Status.Message)
+		}
+		fmt.Println()
+		return
+	}
+	printTask(task)
+}
+
+// randomHex returns n random bytes encoded as hex
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
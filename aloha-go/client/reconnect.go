@@ -0,0 +1,96 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// resubscribeFunc reattaches to a task's event stream
+type resubscribeFunc func(ctx context.Context, taskID a2a.TaskID) iter.Seq2[a2a.Event, error]
+
+// ReconnectStream yields the events of a stream. When the stream breaks on a transport error
+// before its task has ended, it resubscribes to the task, waiting with the retry backoff,
+// up to the connection's --reconnect attempts. Other errors are yielded as they are.
+// Agents that replay a task's events to resubscribing clients start again from the first
+// one: the events already yielded are then skipped.
+func (c *connection) ReconnectStream(ctx context.Context, events iter.Seq2[a2a.Event, error], resubscribe resubscribeFunc) iter.Seq2[a2a.Event, error] {
+	if c.reconnects == 0 {
+		return events
+	}
+	return func(yield func(a2a.Event, error) bool) {
+		var taskID a2a.TaskID
+		var first []byte // the first event yielded, which a replay starts with
+		seen := 0
+		ended := false
+		for attempt := 0; ; attempt++ {
+			var streamErr error
+			skip, resumed := 0, attempt > 0
+			for event, err := range events {
+				if err != nil {
+					streamErr = err
+					break
+				}
+				if resumed {
+					resumed = false
+					if data, _ := json.Marshal(event); bytes.Equal(data, first) {
+						skip = seen
+					}
+				}
+				if skip > 0 {
+					skip--
+					continue
+				}
+				if first == nil {
+					first, _ = json.Marshal(event)
+				}
+				seen++
+				if id := event.TaskInfo().TaskID; id != "" {
+					taskID = id
+				}
+				ended = ended || streamEnded(event)
+				if !yield(event, nil) {
+					return
+				}
+			}
+			if streamErr == nil {
+				return
+			}
+			if taskID == "" || ended || attempt >= c.reconnects || ctx.Err() != nil || !streamDropped(streamErr) {
+				yield(nil, streamErr)
+				return
+			}
+
+			clientLogger.Warn("Stream of task %s dropped (%v), reconnecting (%d/%d)", taskID, streamErr, attempt+1, c.reconnects)
+			if err := c.retry.wait(ctx, attempt); err != nil {
+				yield(nil, streamErr)
+				return
+			}
+			events = resubscribe(ctx, taskID)
+		}
+	}
+}
+
+// streamEnded reports whether an event is the last one of its stream
+func streamEnded(event a2a.Event) bool {
+	switch e := event.(type) {
+	case *a2a.Message:
+		return true
+	case *a2a.Task:
+		return e.Status.State.Terminal()
+	case *a2a.TaskStatusUpdateEvent:
+		return e.Final || e.Status.State.Terminal()
+	}
+	return false
+}
+
+// streamDropped reports whether a stream error means the connection was lost,
+// as opposed to an error reported by the agent
+func streamDropped(err error) bool {
+	return errors.Is(err, io.ErrUnexpectedEOF) || exitCodeForError(err) == exitTransport
+}
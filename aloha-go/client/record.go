@@ -0,0 +1,242 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// recordingVersion is the format version written to --record files
+const recordingVersion = 1
+
+// wireRecord is one HTTP or gRPC message observed on the wire
+type wireRecord struct {
+	Time      time.Time       `json:"time"`
+	Protocol  string          `json:"protocol"`  // http or grpc
+	Direction string          `json:"direction"` // request, response, stream or error
+	Method    string          `json:"method,omitempty"`
+	URL       string          `json:"url,omitempty"`
+	Status    string          `json:"status,omitempty"`
+	Headers   http.Header     `json:"headers,omitempty"`
+	JSON      json.RawMessage `json:"json,omitempty"` // the payload when it is valid JSON
+	Body      string          `json:"body,omitempty"` // the payload otherwise, e.g. SSE chunks
+	Error     string          `json:"error,omitempty"`
+}
+
+// withPayload sets the JSON or Body field from a raw payload
+func (r wireRecord) withPayload(payload []byte) wireRecord {
+	switch {
+	case len(payload) == 0:
+	case json.Valid(payload):
+		r.JSON = json.RawMessage(payload)
+	default:
+		r.Body = string(payload)
+	}
+	return r
+}
+
+// recordedEvent is one A2A event as the client decoded it, with the time it was received
+type recordedEvent struct {
+	ReceivedAt time.Time       `json:"receivedAt"`
+	Event      json.RawMessage `json:"event"`
+}
+
+// recording captures a session for --record: every message on the wire and the
+// A2A events decoded from them, so --replay can render the session offline.
+// Methods are no-ops on a nil recording, so observation points need no checks.
+type recording struct {
+	path       string
+	mu         sync.Mutex
+	Version    int                    `json:"version"`
+	StartedAt  time.Time              `json:"startedAt"`
+	FinishedAt time.Time              `json:"finishedAt"`
+	Transport  string                 `json:"transport"`
+	Streaming  bool                   `json:"streaming"`
+	AgentName  string                 `json:"agentName,omitempty"`
+	AgentURL   string                 `json:"agentUrl,omitempty"`
+	Request    *a2a.MessageSendParams `json:"request,omitempty"`
+	Wire       []wireRecord           `json:"wire"`
+	Events     []recordedEvent        `json:"events"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// activeRecording is the session being recorded, or nil when --record is not set
+var activeRecording *recording
+
+// newRecording starts recording a session to path
+func newRecording(path, transport string, streaming bool, request *a2a.MessageSendParams) *recording {
+	return &recording{
+		path:      path,
+		Version:   recordingVersion,
+		StartedAt: time.Now().UTC(),
+		Transport: transport,
+		Streaming: streaming,
+		Request:   request,
+	}
+}
+
+// SetAgent records the agent the session is with and the transport finally used
+func (r *recording) SetAgent(transport string, card *a2a.AgentCard) {
+	if r == nil || card == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Transport = transport
+	r.AgentName, r.AgentURL = card.Name, card.URL
+}
+
+// AddWire records one message observed on the wire
+func (r *recording) AddWire(record wireRecord) {
+	if r == nil {
+		return
+	}
+	record.Time = time.Now().UTC()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Wire = append(r.Wire, record)
+}
+
+// AddEvent records a decoded event or result
+func (r *recording) AddEvent(event a2a.Event) {
+	if r == nil {
+		return
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		clientLogger.Warn("Failed to record event: %v", err)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Events = append(r.Events, recordedEvent{ReceivedAt: time.Now().UTC(), Event: data})
+}
+
+// Save writes the recording, noting errMsg if the session failed
+func (r *recording) Save(errMsg string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.FinishedAt = time.Now().UTC()
+	r.Error = errMsg
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		clientLogger.Warn("Failed to render recording: %v", err)
+		return
+	}
+	if err := os.WriteFile(r.path, data, 0o644); err != nil {
+		clientLogger.Warn("Failed to save recording %s: %v", r.path, err)
+		return
+	}
+	clientLogger.Info("Recording saved to %s (%d wire messages, %d events)", r.path, len(r.Wire), len(r.Events))
+}
+
+// loadRecording reads a file written by --record
+func loadRecording(path string) (*recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording %s: %w", path, err)
+	}
+	var r recording
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse recording %s: %w", path, err)
+	}
+	if r.Version != recordingVersion {
+		return nil, fmt.Errorf("unsupported recording version %d in %s", r.Version, path)
+	}
+	return &r, nil
+}
+
+// events decodes the recorded events in order
+func (r *recording) events() iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		for i, recorded := range r.Events {
+			event, err := a2a.UnmarshalEventJSON(recorded.Event)
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to decode recorded event %d: %w", i+1, err))
+				return
+			}
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+// runReplay handles --replay: renders a recorded session offline exactly as it was displayed live.
+// With dump set the recorded wire messages are dumped first, as --verbose would have.
+func runReplay(path string, dump bool, out *outputWriter) {
+	r, err := loadRecording(path)
+	if err != nil {
+		clientLogger.Fatal("%v", err)
+	}
+
+	clientLogger.Info("Replaying %s recorded %s", path, r.StartedAt.Format(time.RFC3339))
+	if r.AgentName != "" {
+		clientLogger.Info("  Agent: %s (%s)", r.AgentName, r.AgentURL)
+	}
+	clientLogger.Info("  Transport: %s", r.Transport)
+	clientLogger.Info("  Streaming: %v", r.Streaming)
+	clientLogger.Info("  Wire messages: %d, events: %d", len(r.Wire), len(r.Events))
+
+	if dump {
+		for _, record := range r.Wire {
+			dumpWireRecord(record)
+		}
+	}
+
+	if r.Streaming {
+		printEventStream(r.events(), out)
+	} else {
+		for event, err := range r.events() {
+			if err != nil {
+				clientLogger.Fatal("%v", err)
+			}
+			result, ok := event.(a2a.SendMessageResult)
+			if !ok {
+				clientLogger.Fatal("Recorded result is a %T, not a task or message", event)
+			}
+			inflight.Observe(event)
+			printResult(result, out)
+		}
+	}
+
+	if r.Error != "" {
+		clientLogger.Error("Recorded session ended with an error: %s", r.Error)
+		os.Exit(exitError)
+	}
+}
+
+// dumpWireRecord logs a recorded wire message in the --verbose format
+func dumpWireRecord(record wireRecord) {
+	arrow := "<<<"
+	if record.Direction == "request" {
+		arrow = ">>>"
+	}
+	target := record.Method
+	if record.URL != "" {
+		target = record.Method + " " + record.URL
+	}
+
+	payload := record.Body
+	if len(record.JSON) > 0 {
+		payload = string(record.JSON)
+	}
+	switch {
+	case record.Error != "":
+		clientLogger.Debug("%s %s %s error: %s", arrow, record.Protocol, target, record.Error)
+	case record.Status != "":
+		clientLogger.Debug("%s %s %s %s\n%s", arrow, record.Protocol, target, record.Status, payload)
+	default:
+		clientLogger.Debug("%s %s %s %s\n%s", arrow, record.Protocol, record.Direction, target, payload)
+	}
+}
@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"gopkg.in/yaml.v3"
+)
+
+// registryEntry is one known agent in an --agents-file
+type registryEntry struct {
+	Name      string `yaml:"name" json:"name"`
+	URL       string `yaml:"url" json:"url"`
+	Transport string `yaml:"transport,omitempty" json:"transport,omitempty"` // overrides --transport for this agent
+}
+
+// registryFile is the --agents-file format:
+//
+//	agents:
+//	  - name: dice
+//	    url: http://localhost:12001
+//	  - name: java-dice
+//	    url: http://localhost:13001
+//	    transport: grpc
+type registryFile struct {
+	Agents []registryEntry `yaml:"agents"`
+}
+
+// registeredAgent is a registry entry with its resolved card and, once used, its client
+type registeredAgent struct {
+	registryEntry
+	card   *a2a.AgentCard
+	err    error // why the card could not be resolved
+	client *agentClient
+}
+
+// agentRegistry holds named agents, from an --agents-file or found by --discover. All
+// cards are resolved when it is created; clients are created on first use and reused
+// for later messages.
+type agentRegistry struct {
+	conn   *connection
+	source string // where the agents come from, for messages
+	agents []*registeredAgent
+}
+
+// loadAgentRegistry reads an --agents-file and resolves the card of every agent in it
+func loadAgentRegistry(ctx context.Context, conn *connection, path, transport string) (*agentRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agents file %s: %w", path, err)
+	}
+	var file registryFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse agents file %s: %w", path, err)
+	}
+	if len(file.Agents) == 0 {
+		return nil, fmt.Errorf("agents file %s lists no agents", path)
+	}
+	return newAgentRegistry(ctx, conn, file.Agents, transport, path)
+}
+
+// newAgentRegistry resolves the card of every agent in entries, which come from source.
+// Agents whose card cannot be resolved are kept, so they can be listed with their error.
+func newAgentRegistry(ctx context.Context, conn *connection, entries []registryEntry, transport, source string) (*agentRegistry, error) {
+	registry := &agentRegistry{conn: conn, source: source}
+	seen := make(map[string]bool)
+	for i, entry := range entries {
+		if entry.Name == "" || entry.URL == "" {
+			return nil, fmt.Errorf("agent %d in %s needs a name and a url", i+1, source)
+		}
+		if seen[entry.Name] {
+			return nil, fmt.Errorf("agent %s is listed twice in %s", entry.Name, source)
+		}
+		seen[entry.Name] = true
+		if entry.Transport == "" {
+			entry.Transport = transport
+		}
+		registry.agents = append(registry.agents, &registeredAgent{registryEntry: entry})
+	}
+
+	// Cards are resolved in order: resolution adapts the shared credentials
+	for _, agent := range registry.agents {
+		agent.card, agent.err = conn.ResolveCard(ctx, agent.URL)
+		if agent.err != nil {
+			clientLogger.Warn("Agent %s: failed to resolve agent card: %v", agent.Name, agent.err)
+			continue
+		}
+		clientLogger.Info("Agent %s: %s (v%s) with %d skills", agent.Name, agent.card.Name, agent.card.Version, len(agent.card.Skills))
+	}
+	return registry, nil
+}
+
+// Get returns the named agent, which must have a resolved card
+func (r *agentRegistry) Get(name string) (*registeredAgent, error) {
+	for _, agent := range r.agents {
+		if agent.Name != name {
+			continue
+		}
+		if agent.err != nil {
+			return nil, fmt.Errorf("agent %s is unavailable: %w", name, agent.err)
+		}
+		return agent, nil
+	}
+	return nil, fmt.Errorf("unknown agent %s (known: %s)", name, strings.Join(r.Names(), ", "))
+}
+
+// Default returns the name of the first agent whose card was resolved, or "" if none was
+func (r *agentRegistry) Default() string {
+	for _, agent := range r.agents {
+		if agent.err == nil {
+			return agent.Name
+		}
+	}
+	return ""
+}
+
+// Names returns the agent names in file order
+func (r *agentRegistry) Names() []string {
+	names := make([]string, len(r.agents))
+	for i, agent := range r.agents {
+		names[i] = agent.Name
+	}
+	return names
+}
+
+// Client returns a client for the named agent, connecting on first use
+func (r *agentRegistry) Client(ctx context.Context, name string) (*agentClient, error) {
+	agent, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if agent.client == nil {
+		if agent.client, err = connectAgent(ctx, r.conn, agent.card, agent.Transport); err != nil {
+			return nil, fmt.Errorf("failed to connect to agent %s: %w", name, err)
+		}
+	}
+	return agent.client, nil
+}
+
+// Close releases the clients created so far
+func (r *agentRegistry) Close() {
+	for _, agent := range r.agents {
+		if agent.client != nil {
+			agent.client.Close()
+		}
+	}
+}
+
+// registryListing is one agent in the structured --agents-file listing
+type registryListing struct {
+	registryEntry
+	Card  *a2a.AgentCard `json:"card,omitempty" yaml:"card,omitempty"`
+	Error string         `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Print lists the agents with their card and skills
+func (r *agentRegistry) Print(out *outputWriter) {
+	if !out.Text() {
+		listing := make([]registryListing, len(r.agents))
+		for i, agent := range r.agents {
+			listing[i] = registryListing{registryEntry: agent.registryEntry, Card: agent.card}
+			if agent.err != nil {
+				listing[i].Error = agent.err.Error()
+			}
+		}
+		if err := out.Write(listing); err != nil {
+			clientLogger.Fatal("Failed to write output: %v", err)
+		}
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tAGENT\tURL\tTRANSPORT\tSKILLS")
+	for _, agent := range r.agents {
+		if agent.err != nil {
+			fmt.Fprintf(w, "%s\t-\t%s\t%s\tunavailable: %v\n", agent.Name, agent.URL, agent.Transport, agent.err)
+			continue
+		}
+		skills := make([]string, len(agent.card.Skills))
+		for i, skill := range agent.card.Skills {
+			skills[i] = skill.Name
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", agent.Name, agent.card.Name, agent.URL, agent.Transport, strings.Join(skills, ", "))
+	}
+	w.Flush()
+}
+
+// runRegistry handles --agents-file and --discover: lists the agents, sends the message to
+// the one named by --agent, or opens a --chat console where the agent can be picked for each message
+func runRegistry(ctx context.Context, registry *agentRegistry, name string, params *a2a.MessageSendParams, first []a2a.Part, chat, stream bool, timeout time.Duration, out *outputWriter, sessions *sessionStore, session string) {
+	defer registry.Close()
+
+	switch {
+	case chat:
+		if name == "" {
+			if name = registry.Default(); name == "" {
+				clientLogger.Fatal("No agent card in %s could be resolved", registry.source)
+			}
+		}
+		if _, err := registry.Get(name); err != nil {
+			clientLogger.Fatal("%v", err)
+		}
+		agents := &chatAgents{registry: registry, current: name}
+		sessions.Save(session, runChat(agents, params.Message.ContextID, first, stream, timeout, out))
+	case name == "":
+		registry.Print(out)
+	default:
+		client, err := registry.Client(ctx, name)
+		if err != nil {
+			clientLogger.Fatal("%v", err)
+		}
+		clientLogger.Info("Sending to agent %s (%s)", name, client.card.Name)
+		info := sendAndPrint(ctx, client, params, stream, out)
+		sessions.Save(session, info.ContextID)
+	}
+}
@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptrace"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2apb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// maxRetryBackoff caps the exponential backoff between attempts
+const maxRetryBackoff = 10 * time.Second
+
+// Default retryable HTTP statuses and gRPC codes
+const (
+	defaultRetryStatuses  = "429,502,503,504"
+	defaultRetryGRPCCodes = "UNAVAILABLE,RESOURCE_EXHAUSTED"
+)
+
+// idempotentRPCMethods are the JSON-RPC methods that only read, so sending them twice is harmless
+var idempotentRPCMethods = map[string]bool{
+	"tasks/get":                          true,
+	"tasks/list":                         true,
+	"tasks/pushNotificationConfig/get":   true,
+	"tasks/pushNotificationConfig/list":  true,
+	"agent/getAuthenticatedExtendedCard": true,
+}
+
+// idempotentGRPCMethods are the gRPC methods that only read
+var idempotentGRPCMethods = map[string]bool{
+	a2apb.A2AService_GetTask_FullMethodName:                        true,
+	a2apb.A2AService_ListTasks_FullMethodName:                      true,
+	a2apb.A2AService_GetTaskPushNotificationConfig_FullMethodName:  true,
+	a2apb.A2AService_ListTaskPushNotificationConfig_FullMethodName: true,
+	a2apb.A2AService_GetAgentCard_FullMethodName:                   true,
+}
+
+// retryPolicy decides whether and when failed requests are retried.
+// Idempotent requests, such as the card fetch and task reads, are retried on network errors,
+// and on responses whose status or code is listed. Other requests, message sends among them,
+// are only retried when they failed before reaching a connection, since the agent may have
+// acted on them otherwise; sends retries them like idempotent ones.
+type retryPolicy struct {
+	retries     int
+	backoff     time.Duration
+	statusCodes map[int]bool
+	grpcCodes   map[codes.Code]bool
+	sends       bool // --retry-sends
+}
+
+// newRetryPolicy builds a policy from the --retry* flags
+func newRetryPolicy(retries int, backoff time.Duration, statuses, grpcCodes string, sends bool) (retryPolicy, error) {
+	if retries < 0 {
+		return retryPolicy{}, fmt.Errorf("--retries must not be negative")
+	}
+	policy := retryPolicy{
+		retries:     retries,
+		backoff:     backoff,
+		sends:       sends,
+		statusCodes: make(map[int]bool),
+		grpcCodes:   make(map[codes.Code]bool),
+	}
+
+	for _, field := range splitList(statuses) {
+		code, err := strconv.Atoi(field)
+		if err != nil || code < 100 || code > 599 {
+			return retryPolicy{}, fmt.Errorf("invalid HTTP status %q in --retry-status", field)
+		}
+		policy.statusCodes[code] = true
+	}
+	for _, field := range splitList(grpcCodes) {
+		var code codes.Code
+		if err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(field)))); err != nil {
+			return retryPolicy{}, fmt.Errorf("invalid gRPC code %q in --retry-codes", field)
+		}
+		policy.grpcCodes[code] = true
+	}
+	return policy, nil
+}
+
+// wait sleeps before retry number attempt+1 using exponential backoff with jitter
+func (p retryPolicy) wait(ctx context.Context, attempt int) error {
+	delay := p.backoff << attempt
+	if delay <= 0 || delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+	delay = delay/2 + rand.N(delay/2+1)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryTransport retries HTTP requests that fail with a network error or a retryable status,
+// as the policy allows for the request
+type retryTransport struct {
+	base   http.RoundTripper
+	policy retryPolicy
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	idempotent := t.policy.sends || idempotentRequest(req)
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		// A request that never got a connection was not sent, and is safe to send again
+		var connected atomic.Bool
+		trace := &httptrace.ClientTrace{GotConn: func(httptrace.GotConnInfo) { connected.Store(true) }}
+		resp, err := t.base.RoundTrip(attemptReq.WithContext(httptrace.WithClientTrace(attemptReq.Context(), trace)))
+
+		var reason string
+		switch {
+		case err != nil && (idempotent || !connected.Load()):
+			reason = err.Error()
+		case err == nil && idempotent && t.policy.statusCodes[resp.StatusCode]:
+			reason = resp.Status
+		default:
+			return resp, err
+		}
+		if attempt >= t.policy.retries || ctx.Err() != nil || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		clientLogger.Warn("%s %s failed (%s), retrying (%d/%d)", req.Method, req.URL, reason, attempt+1, t.policy.retries)
+		if err := t.policy.wait(ctx, attempt); err != nil {
+			return nil, err
+		}
+
+		attemptReq = req.Clone(ctx)
+		if req.GetBody != nil {
+			if attemptReq.Body, err = req.GetBody(); err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+		}
+	}
+}
+
+// idempotentRequest reports whether req only reads: GETs such as the card fetch and REST
+// task reads, and JSON-RPC calls of a read-only method
+func idempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		if req.GetBody == nil {
+			return false
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return false
+		}
+		defer body.Close()
+		var call struct {
+			Method string `json:"method"`
+		}
+		return json.NewDecoder(body).Decode(&call) == nil && idempotentRPCMethods[call.Method]
+	}
+	return false
+}
+
+// unaryInterceptor retries unary gRPC calls that fail with a retryable code, as the policy
+// allows for the method
+func (p retryPolicy) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	idempotent := p.sends || idempotentGRPCMethods[method]
+	for attempt := 0; ; attempt++ {
+		// The peer stays unset when the call never got a connection
+		var sentTo peer.Peer
+		err := invoker(ctx, method, req, reply, cc, append(slices.Clip(opts), grpc.Peer(&sentTo))...)
+		code := status.Code(err)
+		if err == nil || !p.grpcCodes[code] || (!idempotent && sentTo.Addr != nil) || attempt >= p.retries || ctx.Err() != nil {
+			return err
+		}
+
+		clientLogger.Warn("%s failed (%s), retrying (%d/%d)", method, code, attempt+1, p.retries)
+		if err := p.wait(ctx, attempt); err != nil {
+			return err
+		}
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping blanks
+func splitList(value string) []string {
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
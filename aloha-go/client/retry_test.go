@@ -0,0 +1,82 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingTransport counts the attempts that reach the base transport
+type countingTransport struct {
+	base     http.RoundTripper
+	attempts atomic.Int32
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.attempts.Add(1)
+	return t.base.RoundTrip(req)
+}
+
+func TestRetryTransport(t *testing.T) {
+	// cut answers with the start of a response, then drops the connection
+	cut := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Type: app")
+		buf.Flush()
+		conn.Close()
+	}))
+	defer cut.Close()
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+	refused := httptest.NewServer(http.NotFoundHandler())
+	refused.Close()
+
+	send := `{"jsonrpc":"2.0","id":1,"method":"message/send","params":{}}`
+	getTask := `{"jsonrpc":"2.0","id":1,"method":"tasks/get","params":{"id":"task-1"}}`
+	tests := []struct {
+		name   string
+		url    string
+		method string
+		body   string
+		sends  bool
+		want   int32
+	}{
+		{"send cut mid-response", cut.URL, http.MethodPost, send, false, 1},
+		{"send cut mid-response with --retry-sends", cut.URL, http.MethodPost, send, true, 3},
+		{"send with a retryable status", unavailable.URL, http.MethodPost, send, false, 1},
+		{"send refused before connecting", refused.URL, http.MethodPost, send, false, 3},
+		{"tasks/get cut mid-response", cut.URL, http.MethodPost, getTask, false, 3},
+		{"card fetch with a retryable status", unavailable.URL + "/.well-known/agent-card.json", http.MethodGet, "", false, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := newRetryPolicy(2, time.Millisecond, defaultRetryStatuses, defaultRetryGRPCCodes, tt.sends)
+			if err != nil {
+				t.Fatal(err)
+			}
+			counter := &countingTransport{base: &http.Transport{}}
+			client := &http.Client{Transport: &retryTransport{base: counter, policy: policy}}
+
+			req, err := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp, err := client.Do(req); err == nil {
+				resp.Body.Close()
+			}
+			if got := counter.attempts.Load(); got != tt.want {
+				t.Errorf("attempts = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/ollama/ollama/api"
+)
+
+// Routing strategies for --route
+const (
+	routeSkills = "skills" // match the message against skill tags, names and descriptions
+	routeLLM    = "llm"    // ask an Ollama model, falling back to skills matching
+)
+
+// Weights of a message word found in each skill field
+const (
+	routeTagWeight         = 3
+	routeNameWeight        = 2
+	routeDescriptionWeight = 1
+)
+
+// defaultRouteModel is the Ollama model used by --route llm unless OLLAMA_MODEL is set
+const defaultRouteModel = "qwen2.5"
+
+// routeStopWords are ignored when matching a message against skills
+var routeStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "this": true, "that": true,
+	"with": true, "what": true, "can": true, "you": true, "please": true, "from": true,
+}
+
+// routeCandidate is an agent whose card was resolved, with its skills match
+type routeCandidate struct {
+	url   string
+	card  *a2a.AgentCard
+	score int
+	skill string // name of the best matching skill
+}
+
+// runRoute handles --route: resolves every agent card, picks the agent best suited to the
+// message and sends it there alone, reporting which agent handled it
+func runRoute(ctx context.Context, conn *connection, urls []string, transport, strategy string, params *a2a.MessageSendParams, stream bool, out *outputWriter) {
+	var candidates []*routeCandidate
+	for _, url := range urls {
+		card, err := conn.ResolveCard(ctx, url)
+		if err != nil {
+			clientLogger.Warn("Skipping %s: failed to resolve agent card: %v", url, err)
+			continue
+		}
+		candidates = append(candidates, &routeCandidate{url: url, card: card})
+	}
+	if len(candidates) == 0 {
+		clientLogger.Fatal("No agent card could be resolved")
+	}
+
+	text := messageText(params.Message)
+	chosen := -1
+	if strategy == routeLLM {
+		var err error
+		if chosen, err = routeWithLLM(ctx, text, candidates); err != nil {
+			clientLogger.Warn("LLM routing failed, matching skills instead: %v", err)
+		}
+	}
+	if chosen < 0 {
+		chosen = routeBySkills(text, candidates)
+	}
+
+	target := candidates[chosen]
+	if target.skill != "" {
+		clientLogger.Info("Routing to %s (%s): skill %s matched with score %d", target.card.Name, target.url, target.skill, target.score)
+	} else {
+		clientLogger.Info("Routing to %s (%s)", target.card.Name, target.url)
+	}
+
+	client, err := connectAgent(ctx, conn, target.card, transport)
+	if err != nil {
+		clientLogger.Fatal("Failed to connect to %s: %v", target.card.Name, err)
+	}
+	defer client.Close()
+
+	sendAndPrint(ctx, client, params, stream, out)
+	if out.Text() {
+		fmt.Printf("Handled by: %s (%s)\n", target.card.Name, target.url)
+	}
+}
+
+// routeBySkills scores every candidate against the message and returns the index of the best.
+// Ties go to the agent listed first; with no match at all the first agent is used.
+func routeBySkills(text string, candidates []*routeCandidate) int {
+	words := routeWords(text)
+	best := 0
+	for i, candidate := range candidates {
+		for _, skill := range candidate.card.Skills {
+			score := routeScore(words, skill.Tags, routeTagWeight) +
+				routeScore(words, []string{skill.ID, skill.Name}, routeNameWeight) +
+				routeScore(words, append([]string{skill.Description}, skill.Examples...), routeDescriptionWeight)
+			if score > candidate.score {
+				candidate.score, candidate.skill = score, skill.Name
+			}
+		}
+		if candidate.score > candidates[best].score {
+			best = i
+		}
+	}
+	if candidates[best].score == 0 {
+		clientLogger.Warn("No skill matched the message, using the first agent")
+	}
+	return best
+}
+
+// routeScore counts the message words found in fields, times weight
+func routeScore(words map[string]bool, fields []string, weight int) int {
+	found := make(map[string]bool)
+	for _, field := range fields {
+		for word := range routeWords(field) {
+			if words[word] {
+				found[word] = true
+			}
+		}
+	}
+	return len(found) * weight
+}
+
+// routeWords splits text into lower-case words without stop words, short words or plural endings
+func routeWords(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(word) < 3 || routeStopWords[word] {
+			continue
+		}
+		if len(word) > 3 {
+			word = strings.TrimSuffix(word, "s")
+		}
+		words[word] = true
+	}
+	return words
+}
+
+// routeChoice finds the agent number in the model's answer
+var routeChoice = regexp.MustCompile(`\d+`)
+
+// routeWithLLM asks an Ollama model (OLLAMA_HOST, OLLAMA_MODEL) which agent should handle
+// the message and returns its index
+func routeWithLLM(ctx context.Context, text string, candidates []*routeCandidate) (int, error) {
+	client, err := api.ClientFromEnvironment()
+	if err != nil {
+		return -1, fmt.Errorf("failed to create Ollama client: %w", err)
+	}
+	model := os.Getenv("OLLAMA_MODEL")
+	if model == "" {
+		model = defaultRouteModel
+	}
+
+	var prompt strings.Builder
+	prompt.WriteString("You route user requests to the agent best able to handle them. ")
+	prompt.WriteString("Reply with only the number of the chosen agent.\n\nAgents:\n")
+	for i, candidate := range candidates {
+		fmt.Fprintf(&prompt, "%d. %s: %s\n", i+1, candidate.card.Name, candidate.card.Description)
+		for _, skill := range candidate.card.Skills {
+			fmt.Fprintf(&prompt, "   - %s [%s]: %s\n", skill.Name, strings.Join(skill.Tags, ", "), skill.Description)
+		}
+	}
+
+	req := &api.ChatRequest{
+		Model: model,
+		Messages: []api.Message{
+			{Role: "system", Content: prompt.String()},
+			{Role: "user", Content: text},
+		},
+		Stream: new(bool),
+	}
+	var answer string
+	err = client.Chat(ctx, req, func(resp api.ChatResponse) error {
+		answer += resp.Message.Content
+		return nil
+	})
+	if err != nil {
+		return -1, fmt.Errorf("chat request failed: %w", err)
+	}
+
+	number, err := strconv.Atoi(routeChoice.FindString(answer))
+	if err != nil || number < 1 || number > len(candidates) {
+		return -1, fmt.Errorf("model answered %q, not an agent number", strings.TrimSpace(answer))
+	}
+	clientLogger.Info("Model %s chose agent %d", model, number)
+	return number - 1, nil
+}
@@ -0,0 +1,654 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+var clientLogger = NewLogger("client")
+
+// run runs the command the options were parsed for
+func (o *options) run() {
+	// Initialize log file output
+	quietLogs = o.quiet
+	InitLogFile(o.transport)
+
+	out, err := o.outputWriter()
+	if err != nil {
+		clientLogger.Fatal("%v", err)
+	}
+
+	// Replay renders a recorded session without contacting the agent
+	if o.replayPath != "" {
+		runReplay(o.replayPath, o.verbose, out)
+		exitForTaskState()
+		return
+	}
+
+	taskCommand := o.taskGet != "" || o.taskCancel != "" || o.resubscribe != "" || o.taskList
+	sends := !taskCommand && !o.inspectCard && !o.listAgents
+
+	// --message - reads the message from stdin, as does piping a message into aloha send
+	if o.chat && o.parts.ReadsStdin() {
+		clientLogger.Fatal("aloha chat reads messages from stdin, so --message - cannot be used with it")
+	}
+	if o.parts.ReadsStdin() || (sends && !o.chat && !o.conformance && !o.interop && o.parts.Empty() && stdinPiped()) {
+		text, err := readStdinMessage()
+		if err != nil {
+			clientLogger.Fatal("%v", err)
+		}
+		if err := o.parts.ResolveStdin(text); err != nil {
+			clientLogger.Fatal("%v", err)
+		}
+	}
+	if sends && !o.chat && !o.conformance && !o.interop && o.parts.Empty() {
+		clientLogger.Fatal("No message to send: give it as arguments, with --message, --file or --data, or on stdin")
+	}
+	o.validate()
+
+	// Set default port based on transport if not specified
+	if o.port == 0 {
+		switch o.transport {
+		case "grpc":
+			o.port = 12000
+		case "jsonrpc", "auto":
+			o.port = 12001
+		case "rest":
+			o.port = 12002
+		default:
+			clientLogger.Fatal("Unsupported transport: %s (use jsonrpc, grpc, rest, or auto)", o.transport)
+		}
+	}
+
+	tlsConfig, err := o.tls.Config()
+	if err != nil {
+		clientLogger.Fatal("Invalid TLS options: %v", err)
+	}
+	retry, err := newRetryPolicy(o.retries, o.retryBackoff, o.retryStatus, o.retryCodes, o.retrySends)
+	if err != nil {
+		clientLogger.Fatal("Invalid retry options: %v", err)
+	}
+	if o.connectTimeout < 0 || o.reconnect < 0 {
+		clientLogger.Fatal("--connect-timeout and --reconnect must not be negative")
+	}
+	proxySettings, err := newProxySettings(o.proxy)
+	if err != nil {
+		clientLogger.Fatal("Invalid proxy options: %v", err)
+	}
+	conn := newConnection(connectionOptions{tlsConfig: tlsConfig, auth: o.auth, retry: retry, connectTimeout: o.connectTimeout, reconnects: o.reconnect, ignoreVersion: o.ignoreVersion, grpcAuthority: o.authority, proxy: proxySettings, verbose: o.verbose, record: o.recordPath != ""})
+
+	clientLogger.Info("============================================================")
+	clientLogger.Info("A2A Host Client (SDK)")
+	if o.profile != "" {
+		clientLogger.Info("  Profile: %s", o.profile)
+	}
+	clientLogger.Info("  Transport: %s", o.transport)
+	clientLogger.Info("  Host: %s:%d", o.host, o.port)
+	clientLogger.Info("  Streaming: %v", o.stream)
+	clientLogger.Info("  TLS: %v", tlsConfig != nil)
+	clientLogger.Info("  Proxy: %s", proxySettings)
+	clientLogger.Info("  Authenticated: %v", !o.auth.Empty())
+	clientLogger.Info("  Message: %s", o.parts.Text())
+	clientLogger.Info("============================================================")
+
+	// Resolve the conversation context from --context-id or the named session
+	var sessions *sessionStore
+	if o.session != "" {
+		var err error
+		sessions, err = loadSessionStore()
+		if err != nil {
+			clientLogger.Fatal("Failed to load sessions: %v", err)
+		}
+		if o.contextID == "" {
+			o.contextID = sessions.ContextID(o.session)
+		}
+	}
+	if o.contextID != "" {
+		clientLogger.Info("  Context ID: %s", o.contextID)
+	}
+
+	// Build the message; its parts follow the order of the arguments, --message, --file and --data on the command line
+	msgParts, err := o.parts.Build()
+	if err != nil {
+		clientLogger.Fatal("%v", err)
+	}
+	msg := protocol.NewUserMessage(msgParts...).InContext(o.contextID)
+	params := &a2a.MessageSendParams{Message: msg.SDK(), Config: sendConfig(o.nonBlocking, o.historyLength, o.accept)}
+
+	// Record the exchange, including failures, when a transcript is requested
+	if o.saveTranscript != "" {
+		activeTranscript = newTranscript(o.saveTranscript, o.transport, params)
+		OnFatal(activeTranscript.Save)
+	}
+	if o.recordPath != "" {
+		activeRecording = newRecording(o.recordPath, o.transport, o.stream || o.resubscribe != "" || o.pushListen != "", params)
+		OnFatal(activeRecording.Save)
+	}
+
+	// Create context with the request timeout; aloha loadtest applies it to each request instead
+	ctx, cancel := context.WithCancel(context.Background())
+	if o.timeout > 0 && !o.loadtest {
+		ctx, cancel = context.WithTimeout(context.Background(), o.timeout)
+	}
+	defer cancel()
+
+	// aloha card only inspects the agent card
+	if o.inspectCard {
+		runCardInspection(ctx, conn, o.agentCardURL(conn), o.validateCard, signatureKeys{file: o.cardKey, jwks: o.cardJWKS}, out)
+		return
+	}
+
+	// aloha interop checks the agents of every language, each on its own ports
+	if o.interop {
+		runInterop(conn, o.interopOpts, o.parts.Text(), out)
+		return
+	}
+
+	// aloha conformance runs its own scenarios over every transport
+	if o.conformance {
+		runConformance(ctx, conn, o.agentCardURL(conn), o.parts.Text(), out)
+		return
+	}
+
+	// The registry service lists the live agents, or the candidates to route to
+	if o.registryURL != "" {
+		urls, found := discoverAgents(ctx, o.registryURL, splitList(o.tags))
+		if o.listAgents {
+			printRegistrations(found, out)
+			return
+		}
+		if len(urls) == 0 {
+			clientLogger.Fatal("No registered agent matches --tags %s", o.tags)
+		}
+		runRoute(ctx, conn, urls, o.transport, o.route, params, o.stream, out)
+		exitForTaskState()
+		return
+	}
+
+	// Fan-out mode sends to every listed agent instead of a single host
+	if o.agents != "" {
+		if o.route != "" {
+			runRoute(ctx, conn, splitList(o.agents), o.transport, o.route, params, o.stream, out)
+			exitForTaskState()
+			return
+		}
+		runFanout(ctx, conn, splitList(o.agents), o.transport, params, o.stream, out)
+		return
+	}
+
+	// A registry of named agents replaces the single host
+	if o.agentsFile != "" {
+		registry, err := loadAgentRegistry(ctx, conn, o.agentsFile, o.transport)
+		if err != nil {
+			clientLogger.Fatal("%v", err)
+		}
+		runRegistry(ctx, registry, o.agentName, params, params.Message.Parts, o.chat, o.stream, o.timeout, out, sessions, o.session)
+		exitForTaskState()
+		return
+	}
+
+	// Agents discovered on the local network are named like those of an --agents-file
+	if o.discover {
+		entries := discoverLocalAgents(ctx, o.discoverTimeout)
+		if o.route != "" {
+			if len(entries) == 0 {
+				clientLogger.Fatal("No agent to route to")
+			}
+			urls := make([]string, len(entries))
+			for i, entry := range entries {
+				urls[i] = entry.URL
+			}
+			runRoute(ctx, conn, urls, o.transport, o.route, params, o.stream, out)
+			exitForTaskState()
+			return
+		}
+		registry, err := newAgentRegistry(ctx, conn, entries, o.transport, localNetwork)
+		if err != nil {
+			clientLogger.Fatal("%v", err)
+		}
+		runRegistry(ctx, registry, o.agentName, params, params.Message.Parts, o.chat, o.stream, o.timeout, out, sessions, o.session)
+		exitForTaskState()
+		return
+	}
+
+	publicCard, err := resolveAgentCard(ctx, conn, o.host, o.port, o.cardURL)
+	if err != nil && o.transport == "grpc" && o.cardURL == "" {
+		clientLogger.Fatal("Failed to resolve agent card: %v (the gRPC port does not serve the card; use --card-url with the JSON-RPC or REST port)", err)
+	}
+	if err != nil {
+		clientLogger.Fatal("Failed to resolve agent card: %v", err)
+	}
+
+	// REST talks to --host and --port; auto picks the first reachable interface the card declares
+	var endpoint string
+	switch o.transport {
+	case "auto":
+		o.transport, endpoint, err = negotiateTransport(ctx, publicCard)
+		if err != nil {
+			clientLogger.Fatal("Transport negotiation failed: %v", err)
+		}
+	case "rest":
+		endpoint = fmt.Sprintf("%s://%s:%d", conn.Scheme(), o.host, o.port)
+	}
+
+	client, err := newAgentClient(ctx, conn, publicCard, o.transport, endpoint)
+	if err != nil {
+		clientLogger.Fatal("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	// With credentials, the authenticated extended card may list more skills than the public one
+	client.card = fetchExtendedCard(ctx, conn, client)
+	agentCard := client.card
+	activeTranscript.SetAgent(o.transport, agentCard)
+	activeRecording.SetAgent(o.transport, agentCard)
+	clientLogger.Info("Connected to agent: %s (v%s)", agentCard.Name, agentCard.Version)
+	clientLogger.Info("  Skills: %d", len(agentCard.Skills))
+	extended := extendedSkillIDs(publicCard, agentCard)
+	for _, skill := range agentCard.Skills {
+		if extended[skill.ID] {
+			clientLogger.Info("    - %s: %s (extended)", skill.Name, skill.Description)
+		} else {
+			clientLogger.Info("    - %s: %s", skill.Name, skill.Description)
+		}
+	}
+
+	// On Ctrl-C, cancel the task the agent is still working on before exiting
+	defer handleInterrupts(func(ctx context.Context, taskID a2a.TaskID) error {
+		_, err := client.CancelTask(ctx, taskID)
+		return err
+	})()
+
+	// Task commands replace the message send
+	switch {
+	case o.taskList:
+		runTaskList(ctx, client, &a2a.ListTasksRequest{ContextID: o.contextID, Status: a2a.TaskState(o.taskState)}, out)
+		activeRecording.Save("")
+		return
+	case o.taskGet != "":
+		runTaskGet(ctx, client, o.taskGet, optionalLength(o.historyLength), out)
+		activeRecording.Save("")
+		return
+	case o.taskCancel != "":
+		runTaskCancel(ctx, client, o.taskCancel, out)
+		activeRecording.Save("")
+		return
+	case o.resubscribe != "":
+		runResubscribe(ctx, client, o.resubscribe, out)
+		activeRecording.Save("")
+		exitForTaskState()
+		return
+	case o.chat:
+		agents := &chatAgents{single: client}
+		sessions.Save(o.session, runChat(agents, o.contextID, params.Message.Parts, o.stream, o.timeout, out))
+		return
+	case o.bench:
+		runBench(ctx, client, msgParts, o.contextID, o.stream, o.concurrency, o.requests, out)
+		return
+	case o.loadtest:
+		opts := loadtestOptions{rps: o.rps, ramp: o.ramp, duration: o.duration, interval: o.reportInterval, maxInflight: o.maxInflight, timeout: o.timeout}
+		runLoadtest(ctx, client, msgParts, o.contextID, o.stream, opts, out)
+		return
+	}
+
+	var info a2a.TaskInfo
+	switch {
+	case o.pushListen != "":
+		info = runPushListen(ctx, client, o.pushListen, o.pushURL, params, out)
+	case o.stream:
+		info = sendStreamingMessage(ctx, client, params, out)
+	default:
+		info = sendMessage(ctx, client, params, o.follow, out)
+	}
+
+	// Streams end with the final task snapshot: in the transcript, and as the last event
+	// of structured output
+	if o.stream && info.TaskID != "" && (activeTranscript != nil || out.Structured()) {
+		task, err := client.GetTask(ctx, info.TaskID, nil)
+		if err != nil {
+			clientLogger.Warn("Could not fetch the final task: %v", err)
+		} else {
+			activeTranscript.SetResult(task)
+			if out.Structured() {
+				if err := out.WriteEvent(task); err != nil {
+					clientLogger.Fatal("Failed to write output: %v", err)
+				}
+			}
+		}
+	}
+	activeTranscript.Save("")
+	activeRecording.Save("")
+
+	sessions.Save(o.session, info.ContextID)
+	// The exit status reflects how the task ended, so scripts can branch on it
+	exitForTaskState()
+}
+
+// validate rejects the flag combinations the command cannot honor
+func (o *options) validate() {
+	if o.pushListen != "" && (o.agents != "" || o.stream) {
+		clientLogger.Fatal("--push-listen cannot be combined with --agents or --stream")
+	}
+	if o.follow && (o.stream || o.pushListen != "" || o.agents != "") {
+		clientLogger.Fatal("--follow only applies to single non-streaming sends")
+	}
+	if o.nonBlocking && (o.stream || o.pushListen != "") {
+		clientLogger.Fatal("--non-blocking only applies to non-streaming sends; --push-listen is always non-blocking")
+	}
+	if o.listAgents && countSet(o.agentsFile != "", o.registryURL != "", o.discover) != 1 {
+		clientLogger.Fatal("aloha agents lists the agents of one of --registry, --agents-file or --discover")
+	}
+	if countSet(o.agents != "", o.agentsFile != "", o.registryURL != "", o.discover) > 1 {
+		clientLogger.Fatal("--agents, --agents-file, --registry and --discover cannot be used together")
+	}
+	if (o.agentsFile != "" || o.discover) && (o.pushListen != "" || o.follow || o.saveTranscript != "" || o.recordPath != "") {
+		clientLogger.Fatal("--agents-file and --discover cannot be combined with --push-listen, --follow, --save-transcript or --record")
+	}
+	if (o.agentsFile != "" || o.discover) && !o.chat && !o.listAgents && o.agentName == "" && o.route == "" {
+		clientLogger.Fatal("--agents-file and --discover need --agent to pick the agent to send to; aloha agents lists them")
+	}
+	if o.agentName != "" && o.agentsFile == "" && !o.discover {
+		clientLogger.Fatal("--agent names an agent from --agents-file or --discover")
+	}
+	if o.registryURL != "" && !o.listAgents && o.route == "" {
+		clientLogger.Fatal("--registry needs --route to pick the agent to send to; aloha agents lists them")
+	}
+	if o.route != "" && o.agents == "" && o.registryURL == "" && !o.discover {
+		clientLogger.Fatal("--route needs the candidate agents in --agents, --registry or --discover")
+	}
+	if o.route != "" && o.agentName != "" {
+		clientLogger.Fatal("--route picks the agent itself, so it cannot be combined with --agent")
+	}
+	if o.route != "" && o.route != routeSkills && o.route != routeLLM {
+		clientLogger.Fatal("Unsupported --route %s (use %s or %s)", o.route, routeSkills, routeLLM)
+	}
+	if o.registryURL != "" && (o.pushListen != "" || o.follow || o.session != "" || o.saveTranscript != "" || o.recordPath != "") {
+		clientLogger.Fatal("--registry cannot be combined with --push-listen, --follow, --session, --save-transcript or --record")
+	}
+	if o.tags != "" && o.registryURL == "" {
+		clientLogger.Fatal("--tags filters the agents of --registry")
+	}
+	if o.agents != "" && (o.session != "" || o.saveTranscript != "" || o.recordPath != "") {
+		clientLogger.Fatal("--agents cannot be combined with --session, --save-transcript or --record")
+	}
+	if o.quiet && o.verbose {
+		clientLogger.Fatal("--quiet and --verbose cannot be used together")
+	}
+	if o.quiet && o.agents != "" {
+		clientLogger.Fatal("--quiet cannot be combined with --agents")
+	}
+}
+
+// outputWriter returns the writer of the --output format; --quiet prints only the final text
+func (o *options) outputWriter() (*outputWriter, error) {
+	if o.quiet {
+		if o.output != outputText {
+			return nil, fmt.Errorf("--quiet cannot be combined with --output %s", o.output)
+		}
+		o.output = outputQuiet
+	}
+	return newOutputWriter(o.output)
+}
+
+// agentCardURL returns --card-url, or the URL of the agent at --host and --port
+func (o *options) agentCardURL(conn *connection) string {
+	if o.cardURL != "" {
+		return o.cardURL
+	}
+	return fmt.Sprintf("%s://%s:%d", conn.Scheme(), o.host, o.port)
+}
+
+// resolveAgentCard resolves the agent card from URL or default well-known path
+func resolveAgentCard(ctx context.Context, conn *connection, host string, port int, cardURL string) (*a2a.AgentCard, error) {
+	if cardURL == "" {
+		cardURL = fmt.Sprintf("%s://%s:%d", conn.Scheme(), host, port)
+	}
+
+	clientLogger.Info("Resolving agent card from: %s", cardURL)
+
+	card, err := conn.ResolveCard(ctx, cardURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve agent card from %s: %w", cardURL, err)
+	}
+
+	return card, nil
+}
+
+// sendMessage sends a non-streaming message, displays the result and returns the resulting task info.
+// With follow set, a task that is still running is polled until it settles.
+func sendMessage(ctx context.Context, client *agentClient, params *a2a.MessageSendParams, follow bool, out *outputWriter) a2a.TaskInfo {
+	clientLogger.Info("Sending message (non-streaming)...")
+
+	result, err := client.SendMessage(ctx, params)
+	if err != nil {
+		clientLogger.Fatal("Failed to send message: %v", err)
+	}
+	if follow {
+		result = followTask(ctx, client, result)
+	}
+	inflight.Observe(result)
+	activeTranscript.SetResult(result)
+	activeRecording.AddEvent(result)
+	return printResult(result, out)
+}
+
+// printResult displays the result of a non-streaming send and returns the resulting task info
+func printResult(result a2a.SendMessageResult, out *outputWriter) a2a.TaskInfo {
+	if !out.Text() {
+		if err := out.Write(result); err != nil {
+			clientLogger.Fatal("Failed to write output: %v", err)
+		}
+		return result.TaskInfo()
+	}
+
+	fmt.Println("\n============================================================")
+	fmt.Println("Agent Response:")
+	fmt.Println("============================================================")
+
+	switch r := result.(type) {
+	case *a2a.Task:
+		printTask(r)
+	case *a2a.Message:
+		printMessageParts(r)
+	default:
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+	}
+
+	fmt.Println("============================================================")
+	return result.TaskInfo()
+}
+
+// sendStreamingMessage sends a streaming message, displays events as they arrive and returns the resulting task info
+func sendStreamingMessage(ctx context.Context, client *agentClient, params *a2a.MessageSendParams, out *outputWriter) a2a.TaskInfo {
+	clientLogger.Info("Sending message (streaming)...")
+	return printEventStream(client.SendStreamingMessage(ctx, params), out)
+}
+
+// printEventStream displays streamed events as they arrive and returns the resulting task info
+func printEventStream(events iter.Seq2[a2a.Event, error], out *outputWriter) a2a.TaskInfo {
+	info, err := renderEventStream(events, out)
+	if err != nil {
+		clientLogger.Fatal("Stream error: %v", err)
+	}
+	return info
+}
+
+// renderEventStream displays streamed events as they arrive and returns the resulting task info,
+// or the error that broke the stream
+func renderEventStream(events iter.Seq2[a2a.Event, error], out *outputWriter) (a2a.TaskInfo, error) {
+	if out.Text() {
+		fmt.Println("\n============================================================")
+		fmt.Println("Agent Response (Streaming):")
+		fmt.Println("============================================================")
+	}
+
+	var info a2a.TaskInfo
+	assembler := newArtifactAssembler()
+	for event, err := range events {
+		if err != nil {
+			return info, err
+		}
+		inflight.Observe(event)
+		activeTranscript.AddEvent(event)
+		activeRecording.AddEvent(event)
+		if event.TaskInfo().ContextID != "" {
+			info = event.TaskInfo()
+		}
+
+		if !out.Text() {
+			if err := out.WriteEvent(event); err != nil {
+				clientLogger.Fatal("Failed to write output: %v", err)
+			}
+			continue
+		}
+
+		switch e := event.(type) {
+		case *a2a.Task:
+			fmt.Printf("[Task] %s State: %s\n", e.ID, e.Status.State)
+			for _, artifact := range e.Artifacts {
+				printArtifact(artifact)
+			}
+		case *a2a.TaskStatusUpdateEvent:
+			printStatusUpdate(e)
+		case *a2a.TaskArtifactUpdateEvent:
+			if artifact, complete := assembler.Add(e); complete {
+				printArtifact(artifact)
+			}
+		case *a2a.Message:
+			fmt.Print("[Message] ")
+			printMessageParts(e)
+		default:
+			data, _ := json.Marshal(event)
+			fmt.Printf("[Event] %s\n", string(data))
+		}
+	}
+
+	if out.Text() {
+		// Artifacts from servers that never set lastChunk are printed once the stream ends
+		for _, artifact := range assembler.Flush() {
+			printArtifact(artifact)
+		}
+		fmt.Println("============================================================")
+	} else if err := out.Flush(); err != nil {
+		clientLogger.Fatal("Failed to write output: %v", err)
+	}
+	return info, nil
+}
+
+// printTask prints the status and artifacts of a task
+func printTask(task *a2a.Task) {
+	fmt.Printf("Task ID: %s\n", task.ID)
+	fmt.Printf("State: %s\n", task.Status.State)
+	if task.Status.Message != nil {
+		printMessageParts(task.Status.Message)
+	}
+	for _, artifact := range task.Artifacts {
+		fmt.Println("--- Artifact ---")
+		// Chunked artifacts are aggregated as consecutive text parts
+		for _, part := range appendParts(nil, artifact.Parts) {
+			printPart(part)
+		}
+	}
+}
+
+// printStatusUpdate prints a task status update event on one line
+func printStatusUpdate(event *a2a.TaskStatusUpdateEvent) {
+	fmt.Printf("[Status] State: %s", event.Status.State)
+	if event.Status.Message != nil {
+		fmt.Print(" | ")
+		printMessagePartsInline(event.Status.Message)
+	}
+	fmt.Println()
+	if event.Final {
+		fmt.Println("[Final event]")
+	}
+}
+
+// printArtifact prints a fully assembled artifact
+func printArtifact(artifact *a2a.Artifact) {
+	fmt.Print("[Artifact] ")
+	for _, part := range appendParts(nil, artifact.Parts) {
+		printPart(part)
+	}
+}
+
+// printMessageParts prints all parts of a message
+func printMessageParts(msg *a2a.Message) {
+	for _, part := range msg.Parts {
+		printPart(part)
+	}
+}
+
+// printMessagePartsInline prints message parts inline
+func printMessagePartsInline(msg *a2a.Message) {
+	for _, part := range msg.Parts {
+		switch p := part.(type) {
+		case a2a.TextPart:
+			fmt.Print(p.Text)
+		default:
+			fmt.Printf("[%T]", p)
+		}
+	}
+}
+
+// printPart prints a single message part
+func printPart(part a2a.Part) {
+	switch p := part.(type) {
+	case a2a.TextPart:
+		fmt.Println(p.Text)
+	case a2a.FilePart:
+		switch f := p.File.(type) {
+		case a2a.FileBytes:
+			fmt.Printf("[File: %s (%s, %d base64 chars)]\n", f.Name, f.MimeType, len(f.Bytes))
+		case a2a.FileURI:
+			fmt.Printf("[File: %s (%s) %s]\n", f.Name, f.MimeType, f.URI)
+		default:
+			fmt.Printf("[File part]\n")
+		}
+	case a2a.DataPart:
+		data, _ := json.MarshalIndent(p.Data, "", "  ")
+		fmt.Printf("[Data: %s]\n", string(data))
+	default:
+		fmt.Printf("[Unknown part type: %T]\n", p)
+	}
+}
+
+// sendConfig builds the message send configuration from --non-blocking, --history-length
+// and --accept, or returns nil when none of them is set
+func sendConfig(nonBlocking bool, historyLength int, accept string) *a2a.MessageSendConfig {
+	config := &a2a.MessageSendConfig{AcceptedOutputModes: splitList(accept)}
+	if nonBlocking {
+		blocking := false
+		config.Blocking = &blocking
+	}
+	config.HistoryLength = optionalLength(historyLength)
+	if config.Blocking == nil && config.HistoryLength == nil && len(config.AcceptedOutputModes) == 0 {
+		return nil
+	}
+	return config
+}
+
+// optionalLength returns --history-length as an optional value, nil when it is negative (unset)
+func optionalLength(length int) *int {
+	if length < 0 {
+		return nil
+	}
+	return &length
+}
+
+// countSet returns how many of the given options are set
+func countSet(options ...bool) int {
+	n := 0
+	for _, set := range options {
+		if set {
+			n++
+		}
+	}
+	return n
+}
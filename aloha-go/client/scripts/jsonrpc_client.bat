@@ -1,3 +1,3 @@
 @echo off
-cd /d %~dp0..
-go run . --transport jsonrpc --port 12001 --message "Roll a 6-sided dice"
+cd /d %~dp0..\..
+go run ./aloha send --transport jsonrpc --port 12001 "Roll a 6-sided dice"
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// sessionState is the persisted conversation state of a named session
+type sessionState struct {
+	ContextID string    `json:"contextId"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// sessionStore persists named sessions in a local JSON state file so
+// consecutive client runs can continue the same conversation
+type sessionStore struct {
+	path     string
+	Sessions map[string]*sessionState `json:"sessions"`
+}
+
+// sessionFilePath returns the session state file path.
+// ALOHA_SESSION_FILE overrides the default under the user config directory.
+func sessionFilePath() (string, error) {
+	if path := os.Getenv("ALOHA_SESSION_FILE"); path != "" {
+		return path, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "aloha-a2a", "sessions.json"), nil
+}
+
+// loadSessionStore reads the session state file, returning an empty store if it does not exist yet
+func loadSessionStore() (*sessionStore, error) {
+	path, err := sessionFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &sessionStore{path: path, Sessions: make(map[string]*sessionState)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session file %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse session file %s: %w", path, err)
+	}
+	if store.Sessions == nil {
+		store.Sessions = make(map[string]*sessionState)
+	}
+	return store, nil
+}
+
+// ContextID returns the stored contextId of a session, or "" if the session is new
+func (s *sessionStore) ContextID(name string) string {
+	if state, ok := s.Sessions[name]; ok {
+		return state.ContextID
+	}
+	return ""
+}
+
+// SetContextID records the contextId of a session and writes the state file
+func (s *sessionStore) SetContextID(name, contextID string) error {
+	s.Sessions[name] = &sessionState{ContextID: contextID, UpdatedAt: time.Now().UTC()}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sessions: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write session file %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// Save stores the contextId of a finished exchange under the session name, logging the outcome.
+// It is a no-op on a nil store (no --session) or without a contextId.
+func (s *sessionStore) Save(name, contextID string) {
+	if s == nil || contextID == "" {
+		return
+	}
+	if err := s.SetContextID(name, contextID); err != nil {
+		clientLogger.Warn("Failed to save session %s: %v", name, err)
+		return
+	}
+	clientLogger.Info("Session %s saved with context ID %s", name, contextID)
+}
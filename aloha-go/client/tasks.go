@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// runTaskGet handles --task-get: fetches a task and displays it
+func runTaskGet(ctx context.Context, client *agentClient, taskID string, historyLength *int, out *outputWriter) {
+	clientLogger.Info("Getting task %s...", taskID)
+
+	task, err := client.GetTask(ctx, a2a.TaskID(taskID), historyLength)
+	if err != nil {
+		clientLogger.Fatal("Failed to get task: %v", err)
+	}
+
+	writeTask(task, "Task:", out)
+}
+
+// runTaskCancel handles --task-cancel: cancels a task and displays its final state
+func runTaskCancel(ctx context.Context, client *agentClient, taskID string, out *outputWriter) {
+	clientLogger.Info("Canceling task %s...", taskID)
+
+	task, err := client.CancelTask(ctx, a2a.TaskID(taskID))
+	if err != nil {
+		clientLogger.Fatal("Failed to cancel task: %v", err)
+	}
+
+	writeTask(task, "Canceled Task:", out)
+}
+
+// runTaskList handles --task-list: lists every page of matching tasks, most recently updated first
+func runTaskList(ctx context.Context, client *agentClient, req *a2a.ListTasksRequest, out *outputWriter) {
+	clientLogger.Info("Listing tasks...")
+
+	tasks := []*a2a.Task{}
+	for {
+		resp, err := client.ListTasks(ctx, req)
+		if err != nil {
+			clientLogger.Fatal("Failed to list tasks: %v", err)
+		}
+		tasks = append(tasks, resp.Tasks...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	if !out.Text() {
+		if err := out.Write(tasks); err != nil {
+			clientLogger.Fatal("Failed to write output: %v", err)
+		}
+		return
+	}
+
+	fmt.Println("\n============================================================")
+	fmt.Printf("Tasks: %d\n", len(tasks))
+	fmt.Println("============================================================")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TASK ID\tCONTEXT ID\tSTATE\tUPDATED")
+	for _, task := range tasks {
+		updated := "-"
+		if task.Status.Timestamp != nil {
+			updated = task.Status.Timestamp.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", task.ID, task.ContextID, task.Status.State, updated)
+	}
+	w.Flush()
+	fmt.Println("============================================================")
+}
+
+// writeTask renders a task in the selected output format
+func writeTask(task *a2a.Task, title string, out *outputWriter) {
+	if !out.Text() {
+		if err := out.Write(task); err != nil {
+			clientLogger.Fatal("Failed to write output: %v", err)
+		}
+		return
+	}
+
+	fmt.Println("\n============================================================")
+	fmt.Println(title)
+	fmt.Println("============================================================")
+	printTask(task)
+	fmt.Println("============================================================")
+}
+
+// runResubscribe handles --resubscribe: reattaches to a running task and prints its events until it ends
+func runResubscribe(ctx context.Context, client *agentClient, taskID string, out *outputWriter) a2a.TaskInfo {
+	clientLogger.Info("Resubscribing to task %s...", taskID)
+	return printEventStream(client.conn.ReconnectStream(ctx, client.Resubscribe(ctx, a2a.TaskID(taskID)), client.Resubscribe), out)
+}
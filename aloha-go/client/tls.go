@@ -0,0 +1,65 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// tlsOptions holds the TLS-related command-line flags
+type tlsOptions struct {
+	enabled    bool
+	caCert     string
+	clientCert string
+	clientKey  string
+	serverName string
+	insecure   bool
+}
+
+// Enabled reports whether TLS should be used; any TLS flag implies it
+func (o tlsOptions) Enabled() bool {
+	return o.enabled || o.caCert != "" || o.clientCert != "" || o.clientKey != "" || o.serverName != "" || o.insecure
+}
+
+// Config builds the tls.Config for the flags, or nil when TLS is disabled
+func (o tlsOptions) Config() (*tls.Config, error) {
+	if !o.Enabled() {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.insecure,
+		// Verify the certificate against this name instead of the dialed host
+		ServerName: o.serverName,
+	}
+
+	if o.caCert != "" {
+		pem, err := os.ReadFile(o.caCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate %s: %w", o.caCert, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in %s", o.caCert)
+		}
+		config.RootCAs = pool
+	}
+
+	if o.clientCert != "" || o.clientKey != "" {
+		if o.clientCert == "" || o.clientKey == "" {
+			return nil, fmt.Errorf("--client-cert and --client-key must be used together")
+		}
+		cert, err := tls.LoadX509KeyPair(o.clientCert, o.clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if o.insecure {
+		clientLogger.Warn("TLS certificate verification is disabled (--insecure)")
+	}
+	return config, nil
+}
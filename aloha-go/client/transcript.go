@@ -0,0 +1,175 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// transcriptEntry is one streamed event with the time it was received
+type transcriptEntry struct {
+	ReceivedAt time.Time `json:"receivedAt"`
+	Event      a2a.Event `json:"event"`
+}
+
+// transcript records a full exchange with an agent for --save-transcript.
+// Methods are no-ops on a nil transcript, so recording points need no checks.
+type transcript struct {
+	path       string
+	StartedAt  time.Time              `json:"startedAt"`
+	FinishedAt time.Time              `json:"finishedAt"`
+	Transport  string                 `json:"transport"`
+	AgentName  string                 `json:"agentName,omitempty"`
+	AgentURL   string                 `json:"agentUrl,omitempty"`
+	Request    *a2a.MessageSendParams `json:"request"`
+	Events     []transcriptEntry      `json:"events,omitempty"`
+	Result     a2a.SendMessageResult  `json:"result,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// activeTranscript is the exchange being recorded, or nil when --save-transcript is not set
+var activeTranscript *transcript
+
+// newTranscript starts recording; the format is Markdown for .md/.markdown paths and JSON otherwise
+func newTranscript(path, transport string, request *a2a.MessageSendParams) *transcript {
+	return &transcript{path: path, StartedAt: time.Now().UTC(), Transport: transport, Request: request}
+}
+
+// SetAgent records the agent the exchange is with and the transport finally used
+func (t *transcript) SetAgent(transport string, card *a2a.AgentCard) {
+	if t == nil || card == nil {
+		return
+	}
+	t.Transport = transport
+	t.AgentName, t.AgentURL = card.Name, card.URL
+}
+
+// AddEvent records a streamed event
+func (t *transcript) AddEvent(event a2a.Event) {
+	if t == nil {
+		return
+	}
+	t.Events = append(t.Events, transcriptEntry{ReceivedAt: time.Now().UTC(), Event: event})
+}
+
+// SetResult records the final task or message
+func (t *transcript) SetResult(result a2a.SendMessageResult) {
+	if t == nil {
+		return
+	}
+	t.Result = result
+}
+
+// Save writes the transcript, recording errMsg if the exchange failed
+func (t *transcript) Save(errMsg string) {
+	if t == nil {
+		return
+	}
+	t.FinishedAt = time.Now().UTC()
+	t.Error = errMsg
+
+	var data []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(t.path)) {
+	case ".md", ".markdown":
+		data, err = t.markdown()
+	default:
+		data, err = json.MarshalIndent(t, "", "  ")
+	}
+	if err != nil {
+		clientLogger.Warn("Failed to render transcript: %v", err)
+		return
+	}
+	if err := os.WriteFile(t.path, data, 0o644); err != nil {
+		clientLogger.Warn("Failed to save transcript %s: %v", t.path, err)
+		return
+	}
+	clientLogger.Info("Transcript saved to %s", t.path)
+}
+
+// markdown renders the transcript as a readable report with the raw payloads in JSON blocks
+func (t *transcript) markdown() ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("# A2A Transcript\n\n")
+	if t.AgentName != "" {
+		fmt.Fprintf(&b, "- **Agent:** %s (%s)\n", t.AgentName, t.AgentURL)
+	}
+	fmt.Fprintf(&b, "- **Transport:** %s\n", t.Transport)
+	fmt.Fprintf(&b, "- **Started:** %s\n", t.StartedAt.Format(time.RFC3339Nano))
+	fmt.Fprintf(&b, "- **Finished:** %s\n", t.FinishedAt.Format(time.RFC3339Nano))
+
+	b.WriteString("\n## Request\n\n")
+	if text := messageText(t.Request.Message); text != "" {
+		fmt.Fprintf(&b, "> %s\n\n", strings.ReplaceAll(text, "\n", "\n> "))
+	}
+	if err := writeJSONBlock(&b, t.Request); err != nil {
+		return nil, err
+	}
+
+	if len(t.Events) > 0 {
+		b.WriteString("\n## Events\n")
+		for i, entry := range t.Events {
+			fmt.Fprintf(&b, "\n### %d. %s `%s`\n\n", i+1, eventSummary(entry.Event), entry.ReceivedAt.Format("15:04:05.000"))
+			if err := writeJSONBlock(&b, entry.Event); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if t.Result != nil {
+		b.WriteString("\n## Result\n\n")
+		if err := writeJSONBlock(&b, t.Result); err != nil {
+			return nil, err
+		}
+	}
+
+	if t.Error != "" {
+		fmt.Fprintf(&b, "\n## Error\n\n```\n%s\n```\n", t.Error)
+	}
+	return []byte(b.String()), nil
+}
+
+// eventSummary returns a one-line description of an event for the Markdown headings
+func eventSummary(event a2a.Event) string {
+	switch e := event.(type) {
+	case *a2a.Task:
+		return fmt.Sprintf("task (%s)", e.Status.State)
+	case *a2a.TaskStatusUpdateEvent:
+		return fmt.Sprintf("status-update (%s)", e.Status.State)
+	case *a2a.TaskArtifactUpdateEvent:
+		return fmt.Sprintf("artifact-update (%s)", e.Artifact.ID)
+	case *a2a.Message:
+		return "message"
+	default:
+		return fmt.Sprintf("%T", event)
+	}
+}
+
+// messageText joins the text parts of a message
+func messageText(msg *a2a.Message) string {
+	if msg == nil {
+		return ""
+	}
+	var texts []string
+	for _, part := range msg.Parts {
+		if text, ok := part.(a2a.TextPart); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// writeJSONBlock writes v as an indented JSON code block
+func writeJSONBlock(b *strings.Builder, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal transcript entry: %w", err)
+	}
+	fmt.Fprintf(b, "```json\n%s\n```\n", data)
+	return nil
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// redactedHeaders are masked in --verbose dumps and --record files so credentials stay out of them
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", defaultAPIKeyHeader}
+
+// wireTransport observes every HTTP request and response, dumping them for --verbose
+// and adding them to activeRecording for --record. Event-stream bodies are observed
+// chunk by chunk as they are read, so streaming is not delayed.
+type wireTransport struct {
+	base http.RoundTripper
+	conn *connection
+	dump bool
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *wireTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	shown := req.Clone(req.Context())
+	for _, name := range t.redacted() {
+		if shown.Header.Get(name) != "" {
+			shown.Header.Set(name, "REDACTED")
+		}
+	}
+	var body []byte
+	if req.Body != nil && req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+	shown.Body = nil
+	if len(body) > 0 {
+		shown.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if t.dump {
+		if dump, err := httputil.DumpRequestOut(shown, shown.Body != nil); err == nil {
+			clientLogger.Debug(">>> HTTP request\n%s", dump)
+		}
+	}
+	activeRecording.AddWire(wireRecord{Protocol: "http", Direction: "request", Method: req.Method, URL: req.URL.String(), Headers: shown.Header}.withPayload(body))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		if t.dump {
+			clientLogger.Debug("<<< HTTP error: %v", err)
+		}
+		activeRecording.AddWire(wireRecord{Protocol: "http", Direction: "error", Method: req.Method, URL: req.URL.String(), Error: err.Error()})
+		return resp, err
+	}
+
+	streaming := strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream")
+	var respBody []byte
+	if !streaming {
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	}
+	if t.dump {
+		if dump, err := httputil.DumpResponse(resp, !streaming); err == nil {
+			clientLogger.Debug("<<< HTTP response\n%s", dump)
+		}
+	}
+	activeRecording.AddWire(wireRecord{Protocol: "http", Direction: "response", Method: req.Method, URL: req.URL.String(), Status: resp.Status, Headers: resp.Header}.withPayload(respBody))
+	if streaming {
+		resp.Body = &wireStreamReader{ReadCloser: resp.Body, url: req.URL.String(), dump: t.dump}
+	}
+	return resp, nil
+}
+
+// redacted returns the header names to mask, including the API key header named by the card
+func (t *wireTransport) redacted() []string {
+	if t.conn.auth.apiKeyHeader == "" {
+		return redactedHeaders
+	}
+	return append(redactedHeaders[:len(redactedHeaders):len(redactedHeaders)], t.conn.auth.apiKeyHeader)
+}
+
+// wireStreamReader observes the chunks of a streamed response body as they arrive
+type wireStreamReader struct {
+	io.ReadCloser
+	url  string
+	dump bool
+}
+
+// Read implements io.Reader
+func (r *wireStreamReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		if r.dump {
+			clientLogger.Debug("<<< HTTP stream\n%s", p[:n])
+		}
+		activeRecording.AddWire(wireRecord{Protocol: "http", Direction: "stream", URL: r.url, Body: string(p[:n])})
+	}
+	return n, err
+}
+
+// wireDialOptions returns gRPC interceptors that observe every request and response message,
+// dumping them for --verbose and adding them to activeRecording for --record
+func wireDialOptions(dump bool) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			observeGRPC(dump, ">>>", "request", method, req, nil)
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err != nil {
+				observeGRPC(dump, "<<<", "error", method, nil, err)
+				return err
+			}
+			observeGRPC(dump, "<<<", "response", method, reply, nil)
+			return nil
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			stream, err := streamer(ctx, desc, cc, method, opts...)
+			if err != nil {
+				observeGRPC(dump, "<<<", "error", method, nil, err)
+				return nil, err
+			}
+			return &wireClientStream{ClientStream: stream, method: method, dump: dump}, nil
+		}),
+	}
+}
+
+// wireClientStream observes the messages sent and received on a gRPC stream
+type wireClientStream struct {
+	grpc.ClientStream
+	method string
+	dump   bool
+}
+
+// SendMsg implements grpc.ClientStream
+func (s *wireClientStream) SendMsg(m any) error {
+	observeGRPC(s.dump, ">>>", "request", s.method, m, nil)
+	return s.ClientStream.SendMsg(m)
+}
+
+// RecvMsg implements grpc.ClientStream
+func (s *wireClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		observeGRPC(s.dump, "<<<", "stream", s.method, m, nil)
+	} else if err != io.EOF {
+		observeGRPC(s.dump, "<<<", "error", s.method, nil, err)
+	}
+	return err
+}
+
+// observeGRPC dumps and records one gRPC message or error
+func observeGRPC(dump bool, arrow, direction, method string, m any, err error) {
+	if err != nil {
+		if dump {
+			clientLogger.Debug("%s gRPC %s error: %v", arrow, method, err)
+		}
+		activeRecording.AddWire(wireRecord{Protocol: "grpc", Direction: direction, Method: method, Error: err.Error()})
+		return
+	}
+	payload := formatProto(m)
+	if dump {
+		clientLogger.Debug("%s gRPC %s\n%s", arrow, method, payload)
+	}
+	activeRecording.AddWire(wireRecord{Protocol: "grpc", Direction: direction, Method: method}.withPayload([]byte(payload)))
+}
+
+// formatProto renders a gRPC message as indented JSON
+func formatProto(m any) string {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Sprintf("%v", m)
+	}
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(msg)
+	if err != nil {
+		return fmt.Sprintf("%v", m)
+	}
+	return string(data)
+}
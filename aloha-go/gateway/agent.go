@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/registry"
+)
+
+// agentCardPath is where agents serve their card
+const agentCardPath = "/.well-known/agent-card.json"
+
+// maxCardSize bounds an agent card fetched from a backend
+const maxCardSize = 1 << 20
+
+// Path segments of the interfaces the gateway forwards, under /agents/<name>/
+const (
+	jsonrpcSegment = "jsonrpc"
+	restSegment    = "rest"
+)
+
+// bearerSchemeName is the security scheme of cards served when the gateway requires tokens
+const bearerSchemeName = a2a.SecuritySchemeName("bearer")
+
+// agent is a backend agent and its last fetched card
+type agent struct {
+	config agentConfig
+	http   *http.Client
+
+	mu      sync.RWMutex
+	card    *a2a.AgentCard
+	targets map[string]*url.URL // interface URL by path segment
+	err     error               // why the last fetch failed
+}
+
+func newAgent(cfg agentConfig) *agent {
+	return &agent{config: cfg, http: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// refresh fetches the card of the agent. A failed fetch keeps the previous card.
+func (a *agent) refresh(ctx context.Context) error {
+	card, err := a.fetchCard(ctx)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.err = err
+	if err != nil {
+		return err
+	}
+	a.card = card
+	a.targets = interfaceTargets(card)
+	return nil
+}
+
+func (a *agent) fetchCard(ctx context.Context) (*a2a.AgentCard, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(a.config.URL, "/")+agentCardPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	if a.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.config.Token)
+	}
+	resp, err := a.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch agent card: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch agent card: %s", resp.Status)
+	}
+	card := new(a2a.AgentCard)
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxCardSize)).Decode(card); err != nil {
+		return nil, fmt.Errorf("invalid agent card: %w", err)
+	}
+	if len(interfaceTargets(card)) == 0 {
+		return nil, fmt.Errorf("agent card has no JSON-RPC or REST interface")
+	}
+	return card, nil
+}
+
+// interfaceTargets returns the JSON-RPC and REST URLs of a card by path segment. gRPC
+// interfaces are left out: the gateway only forwards HTTP.
+func interfaceTargets(card *a2a.AgentCard) map[string]*url.URL {
+	targets := make(map[string]*url.URL)
+	add := func(transport a2a.TransportProtocol, rawURL string) {
+		var segment string
+		switch transport {
+		case a2a.TransportProtocolJSONRPC:
+			segment = jsonrpcSegment
+		case a2a.TransportProtocolHTTPJSON:
+			segment = restSegment
+		default:
+			return
+		}
+		if _, ok := targets[segment]; ok {
+			return
+		}
+		if u, err := url.Parse(rawURL); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+			targets[segment] = u
+		}
+	}
+	transport := card.PreferredTransport
+	if transport == "" {
+		transport = a2a.TransportProtocolJSONRPC
+	}
+	add(transport, card.URL)
+	for _, iface := range card.AdditionalInterfaces {
+		add(iface.Transport, iface.URL)
+	}
+	return targets
+}
+
+// state returns the current card and interface URLs, nil until a fetch succeeds
+func (a *agent) state() (*a2a.AgentCard, map[string]*url.URL, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.card, a.targets, a.err
+}
+
+// publicCard returns the card of the agent as served by the gateway at base: its JSON-RPC
+// and REST interfaces point at the gateway, and the gateway's authentication replaces the
+// agent's. Signatures no longer match, so they are dropped.
+func (a *agent) publicCard(base string, requireAuth bool) (*a2a.AgentCard, bool) {
+	card, targets, _ := a.state()
+	if card == nil {
+		return nil, false
+	}
+	public := *card
+	prefix := base + "/agents/" + a.config.Name + "/"
+
+	public.AdditionalInterfaces = nil
+	for _, transport := range []a2a.TransportProtocol{a2a.TransportProtocolJSONRPC, a2a.TransportProtocolHTTPJSON} {
+		segment := jsonrpcSegment
+		if transport == a2a.TransportProtocolHTTPJSON {
+			segment = restSegment
+		}
+		if _, ok := targets[segment]; ok {
+			public.AdditionalInterfaces = append(public.AdditionalInterfaces, a2a.AgentInterface{Transport: transport, URL: prefix + segment})
+		}
+	}
+	// gRPC is not forwarded, so an agent preferring it is reached over JSON-RPC or REST
+	preferred := public.PreferredTransport
+	if preferred == "" {
+		preferred = a2a.TransportProtocolJSONRPC
+	}
+	public.PreferredTransport = public.AdditionalInterfaces[0].Transport
+	public.URL = public.AdditionalInterfaces[0].URL
+	for _, iface := range public.AdditionalInterfaces {
+		if iface.Transport == preferred {
+			public.PreferredTransport, public.URL = iface.Transport, iface.URL
+		}
+	}
+
+	public.SecuritySchemes, public.Security = nil, nil
+	if requireAuth {
+		public.SecuritySchemes = a2a.NamedSecuritySchemes{bearerSchemeName: a2a.HTTPAuthSecurityScheme{Scheme: "Bearer"}}
+		public.Security = []a2a.SecurityRequirements{{bearerSchemeName: a2a.SecuritySchemeScopes{}}}
+	}
+	public.Signatures = nil
+	return &public, true
+}
+
+// ownsSkill reports whether the card of the agent lists the skill
+func (a *agent) ownsSkill(skillID string) bool {
+	card, _, _ := a.state()
+	if card == nil {
+		return false
+	}
+	for _, skill := range card.Skills {
+		if skill.ID == skillID {
+			return true
+		}
+	}
+	return false
+}
+
+// matchScore counts the skill tags of the agent found in text, ignoring case
+func (a *agent) matchScore(text string) int {
+	card, _, _ := a.state()
+	if card == nil {
+		return 0
+	}
+	text = strings.ToLower(text)
+	score := 0
+	for _, tag := range registry.Tags(card) {
+		if strings.Contains(text, strings.ToLower(tag)) {
+			score++
+		}
+	}
+	return score
+}
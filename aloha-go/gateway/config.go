@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config is the gateway configuration file
+type config struct {
+	// PublicURL is the URL clients reach the gateway at, used in rewritten agent cards;
+	// empty uses the scheme and host of each request
+	PublicURL string `yaml:"public_url"`
+	TLS       struct {
+		CertFile string `yaml:"cert_file"`
+		KeyFile  string `yaml:"key_file"`
+	} `yaml:"tls"`
+	// AuthTokens is a JSON file of bearer tokens, in the format of the server's
+	// AUTH_TOKENS_FILE; when set, every call through the gateway needs one
+	AuthTokens string `yaml:"auth_tokens"`
+	RateLimit  struct {
+		// RequestsPerSecond is the sustained rate allowed to each client; 0 disables limits
+		RequestsPerSecond float64 `yaml:"requests_per_second"`
+		// Burst is the number of requests a client may make at once (default the rate, at least 1)
+		Burst int `yaml:"burst"`
+	} `yaml:"rate_limit"`
+	// CardRefresh is how often the agent cards are fetched again (default 1m)
+	CardRefresh time.Duration `yaml:"card_refresh"`
+	Agents      []agentConfig `yaml:"agents"`
+}
+
+// agentConfig is a backend agent
+type agentConfig struct {
+	// Name is the path segment of the agent: /agents/<name>/...
+	Name string `yaml:"name"`
+	// URL serves the agent card at /.well-known/agent-card.json
+	URL string `yaml:"url"`
+	// Token is sent as a bearer token to agents that require authentication
+	Token string `yaml:"token"`
+}
+
+// principal is an authenticated caller, as in the server's tokens file
+type principal struct {
+	Subject string   `json:"subject"`
+	Scopes  []string `json:"scopes,omitempty"`
+}
+
+// agentNamePattern restricts agent names to a single URL path segment
+var agentNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// loadConfig reads and validates the configuration file
+func loadConfig(path string) (*config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	cfg := &config{CardRefresh: time.Minute}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	if cfg.PublicURL != "" {
+		if u, err := url.Parse(cfg.PublicURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("public_url must be an http(s) URL, got %q", cfg.PublicURL)
+		}
+		cfg.PublicURL = strings.TrimSuffix(cfg.PublicURL, "/")
+	}
+	if (cfg.TLS.CertFile == "") != (cfg.TLS.KeyFile == "") {
+		return nil, fmt.Errorf("tls needs both cert_file and key_file")
+	}
+	if cfg.RateLimit.RequestsPerSecond < 0 || cfg.RateLimit.Burst < 0 {
+		return nil, fmt.Errorf("rate_limit values must not be negative")
+	}
+	if cfg.CardRefresh <= 0 {
+		return nil, fmt.Errorf("card_refresh must be a positive duration such as 1m")
+	}
+	if len(cfg.Agents) == 0 {
+		return nil, fmt.Errorf("no agents configured")
+	}
+	seen := make(map[string]bool)
+	for _, agent := range cfg.Agents {
+		if !agentNamePattern.MatchString(agent.Name) {
+			return nil, fmt.Errorf("agent name %q must be lowercase letters, digits, '-' and '_'", agent.Name)
+		}
+		if seen[agent.Name] {
+			return nil, fmt.Errorf("agent %s is configured twice", agent.Name)
+		}
+		seen[agent.Name] = true
+		if u, err := url.Parse(agent.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("agent %s: url must be the http(s) URL serving its card, got %q", agent.Name, agent.URL)
+		}
+	}
+	return cfg, nil
+}
+
+// loadTokens reads a JSON file mapping bearer tokens to principals:
+//
+//	{"secret-token": {"subject": "alice", "scopes": ["dice"]}}
+func loadTokens(path string) (map[string]*principal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read auth tokens file %s: %w", path, err)
+	}
+	tokens := make(map[string]*principal)
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse auth tokens file %s: %w", path, err)
+	}
+	for token, p := range tokens {
+		if p == nil || p.Subject == "" {
+			return nil, fmt.Errorf("auth token %q has no subject", maskToken(token))
+		}
+	}
+	return tokens, nil
+}
+
+// maskToken hides all but the first characters of a token for logging
+func maskToken(token string) string {
+	if len(token) <= 4 {
+		return "****"
+	}
+	return token[:4] + "****"
+}
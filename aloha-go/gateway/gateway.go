@@ -0,0 +1,496 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// maxRequestSize bounds a JSON-RPC request routed by skill, which is read to route it
+const maxRequestSize = 10 << 20
+
+// maxTrackedTasks is the number of task owners remembered for skill-routed task calls
+const maxTrackedTasks = 10000
+
+// gateway fronts the backend agents: it authenticates and rate limits callers, serves the
+// agents' cards rewritten to its public URL and forwards their calls
+type gateway struct {
+	cfg     *config
+	agents  []*agent
+	byName  map[string]*agent
+	tokens  map[string]*principal // nil when authentication is disabled
+	limiter *rateLimiter          // nil when rate limiting is disabled
+	tasks   *taskOwners
+	proxy   *httputil.ReverseProxy
+}
+
+// routeKey is the context key of the route of a forwarded request
+type routeKey struct{}
+
+// route is where a request is forwarded: the interface of an agent and the path under it
+type route struct {
+	agent  *agent
+	target *url.URL
+	path   string
+	// track records the owner of the tasks in the response, for skill-routed calls
+	track bool
+}
+
+func newGateway(cfg *config) (*gateway, error) {
+	g := &gateway{
+		cfg:     cfg,
+		byName:  make(map[string]*agent),
+		limiter: newRateLimiter(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst),
+		tasks:   newTaskOwners(maxTrackedTasks),
+	}
+	if cfg.AuthTokens != "" {
+		tokens, err := loadTokens(cfg.AuthTokens)
+		if err != nil {
+			return nil, err
+		}
+		g.tokens = tokens
+	}
+	for _, agentCfg := range cfg.Agents {
+		a := newAgent(agentCfg)
+		g.agents = append(g.agents, a)
+		g.byName[agentCfg.Name] = a
+	}
+
+	g.proxy = &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			rt := pr.In.Context().Value(routeKey{}).(*route)
+			pr.Out.URL.Path, pr.Out.URL.RawPath = rt.path, ""
+			pr.SetURL(rt.target)
+			pr.SetXForwarded()
+			// The gateway terminates authentication: agents see its own token, if any
+			pr.Out.Header.Del("Authorization")
+			pr.Out.Header.Del("X-API-Key")
+			if rt.agent.config.Token != "" {
+				pr.Out.Header.Set("Authorization", "Bearer "+rt.agent.config.Token)
+			}
+			// Left to the transport, responses are decompressed before tasks are tracked
+			pr.Out.Header.Del("Accept-Encoding")
+		},
+		// Streamed events reach the client as soon as the agent sends them
+		FlushInterval:  -1,
+		ModifyResponse: g.trackTasks,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			rt := r.Context().Value(routeKey{}).(*route)
+			logf("WARN", "Agent %s unreachable: %v", rt.agent.config.Name, err)
+			writeError(w, http.StatusBadGateway, protocol.ErrInternal.Withf("agent %s unreachable", rt.agent.config.Name))
+		},
+	}
+	return g, nil
+}
+
+// handler returns the routes of the gateway
+func (g *gateway) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /agents", g.handleList)
+	mux.HandleFunc("GET /agents/{name}"+agentCardPath, g.handleAgentCard)
+	mux.HandleFunc("/agents/{name}/{iface}", g.handleForward)
+	mux.HandleFunc("/agents/{name}/{iface}/{rest...}", g.handleForward)
+	mux.HandleFunc("GET "+agentCardPath, g.handleGatewayCard)
+	mux.HandleFunc("POST /{$}", g.handleSkillRoute)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, protocol.ErrMethodNotFound.Withf("%s %s", r.Method, r.URL.Path))
+	})
+	return mux
+}
+
+// publicBase returns the URL clients reach the gateway at
+func (g *gateway) publicBase(r *http.Request) string {
+	if g.cfg.PublicURL != "" {
+		return g.cfg.PublicURL
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// handleList handles GET /agents: the agents behind the gateway and their card URLs
+func (g *gateway) handleList(w http.ResponseWriter, r *http.Request) {
+	type agentInfo struct {
+		Name      string   `json:"name"`
+		URL       string   `json:"url"`
+		AgentName string   `json:"agentName,omitempty"`
+		Skills    []string `json:"skills,omitempty"`
+		Available bool     `json:"available"`
+		Error     string   `json:"error,omitempty"`
+	}
+	base := g.publicBase(r)
+	list := make([]agentInfo, 0, len(g.agents))
+	for _, a := range g.agents {
+		card, _, err := a.state()
+		info := agentInfo{Name: a.config.Name, URL: base + "/agents/" + a.config.Name, Available: card != nil}
+		if card != nil {
+			info.AgentName = card.Name
+			for _, skill := range card.Skills {
+				info.Skills = append(info.Skills, skill.ID)
+			}
+		}
+		if err != nil {
+			info.Error = err.Error()
+		}
+		list = append(list, info)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"agents": list})
+}
+
+// handleAgentCard serves the card of an agent, rewritten to point at the gateway
+func (g *gateway) handleAgentCard(w http.ResponseWriter, r *http.Request) {
+	a, ok := g.byName[r.PathValue("name")]
+	if !ok {
+		writeError(w, http.StatusNotFound, protocol.ErrInvalidParams.Withf("no agent named %s", r.PathValue("name")))
+		return
+	}
+	card, ok := a.publicCard(g.publicBase(r), g.tokens != nil)
+	if !ok {
+		writeError(w, http.StatusServiceUnavailable, protocol.ErrInternal.Withf("card of agent %s unavailable", a.config.Name))
+		return
+	}
+	writeJSON(w, http.StatusOK, card)
+}
+
+// handleGatewayCard serves the card of the gateway itself: one JSON-RPC endpoint with
+// the skills of every agent, routing each message to the agent owning its skill
+func (g *gateway) handleGatewayCard(w http.ResponseWriter, r *http.Request) {
+	card := &a2a.AgentCard{
+		Name:               "A2A Gateway",
+		Description:        "Routes each message to the agent behind the gateway that owns its skill",
+		URL:                g.publicBase(r) + "/",
+		PreferredTransport: a2a.TransportProtocolJSONRPC,
+		ProtocolVersion:    string(a2a.Version),
+		DefaultInputModes:  []string{"text"},
+		DefaultOutputModes: []string{"text"},
+		Skills:             []a2a.AgentSkill{},
+	}
+	seen := make(map[string]bool)
+	for _, a := range g.agents {
+		agentCard, _, _ := a.state()
+		if agentCard == nil {
+			continue
+		}
+		card.Capabilities.Streaming = card.Capabilities.Streaming || agentCard.Capabilities.Streaming
+		for _, skill := range agentCard.Skills {
+			if !seen[skill.ID] {
+				seen[skill.ID] = true
+				card.Skills = append(card.Skills, skill)
+			}
+		}
+	}
+	if g.tokens != nil {
+		card.SecuritySchemes = a2a.NamedSecuritySchemes{bearerSchemeName: a2a.HTTPAuthSecurityScheme{Scheme: "Bearer"}}
+		card.Security = []a2a.SecurityRequirements{{bearerSchemeName: a2a.SecuritySchemeScopes{}}}
+	}
+	writeJSON(w, http.StatusOK, card)
+}
+
+// handleForward forwards a call to /agents/<name>/<jsonrpc|rest>/... to that interface
+func (g *gateway) handleForward(w http.ResponseWriter, r *http.Request) {
+	a, ok := g.byName[r.PathValue("name")]
+	if !ok {
+		writeError(w, http.StatusNotFound, protocol.ErrInvalidParams.Withf("no agent named %s", r.PathValue("name")))
+		return
+	}
+	if !g.admit(w, r) {
+		return
+	}
+	_, targets, _ := a.state()
+	target, ok := targets[r.PathValue("iface")]
+	if !ok {
+		writeError(w, http.StatusNotFound, protocol.ErrMethodNotFound.Withf("agent %s has no %s interface", a.config.Name, r.PathValue("iface")))
+		return
+	}
+	path := "/" + r.PathValue("rest")
+	if path == "/" && !strings.HasSuffix(r.URL.Path, "/") {
+		path = ""
+	}
+	g.forward(w, r, &route{agent: a, target: target, path: path})
+}
+
+// rpcRequest is the part of a JSON-RPC request used to route it by skill or task
+type rpcRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params struct {
+		ID      string `json:"id"`
+		TaskID  string `json:"taskId"`
+		Message *struct {
+			TaskID   string         `json:"taskId"`
+			Metadata map[string]any `json:"metadata"`
+			Parts    []rpcPart      `json:"parts"`
+		} `json:"message"`
+	} `json:"params"`
+}
+
+// rpcPart is a message part, of which only text is used for routing
+type rpcPart struct {
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+}
+
+// handleSkillRoute handles JSON-RPC calls to the gateway itself. A message goes to the
+// agent owning the skill in its skillId metadata, else to the agent with the most skill
+// tags in its text. Calls about a task go to the agent that created it.
+func (g *gateway) handleSkillRoute(w http.ResponseWriter, r *http.Request) {
+	if !g.admit(w, r) {
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize+1))
+	if err != nil || len(body) > maxRequestSize {
+		writeError(w, http.StatusRequestEntityTooLarge, protocol.ErrInvalidRequest.Withf("request exceeds %d bytes", maxRequestSize))
+		return
+	}
+	var req rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeRPCError(w, nil, protocol.ErrParse.Withf("%v", err))
+		return
+	}
+
+	var a *agent
+	switch {
+	case req.Params.Message != nil:
+		if req.Params.Message.TaskID != "" {
+			a = g.tasks.owner(req.Params.Message.TaskID)
+		}
+		if a == nil {
+			a = g.routeMessage(req.Params.Message.Metadata, req.Params.Message.Parts)
+		}
+	case req.Params.ID != "" || req.Params.TaskID != "":
+		taskID := req.Params.ID
+		if taskID == "" {
+			taskID = req.Params.TaskID
+		}
+		if a = g.tasks.owner(taskID); a == nil {
+			writeRPCError(w, req.ID, protocol.ErrTaskNotFound.Withf("task %s was not created through this gateway", taskID))
+			return
+		}
+	default:
+		writeRPCError(w, req.ID, protocol.ErrMethodNotFound.Withf("%s cannot be routed by skill; call an agent under /agents", req.Method))
+		return
+	}
+	if a == nil {
+		writeRPCError(w, req.ID, protocol.ErrInternal.Withf("no agent available"))
+		return
+	}
+	_, targets, _ := a.state()
+	target, ok := targets[jsonrpcSegment]
+	if !ok {
+		writeRPCError(w, req.ID, protocol.ErrUnsupportedOperation.Withf("agent %s has no JSON-RPC interface", a.config.Name))
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	g.forward(w, r, &route{agent: a, target: target, track: true})
+}
+
+// routeMessage picks the agent for a message by skill, then by tags, then the first
+// agent with a card
+func (g *gateway) routeMessage(metadata map[string]any, parts []rpcPart) *agent {
+	if skillID, ok := metadata["skillId"].(string); ok && skillID != "" {
+		for _, a := range g.agents {
+			if a.ownsSkill(skillID) {
+				return a
+			}
+		}
+	}
+	var text strings.Builder
+	for _, part := range parts {
+		if part.Kind == "text" {
+			text.WriteString(part.Text)
+			text.WriteString(" ")
+		}
+	}
+	var best *agent
+	bestScore := -1
+	for _, a := range g.agents {
+		if card, _, _ := a.state(); card == nil {
+			continue
+		}
+		if score := a.matchScore(text.String()); score > bestScore {
+			best, bestScore = a, score
+		}
+	}
+	return best
+}
+
+// admit authenticates the caller and applies its rate limit, answering the request
+// itself when it may not go on
+func (g *gateway) admit(w http.ResponseWriter, r *http.Request) bool {
+	client, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		client = r.RemoteAddr
+	}
+	if g.tokens != nil {
+		p := g.authenticate(r)
+		if p == nil {
+			logf("WARN", "Rejected unauthenticated %s %s from %s", r.Method, r.URL.Path, client)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="a2a-gateway"`)
+			writeError(w, http.StatusUnauthorized, protocol.ErrUnauthenticated)
+			return false
+		}
+		client = "subject:" + p.Subject
+	}
+	if g.limiter != nil {
+		if ok, wait := g.limiter.allow(client); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			writeError(w, http.StatusTooManyRequests, protocol.ErrInvalidRequest.Withf("rate limit exceeded, retry in %s", wait.Round(time.Millisecond)))
+			return false
+		}
+	}
+	return true
+}
+
+// authenticate returns the principal of the bearer token of the request, if known
+func (g *gateway) authenticate(r *http.Request) *principal {
+	for _, value := range r.Header.Values("Authorization") {
+		if token, ok := strings.CutPrefix(value, "Bearer "); ok {
+			if p, ok := g.tokens[strings.TrimSpace(token)]; ok {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+func (g *gateway) forward(w http.ResponseWriter, r *http.Request, rt *route) {
+	g.proxy.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), routeKey{}, rt)))
+}
+
+// trackTasks records which agent owns the task of a skill-routed response, so that
+// later calls about the task reach the same agent
+func (g *gateway) trackTasks(resp *http.Response) error {
+	rt := resp.Request.Context().Value(routeKey{}).(*route)
+	if !rt.track || resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "text/event-stream" {
+		resp.Body = &streamTracker{ReadCloser: resp.Body, record: func(data []byte) bool {
+			return g.recordTask(data, rt.agent)
+		}}
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	g.recordTask(body, rt.agent)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return nil
+}
+
+// recordTask records the owner of the task in a JSON-RPC response, reporting whether
+// there was one
+func (g *gateway) recordTask(data []byte, a *agent) bool {
+	var resp struct {
+		Result struct {
+			Kind   string `json:"kind"`
+			ID     string `json:"id"`
+			TaskID string `json:"taskId"`
+		} `json:"result"`
+	}
+	if json.Unmarshal(data, &resp) != nil {
+		return false
+	}
+	taskID := resp.Result.TaskID
+	if resp.Result.Kind == "task" {
+		taskID = resp.Result.ID
+	}
+	if taskID == "" {
+		return false
+	}
+	g.tasks.record(taskID, a)
+	return true
+}
+
+// streamTracker passes an event stream through, handing the data of its events to
+// record until it reports having found a task
+type streamTracker struct {
+	io.ReadCloser
+	record  func(data []byte) bool
+	pending []byte
+	done    bool
+}
+
+// Read implements io.Reader
+func (t *streamTracker) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	if t.done {
+		return n, err
+	}
+	t.pending = append(t.pending, p[:n]...)
+	for !t.done {
+		end := bytes.IndexByte(t.pending, '\n')
+		if end < 0 {
+			break
+		}
+		line := bytes.TrimRight(t.pending[:end], "\r")
+		t.pending = t.pending[end+1:]
+		if data, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+			t.done = t.record(bytes.TrimSpace(data))
+		}
+	}
+	if t.done {
+		t.pending = nil
+	}
+	return n, err
+}
+
+// taskOwners remembers the agent owning each task, forgetting the oldest beyond a limit
+type taskOwners struct {
+	limit int
+
+	mu     sync.Mutex
+	owners map[string]*agent
+	order  []string
+}
+
+func newTaskOwners(limit int) *taskOwners {
+	return &taskOwners{limit: limit, owners: make(map[string]*agent)}
+}
+
+func (t *taskOwners) record(taskID string, a *agent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.owners[taskID]; ok {
+		return
+	}
+	if len(t.order) >= t.limit {
+		delete(t.owners, t.order[0])
+		t.order = t.order[1:]
+	}
+	t.owners[taskID] = a
+	t.order = append(t.order, taskID)
+}
+
+func (t *taskOwners) owner(taskID string) *agent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.owners[taskID]
+}
+
+// writeRPCError answers a JSON-RPC request with an error
+func writeRPCError(w http.ResponseWriter, id json.RawMessage, err *protocol.Error) {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"jsonrpc": "2.0", "id": id, "error": err})
+}
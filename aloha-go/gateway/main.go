@@ -0,0 +1,122 @@
+// Command gateway is a2a-gateway, which fronts several A2A agents behind one address. It
+// terminates TLS and authentication, rate limits callers, serves the agents' cards
+// rewritten with its public URL, and forwards each call by path or by skill.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+func main() {
+	host := flag.String("host", "0.0.0.0", "Address to listen on")
+	port := flag.Int("port", 12400, "Port to listen on")
+	configPath := flag.String("config", "gateway.yaml", "Gateway configuration: agents, TLS, auth tokens and rate limits")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: a2a-gateway [--config FILE] [--host HOST] [--port PORT]\n\nOptions:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("gateway.main - ERROR - %v", err)
+	}
+	g, err := newGateway(cfg)
+	if err != nil {
+		log.Fatalf("gateway.main - ERROR - %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Fetch the agent cards, then keep them fresh
+	refreshCards(ctx, g.agents)
+	go func() {
+		ticker := time.NewTicker(cfg.CardRefresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				refreshCards(ctx, g.agents)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	server := &http.Server{Addr: fmt.Sprintf("%s:%d", *host, *port), Handler: g.handler()}
+	go func() {
+		<-ctx.Done()
+		logf("INFO", "Shutdown signal received, stopping gateway...")
+		server.Shutdown(context.Background())
+	}()
+
+	if g.tokens != nil {
+		logf("INFO", "Authentication enabled with %d tokens", len(g.tokens))
+	}
+	if g.limiter != nil {
+		logf("INFO", "Rate limit: %g requests/s per client, burst %g", g.limiter.rate, g.limiter.burst)
+	}
+	if cfg.TLS.CertFile != "" {
+		logf("INFO", "Gateway listening on https://%s:%d", *host, *port)
+		err = server.ListenAndServeTLS(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+	} else {
+		logf("INFO", "Gateway listening on http://%s:%d", *host, *port)
+		err = server.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("gateway.main - ERROR - Server error: %v", err)
+	}
+	logf("INFO", "Gateway stopped")
+}
+
+// refreshCards fetches the cards of all agents concurrently, logging changes in their
+// availability
+func refreshCards(ctx context.Context, agents []*agent) {
+	var wg sync.WaitGroup
+	for _, a := range agents {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			before, _, beforeErr := a.state()
+			err := a.refresh(ctx)
+			switch {
+			case err != nil && (beforeErr == nil || beforeErr.Error() != err.Error()):
+				logf("WARN", "Agent %s (%s): %v", a.config.Name, a.config.URL, err)
+			case err == nil && (before == nil || beforeErr != nil):
+				card, targets, _ := a.state()
+				logf("INFO", "Agent %s (%s): %s with %d skills over %d interfaces", a.config.Name, a.config.URL, card.Name, len(card.Skills), len(targets))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a protocol error as {"error": {...}}, with the A2A error code
+func writeError(w http.ResponseWriter, status int, err *protocol.Error) {
+	writeJSON(w, status, map[string]*protocol.Error{"error": err})
+}
+
+// logf logs in the agents' "name - LEVEL - message" format
+func logf(level, format string, args ...any) {
+	log.Printf("gateway.main - %s - %s", level, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,101 @@
+// Package cli holds what the subcommands of the aloha binary share: the config file,
+// and flags that default to environment variables and config file values. A flag given
+// on the command line wins over its environment variable, which wins over the config file.
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// envAnnotation is the flag annotation naming the environment variable a flag defaults to
+const envAnnotation = "aloha.env"
+
+// ConfigFile is the config file given with --config; empty uses ConfigPath's default
+var ConfigFile string
+
+// AddConfigFlag adds the --config flag selecting the config file to fs
+func AddConfigFlag(fs *pflag.FlagSet) {
+	fs.StringVar(&ConfigFile, "config", "", "Config file (env ALOHA_CONFIG, default ~/.aloha/config.yaml)")
+}
+
+// ConfigPath returns the config file path: --config, ALOHA_CONFIG or ~/.aloha/config.yaml
+func ConfigPath() (string, error) {
+	if ConfigFile != "" {
+		return ConfigFile, nil
+	}
+	if path := os.Getenv("ALOHA_CONFIG"); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".aloha", "config.yaml"), nil
+}
+
+// ReadConfig decodes the config file into v and returns its path. A missing file is
+// not an error: found is false and v is left untouched.
+func ReadConfig(v any) (path string, found bool, err error) {
+	path, err = ConfigPath()
+	if err != nil {
+		return "", false, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, false, nil
+	}
+	if err != nil {
+		return path, false, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, v); err != nil {
+		return path, false, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return path, true, nil
+}
+
+// BindEnv makes the named flag default to an environment variable, named in its usage
+func BindEnv(fs *pflag.FlagSet, name, env string) {
+	if err := fs.SetAnnotation(name, envAnnotation, []string{env}); err != nil {
+		panic(err)
+	}
+	f := fs.Lookup(name)
+	f.Usage += " [env " + env + "]"
+}
+
+// ApplyEnv sets the flags not given on the command line from their environment variables
+func ApplyEnv(fs *pflag.FlagSet) error {
+	var err error
+	fs.VisitAll(func(f *pflag.Flag) {
+		env := f.Annotations[envAnnotation]
+		if err != nil || f.Changed || len(env) == 0 {
+			return
+		}
+		if value := os.Getenv(env[0]); value != "" {
+			if setErr := fs.Set(f.Name, value); setErr != nil {
+				err = fmt.Errorf("invalid %s: %w", env[0], setErr)
+			}
+		}
+	})
+	return err
+}
+
+// ApplyDefaults sets the flags not given on the command line nor by the environment
+// from values, keyed by flag name. Empty values and unknown flags are ignored.
+func ApplyDefaults(fs *pflag.FlagSet, values map[string]string, source string) error {
+	for name, value := range values {
+		f := fs.Lookup(name)
+		if value == "" || f == nil || f.Changed {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("invalid %s value for %s: %w", source, name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+// Package conformance runs the same scenarios against each transport of an A2A agent
+// (gRPC, JSON-RPC and REST) and reports which pass on which transport, so that the
+// transport adapters cannot drift apart unnoticed.
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Agent is the agent under test, reached over one transport
+type Agent interface {
+	// Transport is the agent card protocol the agent is reached with
+	Transport() a2a.TransportProtocol
+	// Card returns the agent card as seen by a client of this transport
+	Card(ctx context.Context) (*a2a.AgentCard, error)
+	SendMessage(ctx context.Context, params *a2a.MessageSendParams) (a2a.SendMessageResult, error)
+	SendStreamingMessage(ctx context.Context, params *a2a.MessageSendParams) iter.Seq2[a2a.Event, error]
+	GetTask(ctx context.Context, taskID a2a.TaskID) (*a2a.Task, error)
+	CancelTask(ctx context.Context, taskID a2a.TaskID) (*a2a.Task, error)
+}
+
+// Scenario is one check run against every transport. Run returns nil when the agent behaves as expected.
+type Scenario struct {
+	Name        string
+	Description string
+	Run         func(ctx context.Context, agent Agent) error
+}
+
+// Result is the outcome of one scenario on one transport
+type Result struct {
+	Scenario  string                `json:"scenario"`
+	Transport a2a.TransportProtocol `json:"transport"`
+	Passed    bool                  `json:"passed"`
+	Error     string                `json:"error,omitempty"`
+	Duration  time.Duration         `json:"durationNs"`
+}
+
+// Report is the scenario matrix: every scenario run on every transport
+type Report struct {
+	Transports []a2a.TransportProtocol `json:"transports"`
+	Scenarios  []string                `json:"scenarios"`
+	Results    []Result                `json:"results"`
+}
+
+// Run runs every scenario against every agent, each with its own time limit, and reports the outcomes.
+// A timeout of 0 only bounds the scenarios by ctx.
+func Run(ctx context.Context, agents []Agent, scenarios []Scenario, timeout time.Duration) *Report {
+	report := &Report{}
+	for _, agent := range agents {
+		report.Transports = append(report.Transports, agent.Transport())
+	}
+	for _, scenario := range scenarios {
+		report.Scenarios = append(report.Scenarios, scenario.Name)
+		for _, agent := range agents {
+			report.Results = append(report.Results, runScenario(ctx, agent, scenario, timeout))
+		}
+	}
+	return report
+}
+
+// runScenario runs one scenario against one agent, turning a panic into a failure
+func runScenario(ctx context.Context, agent Agent, scenario Scenario, timeout time.Duration) (result Result) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result = Result{Scenario: scenario.Name, Transport: agent.Transport()}
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			result.Passed, result.Error = false, fmt.Sprintf("panic: %v", r)
+		}
+		result.Duration = time.Since(start)
+	}()
+
+	if err := scenario.Run(ctx, agent); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Passed = true
+	return result
+}
+
+// Result returns the outcome of a scenario on a transport
+func (r *Report) Result(scenario string, transport a2a.TransportProtocol) (Result, bool) {
+	for _, result := range r.Results {
+		if result.Scenario == scenario && result.Transport == transport {
+			return result, true
+		}
+	}
+	return Result{}, false
+}
+
+// Failed returns the number of failed scenario runs
+func (r *Report) Failed() int {
+	failed := 0
+	for _, result := range r.Results {
+		if !result.Passed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Divergent returns the scenarios that pass on some transports but fail on others
+func (r *Report) Divergent() []string {
+	var divergent []string
+	for _, scenario := range r.Scenarios {
+		passed, failed := false, false
+		for _, transport := range r.Transports {
+			if result, ok := r.Result(scenario, transport); ok {
+				passed = passed || result.Passed
+				failed = failed || !result.Passed
+			}
+		}
+		if passed && failed {
+			divergent = append(divergent, scenario)
+		}
+	}
+	return divergent
+}
+
+// Write renders the report as a pass/fail matrix, one row per scenario and one
+// column per transport, followed by the reason of each failure
+func (r *Report) Write(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{"SCENARIO"}
+	for _, transport := range r.Transports {
+		header = append(header, string(transport))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, scenario := range r.Scenarios {
+		row := []string{scenario}
+		for _, transport := range r.Transports {
+			result, _ := r.Result(scenario, transport)
+			if result.Passed {
+				row = append(row, "PASS")
+			} else {
+				row = append(row, "FAIL")
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+
+	failed := r.Failed()
+	fmt.Fprintf(w, "\n%d of %d checks passed\n", len(r.Results)-failed, len(r.Results))
+	if divergent := r.Divergent(); len(divergent) > 0 {
+		fmt.Fprintf(w, "Transports diverge on: %s\n", strings.Join(divergent, ", "))
+	}
+	if failed == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nFailures:")
+	for _, result := range r.Results {
+		if !result.Passed {
+			fmt.Fprintf(w, "  %s [%s]: %s\n", result.Scenario, result.Transport, result.Error)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// DefaultMessage is the message the scenarios send unless Scenarios is given another one.
+// Any message the agent completes with a text answer will do.
+const DefaultMessage = "Roll a 6-sided dice"
+
+// unknownTaskID names a task no agent has
+const unknownTaskID = a2a.TaskID("conformance-unknown-task")
+
+// Scenarios returns the scenario matrix run against every transport: sending, streaming,
+// getting and canceling tasks, the errors reported for bad requests, and the agent card.
+// message is the text sent by the scenarios that need an answer; empty uses DefaultMessage.
+func Scenarios(message string) []Scenario {
+	if message == "" {
+		message = DefaultMessage
+	}
+	return []Scenario{
+		{
+			Name:        "agent-card",
+			Description: "The agent card names the agent, lists its skills and declares the transport",
+			Run:         checkAgentCard,
+		},
+		{
+			Name:        "send",
+			Description: "A blocking send returns a completed task with a text answer",
+			Run: func(ctx context.Context, agent Agent) error {
+				_, err := sendCompleted(ctx, agent, message)
+				return err
+			},
+		},
+		{
+			Name:        "stream",
+			Description: "A streaming send yields events of one task, ending with a final completed status",
+			Run: func(ctx context.Context, agent Agent) error {
+				return checkStream(ctx, agent, message)
+			},
+		},
+		{
+			Name:        "get",
+			Description: "Getting a task returns the same task as the send, with its history",
+			Run: func(ctx context.Context, agent Agent) error {
+				return checkGet(ctx, agent, message)
+			},
+		},
+		{
+			Name:        "cancel-completed",
+			Description: "Canceling a completed task fails with task not cancelable",
+			Run: func(ctx context.Context, agent Agent) error {
+				task, err := sendCompleted(ctx, agent, message)
+				if err != nil {
+					return err
+				}
+				_, err = agent.CancelTask(ctx, task.ID)
+				return expectError(err, a2a.ErrTaskNotCancelable)
+			},
+		},
+		{
+			Name:        "get-unknown-task",
+			Description: "Getting a task that does not exist fails with task not found",
+			Run: func(ctx context.Context, agent Agent) error {
+				_, err := agent.GetTask(ctx, unknownTaskID)
+				return expectError(err, a2a.ErrTaskNotFound)
+			},
+		},
+		{
+			Name:        "cancel-unknown-task",
+			Description: "Canceling a task that does not exist fails with task not found",
+			Run: func(ctx context.Context, agent Agent) error {
+				_, err := agent.CancelTask(ctx, unknownTaskID)
+				return expectError(err, a2a.ErrTaskNotFound)
+			},
+		},
+		{
+			Name:        "send-empty-message",
+			Description: "Sending a message without parts fails with invalid params",
+			Run: func(ctx context.Context, agent Agent) error {
+				_, err := agent.SendMessage(ctx, &a2a.MessageSendParams{Message: protocol.NewUserMessage().SDK()})
+				return expectError(err, a2a.ErrInvalidParams)
+			},
+		},
+	}
+}
+
+// checkAgentCard checks the card's required fields and that it declares the agent's transport
+func checkAgentCard(ctx context.Context, agent Agent) error {
+	card, err := agent.Card(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the agent card: %w", err)
+	}
+	switch {
+	case card.Name == "":
+		return fmt.Errorf("card has no name")
+	case card.Version == "":
+		return fmt.Errorf("card has no version")
+	case card.ProtocolVersion == "":
+		return fmt.Errorf("card has no protocol version")
+	case len(card.Skills) == 0:
+		return fmt.Errorf("card lists no skills")
+	}
+
+	declared := card.PreferredTransport == agent.Transport() ||
+		(card.PreferredTransport == "" && agent.Transport() == a2a.TransportProtocolJSONRPC)
+	for _, iface := range card.AdditionalInterfaces {
+		declared = declared || iface.Transport == agent.Transport()
+	}
+	if !declared {
+		return fmt.Errorf("card does not declare a %s interface", agent.Transport())
+	}
+	return nil
+}
+
+// sendCompleted sends a blocking message and checks that it returns a completed task with a text answer
+func sendCompleted(ctx context.Context, agent Agent, message string) (*a2a.Task, error) {
+	blocking := true
+	result, err := agent.SendMessage(ctx, &a2a.MessageSendParams{
+		Message: protocol.NewUserText("", message).SDK(),
+		Config:  &a2a.MessageSendConfig{Blocking: &blocking},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("send failed: %w", err)
+	}
+	task, ok := result.(*a2a.Task)
+	if !ok {
+		return nil, fmt.Errorf("send returned a %T, want a task", result)
+	}
+	if err := checkTask(task); err != nil {
+		return nil, err
+	}
+	if task.Status.State != a2a.TaskStateCompleted {
+		return nil, fmt.Errorf("task %s is %s, want completed", task.ID, task.Status.State)
+	}
+	if !hasText(task.Artifacts) {
+		return nil, fmt.Errorf("task %s has no text artifact", task.ID)
+	}
+	return task, nil
+}
+
+// checkStream sends a streaming message and checks the events it yields
+func checkStream(ctx context.Context, agent Agent, message string) error {
+	params := &a2a.MessageSendParams{Message: protocol.NewUserText("", message).SDK()}
+
+	var taskID a2a.TaskID
+	var final *a2a.TaskStatusUpdateEvent
+	var artifacts []*a2a.Artifact
+	events := 0
+	for event, err := range agent.SendStreamingMessage(ctx, params) {
+		if err != nil {
+			return fmt.Errorf("stream failed after %d events: %w", events, err)
+		}
+		events++
+		if final != nil {
+			return fmt.Errorf("event %d (%T) follows the final status", events, event)
+		}
+
+		info := event.TaskInfo()
+		if taskID == "" {
+			taskID = info.TaskID
+		} else if info.TaskID != taskID {
+			return fmt.Errorf("event %d belongs to task %s, want %s", events, info.TaskID, taskID)
+		}
+
+		switch event := event.(type) {
+		case *a2a.TaskStatusUpdateEvent:
+			if event.Final {
+				final = event
+			}
+		case *a2a.TaskArtifactUpdateEvent:
+			artifacts = append(artifacts, event.Artifact)
+		case *a2a.Task:
+			artifacts = append(artifacts, event.Artifacts...)
+		}
+	}
+
+	switch {
+	case events == 0:
+		return fmt.Errorf("stream yielded no events")
+	case taskID == "":
+		return fmt.Errorf("stream events carry no task ID")
+	case final == nil:
+		return fmt.Errorf("stream ended without a final status")
+	case final.Status.State != a2a.TaskStateCompleted:
+		return fmt.Errorf("final status is %s, want completed", final.Status.State)
+	case !hasText(artifacts):
+		return fmt.Errorf("stream yielded no text artifact")
+	}
+	return nil
+}
+
+// checkGet sends a message and checks that getting its task returns the same task
+func checkGet(ctx context.Context, agent Agent, message string) error {
+	sent, err := sendCompleted(ctx, agent, message)
+	if err != nil {
+		return err
+	}
+	task, err := agent.GetTask(ctx, sent.ID)
+	if err != nil {
+		return fmt.Errorf("get failed: %w", err)
+	}
+	if err := checkTask(task); err != nil {
+		return err
+	}
+
+	switch {
+	case task.ID != sent.ID:
+		return fmt.Errorf("got task %s, want %s", task.ID, sent.ID)
+	case task.ContextID != sent.ContextID:
+		return fmt.Errorf("task context is %s, want %s", task.ContextID, sent.ContextID)
+	case task.Status.State != sent.Status.State:
+		return fmt.Errorf("task is %s, but the send returned it %s", task.Status.State, sent.Status.State)
+	case len(task.Artifacts) != len(sent.Artifacts):
+		return fmt.Errorf("task has %d artifacts, but the send returned %d", len(task.Artifacts), len(sent.Artifacts))
+	case len(task.History) == 0:
+		return fmt.Errorf("task has no history")
+	}
+	return nil
+}
+
+// checkTask checks the fields every task must have
+func checkTask(task *a2a.Task) error {
+	switch {
+	case task == nil:
+		return fmt.Errorf("no task returned")
+	case task.ID == "":
+		return fmt.Errorf("task has no ID")
+	case task.ContextID == "":
+		return fmt.Errorf("task %s has no context ID", task.ID)
+	case task.Status.State == "":
+		return fmt.Errorf("task %s has no state", task.ID)
+	}
+	return nil
+}
+
+// expectError checks that a call failed with the given kind of error
+func expectError(err, want error) error {
+	if err == nil {
+		return fmt.Errorf("call succeeded, want error %q", want)
+	}
+	if !errors.Is(err, want) {
+		return fmt.Errorf("got error %q, want %q", err, want)
+	}
+	return nil
+}
+
+// hasText reports whether any artifact has a non-empty text part
+func hasText(artifacts []*a2a.Artifact) bool {
+	for _, artifact := range artifacts {
+		if artifact == nil {
+			continue
+		}
+		for _, part := range artifact.Parts {
+			if text, ok := part.(a2a.TextPart); ok && text.Text != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+// Package flags gates experimental behaviors behind feature flags, so that partially
+// complete features can ship dark and be turned on per deployment. A flag starts at its
+// default, which a flags file can override, which an environment variable can override in
+// turn. Flags are loaded at startup and read-only afterwards.
+package flags
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix starts the environment variables setting flags, e.g. ALOHA_FEATURE_STREAMING_TOKENS
+const EnvPrefix = "ALOHA_FEATURE_"
+
+// Sources of a flag's value
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+)
+
+// Flag is a feature that can be turned on or off
+type Flag struct {
+	// Name identifies the flag in files and, upper-cased, in environment variables,
+	// e.g. "streaming-tokens"
+	Name        string
+	Description string
+	Default     bool
+}
+
+// State is the value of a flag and where it came from
+type State struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Source      string `json:"source"`
+}
+
+// Set holds the values of known flags
+type Set struct {
+	flags  []Flag
+	states map[string]State
+}
+
+// NewSet returns a set of the given flags, each at its default
+func NewSet(flags ...Flag) *Set {
+	s := &Set{flags: flags, states: make(map[string]State, len(flags))}
+	for _, flag := range flags {
+		s.states[flag.Name] = State{Name: flag.Name, Description: flag.Description, Enabled: flag.Default, Source: SourceDefault}
+	}
+	return s
+}
+
+// Enabled reports whether a flag is on. Unknown flags are off.
+func (s *Set) Enabled(name string) bool {
+	return s.states[name].Enabled
+}
+
+// Set turns a flag on or off, recording where the value came from
+func (s *Set) Set(name string, enabled bool, source string) error {
+	state, ok := s.states[name]
+	if !ok {
+		return fmt.Errorf("unknown feature flag %q (known: %s)", name, strings.Join(s.names(), ", "))
+	}
+	state.Enabled, state.Source = enabled, source
+	s.states[name] = state
+	return nil
+}
+
+// LoadFile applies a YAML or JSON file mapping flag names to booleans:
+//
+//	streaming-tokens: true
+//	push-notifications: false
+func (s *Set) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read feature flags file: %w", err)
+	}
+	var values map[string]bool
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse feature flags file %s: %w", path, err)
+	}
+	for name, enabled := range values {
+		if err := s.Set(name, enabled, SourceFile); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// LoadEnv applies the environment variables named after the flags with EnvPrefix, whose
+// values are booleans as strconv.ParseBool reads them. lookup is usually os.LookupEnv.
+func (s *Set) LoadEnv(lookup func(string) (string, bool)) error {
+	for _, flag := range s.flags {
+		name := EnvName(flag.Name)
+		value, ok := lookup(name)
+		if !ok || value == "" {
+			continue
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s=%q: expected true or false", name, value)
+		}
+		s.Set(flag.Name, enabled, SourceEnv)
+	}
+	return nil
+}
+
+// States returns the flags in the order they were declared
+func (s *Set) States() []State {
+	states := make([]State, len(s.flags))
+	for i, flag := range s.flags {
+		states[i] = s.states[flag.Name]
+	}
+	return states
+}
+
+// EnvName returns the environment variable setting a flag
+func EnvName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+func (s *Set) names() []string {
+	names := make([]string, len(s.flags))
+	for i, flag := range s.flags {
+		names[i] = flag.Name
+	}
+	slices.Sort(names)
+	return names
+}
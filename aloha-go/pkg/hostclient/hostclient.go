@@ -0,0 +1,207 @@
+// Package hostclient is the client an A2A host uses to talk to one agent over gRPC,
+// JSON-RPC or HTTP+JSON (REST). gRPC and JSON-RPC go through the a2a-go SDK client;
+// REST goes through RESTClient, as the SDK has no REST transport.
+//
+//	client, err := hostclient.Initialize(ctx, "http://localhost:12001", hostclient.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	defer client.Close()
+//	result, err := client.SendMessage(ctx, &a2a.MessageSendParams{Message: msg})
+package hostclient
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/a2aproject/a2a-go/a2aclient/agentcard"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// restTimeout bounds each REST request, including reading a streamed response
+const restTimeout = 120 * time.Second
+
+// Options configures how a Client reaches its agent
+type Options struct {
+	// Transport pins the transport protocol; empty uses the card's preferred transport
+	Transport a2a.TransportProtocol
+	// Endpoint overrides the URL the card declares for the REST transport
+	Endpoint string
+	// HTTPClient carries agent card, JSON-RPC and REST requests; nil uses http.DefaultClient
+	HTTPClient *http.Client
+	// GRPCDialOptions configure gRPC connections; nil dials without TLS
+	GRPCDialOptions []grpc.DialOption
+}
+
+// Client talks to one agent over a single transport, hiding the SDK/REST client split
+type Client struct {
+	card      *a2a.AgentCard
+	transport a2a.TransportProtocol
+	sdk       *a2aclient.Client
+	rest      *RESTClient
+}
+
+// Initialize resolves the agent card served at cardURL and connects to the agent
+func Initialize(ctx context.Context, cardURL string, opts Options) (*Client, error) {
+	card, err := agentcard.NewResolver(opts.httpClient()).Resolve(ctx, cardURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve agent card from %s: %w", cardURL, err)
+	}
+	return New(ctx, card, opts)
+}
+
+// New connects to an agent whose card is already resolved
+func New(ctx context.Context, card *a2a.AgentCard, opts Options) (*Client, error) {
+	protocol := opts.Transport
+	if protocol == "" {
+		protocol = preferredTransport(card)
+	}
+
+	client := &Client{card: card, transport: protocol}
+	if protocol == a2a.TransportProtocolHTTPJSON {
+		endpoint := opts.Endpoint
+		if endpoint == "" {
+			var ok bool
+			if endpoint, ok = Endpoint(card, protocol); !ok {
+				return nil, fmt.Errorf("agent %s does not declare a %s interface", card.Name, protocol)
+			}
+		}
+		httpClient := &http.Client{Transport: opts.httpClient().Transport, Timeout: restTimeout}
+		client.rest = NewRESTClient(httpClient, endpoint)
+		return client, nil
+	}
+
+	// Pin the transport; otherwise the factory follows the card's preferred transport
+	factoryOpts := []a2aclient.FactoryOption{
+		a2aclient.WithConfig(a2aclient.Config{PreferredTransports: []a2a.TransportProtocol{protocol}}),
+	}
+	switch protocol {
+	case a2a.TransportProtocolGRPC:
+		dialOpts := opts.GRPCDialOptions
+		if dialOpts == nil {
+			dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+		}
+		factoryOpts = append(factoryOpts, a2aclient.WithGRPCTransport(dialOpts...))
+	case a2a.TransportProtocolJSONRPC:
+		factoryOpts = append(factoryOpts, a2aclient.WithJSONRPCTransport(opts.httpClient()))
+	default:
+		return nil, fmt.Errorf("unsupported transport: %s", protocol)
+	}
+	sdk, err := a2aclient.NewFromCard(ctx, card, factoryOpts...)
+	if err != nil {
+		return nil, err
+	}
+	client.sdk = sdk
+	return client, nil
+}
+
+// httpClient returns the HTTP client to use, defaulting to http.DefaultClient
+func (o Options) httpClient() *http.Client {
+	if o.HTTPClient != nil {
+		return o.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// Endpoint returns the URL the agent card declares for a transport protocol
+func Endpoint(card *a2a.AgentCard, protocol a2a.TransportProtocol) (string, bool) {
+	if preferredTransport(card) == protocol {
+		return card.URL, true
+	}
+	for _, iface := range card.AdditionalInterfaces {
+		if iface.Transport == protocol {
+			return iface.URL, true
+		}
+	}
+	return "", false
+}
+
+// preferredTransport returns the card's preferred transport, JSON-RPC when it names none
+func preferredTransport(card *a2a.AgentCard) a2a.TransportProtocol {
+	if card.PreferredTransport == "" {
+		return a2a.TransportProtocolJSONRPC
+	}
+	return card.PreferredTransport
+}
+
+// Card returns the agent card the client was created from
+func (c *Client) Card() *a2a.AgentCard {
+	return c.card
+}
+
+// Transport returns the transport protocol the client uses
+func (c *Client) Transport() a2a.TransportProtocol {
+	return c.transport
+}
+
+// SendMessage sends a non-streaming message
+func (c *Client) SendMessage(ctx context.Context, params *a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	if c.rest != nil {
+		return c.rest.SendMessage(ctx, params)
+	}
+	return c.sdk.SendMessage(ctx, params)
+}
+
+// Stream sends a streaming message and yields its events as they arrive
+func (c *Client) Stream(ctx context.Context, params *a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	if c.rest != nil {
+		return c.rest.SendStreamingMessage(ctx, params)
+	}
+	return c.sdk.SendStreamingMessage(ctx, params)
+}
+
+// Resubscribe reattaches to a running task and yields its remaining events
+func (c *Client) Resubscribe(ctx context.Context, taskID a2a.TaskID) iter.Seq2[a2a.Event, error] {
+	if c.rest != nil {
+		return c.rest.ResubscribeToTask(ctx, string(taskID))
+	}
+	return c.sdk.ResubscribeToTask(ctx, &a2a.TaskIDParams{ID: taskID})
+}
+
+// GetTask fetches a task. historyLength limits the returned history to the most recent
+// messages; nil returns all.
+func (c *Client) GetTask(ctx context.Context, taskID a2a.TaskID, historyLength *int) (*a2a.Task, error) {
+	if c.rest != nil {
+		return c.rest.GetTask(ctx, string(taskID), historyLength)
+	}
+	return c.sdk.GetTask(ctx, &a2a.TaskQueryParams{ID: taskID, HistoryLength: historyLength})
+}
+
+// CancelTask requests cancellation of a task
+func (c *Client) CancelTask(ctx context.Context, taskID a2a.TaskID) (*a2a.Task, error) {
+	if c.rest != nil {
+		return c.rest.CancelTask(ctx, string(taskID))
+	}
+	return c.sdk.CancelTask(ctx, &a2a.TaskIDParams{ID: taskID})
+}
+
+// ListTasks lists one page of the tasks matching the request
+func (c *Client) ListTasks(ctx context.Context, req *a2a.ListTasksRequest) (*a2a.ListTasksResponse, error) {
+	if c.rest != nil {
+		return c.rest.ListTasks(ctx, req)
+	}
+	return c.sdk.ListTasks(ctx, req)
+}
+
+// GetExtendedCard fetches the authenticated extended agent card
+func (c *Client) GetExtendedCard(ctx context.Context) (*a2a.AgentCard, error) {
+	if c.rest != nil {
+		return c.rest.GetExtendedAgentCard(ctx)
+	}
+	// The SDK client calls agent/getAuthenticatedExtendedCard when its card supports it
+	return c.sdk.GetAgentCard(ctx)
+}
+
+// Close releases the underlying transport
+func (c *Client) Close() error {
+	if c.sdk != nil {
+		return c.sdk.Destroy()
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package hostclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestEndpoint(t *testing.T) {
+	card := &a2a.AgentCard{
+		URL: "http://localhost:12001",
+		AdditionalInterfaces: []a2a.AgentInterface{
+			{Transport: a2a.TransportProtocolGRPC, URL: "localhost:12000"},
+			{Transport: a2a.TransportProtocolHTTPJSON, URL: "http://localhost:12002"},
+		},
+	}
+	tests := []struct {
+		protocol a2a.TransportProtocol
+		want     string
+		wantOK   bool
+	}{
+		{a2a.TransportProtocolJSONRPC, "http://localhost:12001", true},
+		{a2a.TransportProtocolGRPC, "localhost:12000", true},
+		{a2a.TransportProtocolHTTPJSON, "http://localhost:12002", true},
+		{"CUSTOM", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.protocol), func(t *testing.T) {
+			got, ok := Endpoint(card, tt.protocol)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Endpoint = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestInitializeREST resolves a card declaring only a REST interface and sends through it
+func TestInitializeREST(t *testing.T) {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	card := a2a.AgentCard{Name: "echo", URL: srv.URL, PreferredTransport: a2a.TransportProtocolHTTPJSON}
+	task := a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}}
+	mux.HandleFunc("GET /.well-known/agent-card.json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(card)
+	})
+	mux.HandleFunc("POST /v1/message:send", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(task)
+	})
+	mux.HandleFunc("GET /v1/tasks/task-1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(task)
+	})
+
+	ctx := context.Background()
+	client, err := Initialize(ctx, srv.URL, Options{})
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	defer client.Close()
+	if client.Transport() != a2a.TransportProtocolHTTPJSON || client.Card().Name != "echo" {
+		t.Fatalf("connected to %s over %s, want echo over %s", client.Card().Name, client.Transport(), a2a.TransportProtocolHTTPJSON)
+	}
+
+	msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hello"})
+	result, err := client.SendMessage(ctx, &a2a.MessageSendParams{Message: msg})
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	if info := result.TaskInfo(); info.TaskID != task.ID {
+		t.Errorf("SendMessage task = %q, want %q", info.TaskID, task.ID)
+	}
+	got, err := client.GetTask(ctx, task.ID, nil)
+	if err != nil {
+		t.Fatalf("GetTask: %v", err)
+	}
+	if got.Status.State != a2a.TaskStateCompleted {
+		t.Errorf("GetTask state = %s, want %s", got.Status.State, a2a.TaskStateCompleted)
+	}
+	if _, err := client.GetTask(ctx, "task-2", nil); err == nil {
+		t.Error("GetTask of an unknown task succeeded, want an error")
+	}
+}
@@ -0,0 +1,441 @@
+package hostclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/protocol"
+	"github.com/aloha/a2a-go/pkg/sse"
+)
+
+// RESTClient is the HTTP+JSON (REST) transport, which the a2a-go SDK does not provide
+type RESTClient struct {
+	serverURL  string
+	httpClient *http.Client
+}
+
+// NewRESTClient creates a REST client for the agent served at serverURL
+func NewRESTClient(httpClient *http.Client, serverURL string) *RESTClient {
+	return &RESTClient{serverURL: serverURL, httpClient: httpClient}
+}
+
+// GetExtendedAgentCard fetches the authenticated extended agent card (GET /v1/card)
+func (c *RESTClient) GetExtendedAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
+	url := c.serverURL + "/v1/card"
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, restStatusError(resp)
+	}
+
+	var card a2a.AgentCard
+	if err := json.NewDecoder(resp.Body).Decode(&card); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &card, nil
+}
+
+// SendMessage sends a non-streaming message
+func (c *RESTClient) SendMessage(ctx context.Context, params *a2a.MessageSendParams) (*a2a.Task, error) {
+	// Build REST request - extract message from params
+	type MessageSendRequest struct {
+		Message       *a2a.Message           `json:"message"`
+		Configuration *a2a.MessageSendConfig `json:"configuration,omitempty"`
+	}
+
+	reqBody := MessageSendRequest{
+		Message:       params.Message,
+		Configuration: params.Config,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := c.serverURL + "/v1/message:send"
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(string(jsonBody)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, restStatusError(resp)
+	}
+
+	var task a2a.Task
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &task, nil
+}
+
+// SendStreamingMessage sends a streaming message and yields every A2A event
+// (task snapshots, messages, status and artifact updates) as it arrives
+func (c *RESTClient) SendStreamingMessage(ctx context.Context, params *a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		type MessageSendRequest struct {
+			Message       *a2a.Message           `json:"message"`
+			Configuration *a2a.MessageSendConfig `json:"configuration,omitempty"`
+		}
+
+		reqBody := MessageSendRequest{
+			Message:       params.Message,
+			Configuration: params.Config,
+		}
+
+		jsonBody, _ := json.Marshal(reqBody)
+		url := c.serverURL + "/v1/message:stream"
+
+		req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(string(jsonBody)))
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to create request: %w", err))
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		c.streamEvents(req, yield)
+	}
+}
+
+// ResubscribeToTask reattaches to the event stream of a running task and yields its remaining events
+func (c *RESTClient) ResubscribeToTask(ctx context.Context, taskID string) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		url := fmt.Sprintf("%s/v1/tasks/%s:subscribe", c.serverURL, taskID)
+
+		req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to create request: %w", err))
+			return
+		}
+
+		c.streamEvents(req, yield)
+	}
+}
+
+// streamEvents performs a request answered with server-sent events and yields each decoded
+// A2A event, until the final one even if the agent keeps the stream open
+func (c *RESTClient) streamEvents(req *http.Request, yield func(a2a.Event, error) bool) {
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		yield(nil, fmt.Errorf("request failed: %w", err))
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		yield(nil, restStatusError(resp))
+		return
+	}
+
+	decoder := sse.NewDecoder(resp.Body)
+	for {
+		sseEvent, err := decoder.Next()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to read event stream: %w", err))
+			return
+		}
+
+		if sseEvent.Data == "[DONE]" {
+			return
+		}
+		if sseEvent.Type == "error" {
+			yield(nil, fmt.Errorf("stream error: %s", sseEvent.Data))
+			return
+		}
+
+		event, err := decodeRESTEvent([]byte(sseEvent.Data))
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		if event == nil {
+			continue
+		}
+		if !yield(event, nil) || finalEvent(event) {
+			return
+		}
+	}
+}
+
+// finalEvent reports whether the event ends its stream: a status update marked final,
+// or a message, which agents answer with instead of a task
+func finalEvent(event a2a.Event) bool {
+	switch event := event.(type) {
+	case *a2a.TaskStatusUpdateEvent:
+		return event.Final
+	case *a2a.Message:
+		return true
+	}
+	return false
+}
+
+// restStreamResponse is the HTTP+JSON StreamResponse form of a streamed event, which
+// wraps the event in the field named after its kind
+type restStreamResponse struct {
+	Error          json.RawMessage                   `json:"error"`
+	Task           *protocol.Task                    `json:"task"`
+	Message        *protocol.Message                 `json:"message"`
+	StatusUpdate   *protocol.TaskStatusUpdateEvent   `json:"statusUpdate"`
+	ArtifactUpdate *protocol.TaskArtifactUpdateEvent `json:"artifactUpdate"`
+}
+
+// decodeRESTEvent decodes one streamed event. It accepts events discriminated by "kind"
+// as well as the StreamResponse form. Unknown payloads are skipped (nil event).
+func decodeRESTEvent(data []byte) (a2a.Event, error) {
+	var resp restStreamResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode stream event: %w", err)
+	}
+
+	var event protocol.Event
+	switch {
+	case resp.Error != nil:
+		return nil, fmt.Errorf("stream error: %s", restErrorMessage(resp.Error))
+	case resp.Task != nil:
+		resp.Task.Kind = protocol.KindTask
+		event = resp.Task
+	case resp.Message != nil:
+		resp.Message.Kind = protocol.KindMessage
+		event = resp.Message
+	case resp.StatusUpdate != nil:
+		resp.StatusUpdate.Kind = protocol.KindStatusUpdate
+		event = resp.StatusUpdate
+	case resp.ArtifactUpdate != nil:
+		resp.ArtifactUpdate.Kind = protocol.KindArtifactUpdate
+		event = resp.ArtifactUpdate
+	default:
+		var err error
+		event, err = protocol.UnmarshalEvent(data)
+		if errors.Is(err, protocol.ErrUnknownEventKind) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sdkEvent(event)
+}
+
+// sdkEvent converts a pkg/protocol event to the SDK event of the same kind. Both encode
+// to the same JSON, so the event goes through its encoding.
+func sdkEvent(event protocol.Event) (a2a.Event, error) {
+	var converted a2a.Event
+	switch event.(type) {
+	case *protocol.Task:
+		converted = &a2a.Task{}
+	case *protocol.Message:
+		converted = &a2a.Message{}
+	case *protocol.TaskStatusUpdateEvent:
+		converted = &a2a.TaskStatusUpdateEvent{}
+	case *protocol.TaskArtifactUpdateEvent:
+		converted = &a2a.TaskArtifactUpdateEvent{}
+	default:
+		return nil, fmt.Errorf("unsupported event %T", event)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.EventKind(), err)
+	}
+	if err := json.Unmarshal(data, converted); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", event.EventKind(), err)
+	}
+	return converted, nil
+}
+
+// restStatusError describes a failed REST response, using the message of an
+// {"error": ...} body when the agent sent one. Errors carrying an A2A error code
+// match the SDK's error of the same kind with errors.Is, as on the other transports.
+func restStatusError(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+	var envelope struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Error != nil {
+		message := restErrorMessage(envelope.Error)
+		if kind, ok := restErrorKinds[restErrorCode(envelope.Error)]; ok {
+			return fmt.Errorf("server returned status %d: %w", resp.StatusCode, a2a.NewError(kind, message))
+		}
+		return fmt.Errorf("server returned status %d: %s", resp.StatusCode, message)
+	}
+	return fmt.Errorf("server returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// restErrorKinds maps the A2A error codes of REST error bodies to the SDK's errors
+var restErrorKinds = map[int]error{
+	protocol.ErrParse.Code:                        a2a.ErrParseError,
+	protocol.ErrInvalidRequest.Code:               a2a.ErrInvalidRequest,
+	protocol.ErrMethodNotFound.Code:               a2a.ErrMethodNotFound,
+	protocol.ErrInvalidParams.Code:                a2a.ErrInvalidParams,
+	protocol.ErrInternal.Code:                     a2a.ErrInternalError,
+	protocol.ErrTaskNotFound.Code:                 a2a.ErrTaskNotFound,
+	protocol.ErrTaskNotCancelable.Code:            a2a.ErrTaskNotCancelable,
+	protocol.ErrPushNotificationNotSupported.Code: a2a.ErrPushNotificationNotSupported,
+	protocol.ErrUnsupportedOperation.Code:         a2a.ErrUnsupportedOperation,
+	protocol.ErrContentTypeNotSupported.Code:      a2a.ErrUnsupportedContentType,
+	protocol.ErrInvalidAgentResponse.Code:         a2a.ErrInvalidAgentResponse,
+	protocol.ErrExtendedCardNotConfigured.Code:    a2a.ErrAuthenticatedExtendedCardNotConfigured,
+	protocol.ErrUnauthenticated.Code:              a2a.ErrUnauthenticated,
+	protocol.ErrUnauthorized.Code:                 a2a.ErrUnauthorized,
+}
+
+// restErrorMessage extracts the message from an error payload that is either a string or an object
+func restErrorMessage(raw json.RawMessage) string {
+	var message string
+	if err := json.Unmarshal(raw, &message); err == nil {
+		return message
+	}
+	var object struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(raw, &object); err == nil && object.Message != "" {
+		return object.Message
+	}
+	return string(raw)
+}
+
+// restErrorCode extracts the A2A error code from an error payload object, 0 if it has none.
+// Google API-style errors hold it in their ErrorInfo details, their code being the HTTP status.
+func restErrorCode(raw json.RawMessage) int {
+	var object protocol.HTTPErrorStatus
+	json.Unmarshal(raw, &object)
+	if code := object.ErrorCode(); code != 0 {
+		return code
+	}
+	return object.Code
+}
+
+// GetTask gets a task by ID
+func (c *RESTClient) GetTask(ctx context.Context, taskID string, historyLength *int) (*a2a.Task, error) {
+	url := fmt.Sprintf("%s/v1/tasks/%s", c.serverURL, taskID)
+	if historyLength != nil {
+		url += fmt.Sprintf("?historyLength=%d", *historyLength)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, restStatusError(resp)
+	}
+
+	var task a2a.Task
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &task, nil
+}
+
+// ListTasks lists tasks matching the request (GET /v1/tasks)
+func (c *RESTClient) ListTasks(ctx context.Context, req *a2a.ListTasksRequest) (*a2a.ListTasksResponse, error) {
+	query := url.Values{}
+	if req.ContextID != "" {
+		query.Set("contextId", req.ContextID)
+	}
+	if req.Status != "" {
+		query.Set("status", string(req.Status))
+	}
+	if req.PageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(req.PageSize))
+	}
+	if req.PageToken != "" {
+		query.Set("pageToken", req.PageToken)
+	}
+	endpoint := c.serverURL + "/v1/tasks"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, restStatusError(resp)
+	}
+
+	var list a2a.ListTasksResponse
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &list, nil
+}
+
+// CancelTask cancels a task
+func (c *RESTClient) CancelTask(ctx context.Context, taskID string) (*a2a.Task, error) {
+	url := fmt.Sprintf("%s/v1/tasks/%s:cancel", c.serverURL, taskID)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, restStatusError(resp)
+	}
+
+	var task a2a.Task
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &task, nil
+}
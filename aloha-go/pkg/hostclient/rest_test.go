@@ -0,0 +1,142 @@
+package hostclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestDecodeRESTEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    a2a.Event
+		wantErr string
+	}{
+		{
+			name: "status update by kind",
+			data: `{"kind":"status-update","taskId":"t1","contextId":"c1","final":true,"status":{"state":"completed"}}`,
+			want: &a2a.TaskStatusUpdateEvent{TaskID: "t1", ContextID: "c1", Final: true, Status: a2a.TaskStatus{State: a2a.TaskStateCompleted}},
+		},
+		{
+			name: "artifact update by kind",
+			data: `{"kind":"artifact-update","taskId":"t1","contextId":"c1","lastChunk":true,"artifact":{"artifactId":"a1","parts":[{"kind":"text","text":"4"}]}}`,
+			want: &a2a.TaskArtifactUpdateEvent{TaskID: "t1", ContextID: "c1", LastChunk: true,
+				Artifact: &a2a.Artifact{ID: "a1", Parts: a2a.ContentParts{a2a.TextPart{Text: "4"}}}},
+		},
+		{
+			name: "wrapped task",
+			data: `{"task":{"id":"t1","contextId":"c1","status":{"state":"working"}}}`,
+			want: &a2a.Task{ID: "t1", ContextID: "c1", Status: a2a.TaskStatus{State: a2a.TaskStateWorking}},
+		},
+		{
+			name: "wrapped message",
+			data: `{"message":{"messageId":"m1","role":"agent","parts":[{"kind":"text","text":"hi"}]}}`,
+			want: &a2a.Message{ID: "m1", Role: a2a.MessageRoleAgent, Parts: a2a.ContentParts{a2a.TextPart{Text: "hi"}}},
+		},
+		{
+			name: "wrapped status update",
+			data: `{"statusUpdate":{"taskId":"t1","contextId":"c1","status":{"state":"failed"}}}`,
+			want: &a2a.TaskStatusUpdateEvent{TaskID: "t1", ContextID: "c1", Status: a2a.TaskStatus{State: a2a.TaskStateFailed}},
+		},
+		{name: "unknown kind is skipped", data: `{"kind":"heartbeat"}`},
+		{name: "no kind is skipped", data: `{"ping":true}`},
+		{name: "error", data: `{"error":{"code":-32001,"message":"task not found"}}`, wantErr: "task not found"},
+		{name: "invalid state", data: `{"kind":"status-update","taskId":"t1","contextId":"c1","status":{"state":"done"}}`, wantErr: "unknown task state"},
+		{name: "not JSON", data: `data`, wantErr: "failed to decode stream event"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeRESTEvent([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("decodeRESTEvent error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeRESTEvent: %v", err)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("decodeRESTEvent = %#v, want the event skipped", got)
+				}
+				return
+			}
+			if gotJSON, wantJSON := mustJSON(t, got), mustJSON(t, tt.want); gotJSON != wantJSON {
+				t.Errorf("decodeRESTEvent = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+// TestRESTStreamEndsOnFinalEvent checks that a stream ends with its final event, without
+// waiting for the agent to close the connection
+func TestRESTStreamEndsOnFinalEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		events    []string
+		wantKinds []string
+	}{
+		{
+			name: "final status update",
+			events: []string{
+				`{"kind":"task","id":"t1","contextId":"c1","status":{"state":"submitted"}}`,
+				`{"kind":"status-update","taskId":"t1","contextId":"c1","final":true,"status":{"state":"completed"}}`,
+			},
+			wantKinds: []string{"*a2a.Task", "*a2a.TaskStatusUpdateEvent"},
+		},
+		{
+			name:      "message reply",
+			events:    []string{`{"kind":"message","messageId":"m1","role":"agent","parts":[{"kind":"text","text":"hi"}]}`},
+			wantKinds: []string{"*a2a.Message"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan struct{})
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				for _, event := range tt.events {
+					fmt.Fprintf(w, "data: %s\n\n", event)
+				}
+				w.(http.Flusher).Flush()
+				// Hold the stream open until the client is done with it
+				select {
+				case <-r.Context().Done():
+				case <-done:
+				}
+			}))
+			defer srv.Close()
+			defer close(done)
+
+			client := NewRESTClient(srv.Client(), srv.URL)
+			msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "hello"})
+			var kinds []string
+			for event, err := range client.SendStreamingMessage(context.Background(), &a2a.MessageSendParams{Message: msg}) {
+				if err != nil {
+					t.Fatalf("stream: %v", err)
+				}
+				kinds = append(kinds, fmt.Sprintf("%T", event))
+			}
+			if got, want := strings.Join(kinds, ","), strings.Join(tt.wantKinds, ","); got != want {
+				t.Errorf("events = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal %T: %v", v, err)
+	}
+	return string(data)
+}
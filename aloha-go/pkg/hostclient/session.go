@@ -0,0 +1,178 @@
+package hostclient
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/google/uuid"
+)
+
+// Session is one conversation with an agent. Messages sent through it carry the context ID
+// the agent assigned to the conversation, and continue the task the agent waits on for input.
+// A Session is safe for concurrent use, but its turns are meant to be taken one at a time.
+type Session struct {
+	id      string
+	client  *Client
+	created time.Time
+
+	mu        sync.Mutex
+	contextID string
+	taskID    a2a.TaskID // set while the agent waits for input on a task
+}
+
+// NewSession starts a session with the agent behind client. contextID continues an
+// existing conversation; empty starts a new one.
+func NewSession(client *Client, contextID string) *Session {
+	return &Session{id: uuid.NewString(), client: client, created: time.Now(), contextID: contextID}
+}
+
+// ID returns the identifier the session is known by in its Sessions
+func (s *Session) ID() string {
+	return s.id
+}
+
+// Client returns the client of the agent the session talks to
+func (s *Session) Client() *Client {
+	return s.client
+}
+
+// Created returns when the session was created
+func (s *Session) Created() time.Time {
+	return s.created
+}
+
+// ContextID returns the conversation's context ID, empty until the agent assigns one
+func (s *Session) ContextID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.contextID
+}
+
+// TaskID returns the task the agent waits on for input, empty when there is none
+func (s *Session) TaskID() a2a.TaskID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.taskID
+}
+
+// Message returns the parameters of the conversation's next user message
+func (s *Session) Message(parts ...a2a.Part) *a2a.MessageSendParams {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg := a2a.NewMessage(a2a.MessageRoleUser, parts...)
+	msg.ContextID = s.contextID
+	msg.TaskID = s.taskID
+	return &a2a.MessageSendParams{Message: msg}
+}
+
+// Observe records the progress of the conversation from a reply or a streamed event:
+// the context ID, and the task while the agent waits for input on it
+func (s *Session) Observe(event a2a.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if contextID := event.TaskInfo().ContextID; contextID != "" {
+		s.contextID = contextID
+	}
+	switch event := event.(type) {
+	case *a2a.Message:
+		if event.TaskID == "" {
+			s.taskID = ""
+		}
+	case *a2a.Task:
+		s.track(event.ID, event.Status.State)
+	case *a2a.TaskStatusUpdateEvent:
+		s.track(event.TaskID, event.Status.State)
+	}
+}
+
+// track keeps the task while it waits for input or authentication
+func (s *Session) track(taskID a2a.TaskID, state a2a.TaskState) {
+	s.taskID = ""
+	if state == a2a.TaskStateInputRequired || state == a2a.TaskStateAuthRequired {
+		s.taskID = taskID
+	}
+}
+
+// SendMessage sends the parts as the conversation's next non-streaming message
+func (s *Session) SendMessage(ctx context.Context, parts ...a2a.Part) (a2a.SendMessageResult, error) {
+	result, err := s.client.SendMessage(ctx, s.Message(parts...))
+	if err != nil {
+		return nil, err
+	}
+	s.Observe(result)
+	return result, nil
+}
+
+// Stream sends the parts as the conversation's next streaming message and yields its events
+func (s *Session) Stream(ctx context.Context, parts ...a2a.Part) iter.Seq2[a2a.Event, error] {
+	return s.Track(s.client.Stream(ctx, s.Message(parts...)))
+}
+
+// Track yields the events of a stream sent with Message, observing each one
+func (s *Session) Track(events iter.Seq2[a2a.Event, error]) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		for event, err := range events {
+			if err == nil {
+				s.Observe(event)
+			}
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// Sessions holds the open conversations of a host, with the same or different agents
+type Sessions struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+	order    []*Session // oldest first
+}
+
+// NewSessions creates an empty set of sessions
+func NewSessions() *Sessions {
+	return &Sessions{sessions: make(map[string]*Session)}
+}
+
+// Create opens a session with the agent behind client, as NewSession does
+func (m *Sessions) Create(client *Client, contextID string) *Session {
+	session := NewSession(client, contextID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[session.id] = session
+	m.order = append(m.order, session)
+	return session
+}
+
+// Get returns the open session with the given ID
+func (m *Sessions) Get(id string) (*Session, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[id]
+	return session, ok
+}
+
+// List returns the open sessions, oldest first
+func (m *Sessions) List() []*Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.order)
+}
+
+// Close forgets the session with the given ID and reports whether it was open. The
+// session's client is left open, as other sessions may share it.
+func (m *Sessions) Close(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[id]
+	if !ok {
+		return false
+	}
+	delete(m.sessions, id)
+	m.order = slices.DeleteFunc(m.order, func(s *Session) bool { return s == session })
+	return true
+}
@@ -0,0 +1,79 @@
+package hostclient
+
+import (
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+func TestSessionObserve(t *testing.T) {
+	task := func(state a2a.TaskState) *a2a.Task {
+		return &a2a.Task{ID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: state}}
+	}
+	status := func(state a2a.TaskState) *a2a.TaskStatusUpdateEvent {
+		return &a2a.TaskStatusUpdateEvent{TaskID: "task-1", ContextID: "ctx-1", Status: a2a.TaskStatus{State: state}}
+	}
+	tests := []struct {
+		name       string
+		events     []a2a.Event
+		wantTaskID a2a.TaskID
+	}{
+		{"message reply", []a2a.Event{&a2a.Message{ID: "m1", ContextID: "ctx-1"}}, ""},
+		{"completed task", []a2a.Event{task(a2a.TaskStateCompleted)}, ""},
+		{"input required", []a2a.Event{task(a2a.TaskStateInputRequired)}, "task-1"},
+		{"auth required", []a2a.Event{status(a2a.TaskStateAuthRequired)}, "task-1"},
+		{"input given", []a2a.Event{task(a2a.TaskStateInputRequired), status(a2a.TaskStateWorking), status(a2a.TaskStateCompleted)}, ""},
+		{"artifact keeps the task", []a2a.Event{status(a2a.TaskStateInputRequired), &a2a.TaskArtifactUpdateEvent{TaskID: "task-1", ContextID: "ctx-1"}}, "task-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := NewSession(nil, "")
+			for _, event := range tt.events {
+				session.Observe(event)
+			}
+			if got := session.ContextID(); got != "ctx-1" {
+				t.Errorf("ContextID = %q, want ctx-1", got)
+			}
+			if got := session.TaskID(); got != tt.wantTaskID {
+				t.Errorf("TaskID = %q, want %q", got, tt.wantTaskID)
+			}
+			msg := session.Message(a2a.TextPart{Text: "next"}).Message
+			if msg.ContextID != "ctx-1" || msg.TaskID != tt.wantTaskID {
+				t.Errorf("Message continues %q/%q, want ctx-1/%q", msg.ContextID, msg.TaskID, tt.wantTaskID)
+			}
+		})
+	}
+}
+
+func TestSessions(t *testing.T) {
+	sessions := NewSessions()
+	first := sessions.Create(nil, "ctx-1")
+	second := sessions.Create(nil, "")
+	if first.ID() == second.ID() {
+		t.Fatalf("sessions share the ID %s", first.ID())
+	}
+	if got := second.Message().Message.ContextID; got != "" {
+		t.Errorf("new session sends context ID %q, want none", got)
+	}
+
+	list := sessions.List()
+	if len(list) != 2 || list[0] != first || list[1] != second {
+		t.Fatalf("List = %v, want the sessions in creation order", list)
+	}
+	if got, ok := sessions.Get(first.ID()); !ok || got != first {
+		t.Errorf("Get(%s) = %v, %v", first.ID(), got, ok)
+	}
+
+	if !sessions.Close(first.ID()) {
+		t.Error("Close of an open session reported false")
+	}
+	if sessions.Close(first.ID()) {
+		t.Error("Close of a closed session reported true")
+	}
+	if _, ok := sessions.Get(first.ID()); ok {
+		t.Error("Get found a closed session")
+	}
+	if list := sessions.List(); len(list) != 1 || list[0] != second {
+		t.Errorf("List after Close = %v, want the second session", list)
+	}
+}
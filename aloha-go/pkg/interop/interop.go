@@ -0,0 +1,146 @@
+// Package interop checks that the Go client and the aloha agents of the other languages
+// understand each other. It knows where each language's agent listens and how to start
+// it, checks the raw wire format of cards, tasks, errors and SSE streams, and reports the
+// outcomes as a matrix of checks by agent.
+package interop
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Target is an agent under test
+type Target struct {
+	// Name identifies the agent in the report, e.g. "python"
+	Name string `json:"name"`
+	// CardURL serves the agent card
+	CardURL string `json:"cardUrl"`
+	// Script starts the agent, relative to the repository root; empty if it cannot be launched
+	Script string `json:"script,omitempty"`
+}
+
+// KnownTargets returns the agents of this repository, each started in JSON-RPC mode on
+// its language's ports, in the order of the port table
+func KnownTargets() []Target {
+	return []Target{
+		{Name: "java", CardURL: "http://localhost:11001", Script: "aloha-java/server/scripts/jsonrpc_server"},
+		{Name: "go", CardURL: "http://localhost:12001", Script: "aloha-go/server/scripts/jsonrpc_server"},
+		{Name: "python", CardURL: "http://localhost:13001", Script: "aloha-python/server/scripts/jsonrpc_server"},
+		{Name: "js", CardURL: "http://localhost:14001", Script: "aloha-js/server/scripts/jsonrpc_server"},
+		{Name: "csharp", CardURL: "http://localhost:15001", Script: "aloha-csharp/Server/scripts/jsonrpc_server"},
+	}
+}
+
+// Result is the outcome of one check against one agent
+type Result struct {
+	Agent   string `json:"agent"`
+	Check   string `json:"check"`
+	Passed  bool   `json:"passed"`
+	Skipped bool   `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+	// Duration is in nanoseconds, like the conformance report
+	Duration time.Duration `json:"durationNs"`
+}
+
+// Report is the check matrix: the checks run against each agent
+type Report struct {
+	Agents  []string `json:"agents"`
+	Checks  []string `json:"checks"`
+	Results []Result `json:"results"`
+}
+
+// Add records a result, adding its agent and check to the matrix
+func (r *Report) Add(result Result) {
+	if !slices.Contains(r.Agents, result.Agent) {
+		r.Agents = append(r.Agents, result.Agent)
+	}
+	if !slices.Contains(r.Checks, result.Check) {
+		r.Checks = append(r.Checks, result.Check)
+	}
+	r.Results = append(r.Results, result)
+}
+
+// Skip records that an agent was not checked, and why
+func (r *Report) Skip(agent, reason string) {
+	r.Add(Result{Agent: agent, Check: "reachable", Skipped: true, Error: reason})
+}
+
+// Result returns the outcome of a check on an agent
+func (r *Report) Result(check, agent string) (Result, bool) {
+	for _, result := range r.Results {
+		if result.Check == check && result.Agent == agent {
+			return result, true
+		}
+	}
+	return Result{}, false
+}
+
+// Failed returns the number of failed checks; skipped agents are not failures
+func (r *Report) Failed() int {
+	failed := 0
+	for _, result := range r.Results {
+		if !result.Passed && !result.Skipped {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Ran returns the number of checks run, whether they passed or failed
+func (r *Report) Ran() int {
+	ran := 0
+	for _, result := range r.Results {
+		if !result.Skipped {
+			ran++
+		}
+	}
+	return ran
+}
+
+// Write renders the report as a pass/fail matrix, one row per check and one column per
+// agent, followed by the reason of each failure and skip. A check that does not apply to
+// an agent, such as gRPC on an agent without it, is shown as "-".
+func (r *Report) Write(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\t"+strings.Join(r.Agents, "\t"))
+	for _, check := range r.Checks {
+		row := []string{check}
+		for _, agent := range r.Agents {
+			result, ok := r.Result(check, agent)
+			switch {
+			case !ok:
+				row = append(row, "-")
+			case result.Skipped:
+				row = append(row, "SKIP")
+			case result.Passed:
+				row = append(row, "PASS")
+			default:
+				row = append(row, "FAIL")
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+
+	failed := r.Failed()
+	fmt.Fprintf(w, "\n%d of %d checks passed\n", r.Ran()-failed, r.Ran())
+	var skipped, failures []string
+	for _, result := range r.Results {
+		switch {
+		case result.Skipped:
+			skipped = append(skipped, fmt.Sprintf("  %s: %s", result.Agent, result.Error))
+		case !result.Passed:
+			failures = append(failures, fmt.Sprintf("  %s [%s]: %s", result.Check, result.Agent, result.Error))
+		}
+	}
+	if len(skipped) > 0 {
+		fmt.Fprintf(w, "\nSkipped:\n%s\n", strings.Join(skipped, "\n"))
+	}
+	if len(failures) > 0 {
+		fmt.Fprintf(w, "\nFailures:\n%s\n", strings.Join(failures, "\n"))
+	}
+}
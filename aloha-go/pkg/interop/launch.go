@@ -0,0 +1,155 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// agentCardPath is where agents serve their card
+const agentCardPath = "/.well-known/agent-card.json"
+
+// stopTimeout is how long a launched agent has to exit after an interrupt
+const stopTimeout = 5 * time.Second
+
+// Process is an agent started by Launch
+type Process struct {
+	target  Target
+	cmd     *exec.Cmd
+	logPath string
+	exited  chan struct{}
+	err     error
+}
+
+// FindRepoRoot returns the repository root holding the agents of every language: the
+// first of dir and its parents with an aloha-go and an aloha-python directory
+func FindRepoRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if isDir(filepath.Join(dir, "aloha-go")) && isDir(filepath.Join(dir, "aloha-python")) {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("not inside the aloha-a2a repository")
+		}
+		dir = parent
+	}
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// Launch starts the agent of target with its script under root, logging its output to
+// a file in the temporary directory, and waits until it serves its card
+func Launch(ctx context.Context, root string, target Target, startupTimeout time.Duration) (*Process, error) {
+	if target.Script == "" {
+		return nil, fmt.Errorf("no script starts agent %s", target.Name)
+	}
+	script := filepath.Join(root, filepath.FromSlash(target.Script))
+	if _, err := os.Stat(script); err != nil {
+		return nil, fmt.Errorf("cannot launch agent %s: %w", target.Name, err)
+	}
+	logFile, err := os.CreateTemp("", "aloha-interop-"+target.Name+"-*.log")
+	if err != nil {
+		return nil, err
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command("bash", script)
+	cmd.Dir = root
+	cmd.Stdout, cmd.Stderr = logFile, logFile
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start agent %s: %w", target.Name, err)
+	}
+	p := &Process{target: target, cmd: cmd, logPath: logFile.Name(), exited: make(chan struct{})}
+	go func() {
+		p.err = cmd.Wait()
+		close(p.exited)
+	}()
+
+	waitCtx, cancel := context.WithTimeout(ctx, startupTimeout)
+	defer cancel()
+	if err := p.waitForCard(waitCtx); err != nil {
+		p.Stop()
+		return nil, fmt.Errorf("agent %s did not start: %w (output in %s)", target.Name, err, p.logPath)
+	}
+	return p, nil
+}
+
+// waitForCard polls the card URL until it answers, the process exits or ctx is done
+func (p *Process) waitForCard(ctx context.Context) error {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if Reachable(ctx, p.target.CardURL) {
+			return nil
+		}
+		select {
+		case <-p.exited:
+			return fmt.Errorf("exited: %v", p.err)
+		case <-ctx.Done():
+			return fmt.Errorf("no agent card at %s", p.target.CardURL)
+		case <-ticker.C:
+		}
+	}
+}
+
+// LogPath returns the file holding the output of the agent
+func (p *Process) LogPath() string {
+	return p.logPath
+}
+
+// Stop interrupts the agent and the processes its script started, killing them if they
+// have not exited within a few seconds
+func (p *Process) Stop() {
+	select {
+	case <-p.exited:
+		return
+	default:
+	}
+	interruptProcess(p.cmd)
+	select {
+	case <-p.exited:
+	case <-time.After(stopTimeout):
+		killProcess(p.cmd)
+		<-p.exited
+	}
+}
+
+// Reachable reports whether an agent card is served at cardURL
+func Reachable(ctx context.Context, cardURL string) bool {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, CardURL(cardURL), nil)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// CardURL returns the URL of the card of the agent at cardURL, which may be the agent's
+// base URL or the card URL itself
+func CardURL(cardURL string) string {
+	if strings.HasSuffix(cardURL, agentCardPath) || strings.HasSuffix(cardURL, ".json") {
+		return cardURL
+	}
+	return strings.TrimSuffix(cardURL, "/") + agentCardPath
+}
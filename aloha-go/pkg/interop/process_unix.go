@@ -0,0 +1,24 @@
+//go:build !windows
+
+package interop
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts the agent in its own process group, so that the processes its
+// script starts, such as go run or npm, are stopped with it
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// interruptProcess interrupts the process group of the agent
+func interruptProcess(cmd *exec.Cmd) {
+	syscall.Kill(-cmd.Process.Pid, syscall.SIGINT)
+}
+
+// killProcess kills the process group of the agent
+func killProcess(cmd *exec.Cmd) {
+	syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
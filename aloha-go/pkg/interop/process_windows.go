@@ -0,0 +1,21 @@
+//go:build windows
+
+package interop
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+// setProcessGroup does nothing: on Windows, the process tree is killed with taskkill
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// interruptProcess stops the process tree of the agent; Windows has no interrupt to send
+func interruptProcess(cmd *exec.Cmd) {
+	exec.Command("taskkill", "/T", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+}
+
+// killProcess forcibly kills the process tree of the agent
+func killProcess(cmd *exec.Cmd) {
+	exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+}
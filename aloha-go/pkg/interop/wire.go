@@ -0,0 +1,475 @@
+package interop
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+	"github.com/aloha/a2a-go/pkg/sse"
+	"github.com/google/uuid"
+)
+
+// maxResponseSize bounds a response read by the wire checks
+const maxResponseSize = 10 << 20
+
+// Task states and event kinds as the A2A JSON schema spells them
+var (
+	taskStates = []string{"submitted", "working", "input-required", "completed", "canceled", "failed", "rejected", "auth-required", "unknown"}
+	eventKinds = []string{"task", "message", "status-update", "artifact-update"}
+	partKinds  = []string{"text", "file", "data"}
+	transports = []string{"JSONRPC", "GRPC", "HTTP+JSON"}
+)
+
+// WireCheck checks the raw JSON an agent sends, below any SDK that would tolerate or
+// normalize deviations
+type WireCheck struct {
+	Name        string
+	Description string
+	Run         func(ctx context.Context, w *Wire) error
+}
+
+// Wire sends raw HTTP requests to an agent
+type Wire struct {
+	HTTP    *http.Client
+	CardURL string
+	// Message is the text sent by the checks that need an answer
+	Message string
+
+	card map[string]any
+}
+
+// WireChecks returns the wire checks: the agent card, a JSON-RPC task, JSON-RPC errors and
+// the framing of an SSE stream
+func WireChecks() []WireCheck {
+	return []WireCheck{
+		{Name: "wire: card", Description: "The card is JSON with the required fields and types", Run: checkCardWire},
+		{Name: "wire: jsonrpc task", Description: "message/send answers with the request id and a well-formed task or message", Run: checkTaskWire},
+		{Name: "wire: jsonrpc errors", Description: "Unknown methods and tasks fail with the JSON-RPC and A2A error codes", Run: checkErrorsWire},
+		{Name: "wire: sse framing", Description: "message/stream sends text/event-stream events of one task, ending with a final status", Run: checkSSEWire},
+	}
+}
+
+// RunWireChecks runs the wire checks against an agent, each with its own time limit
+func RunWireChecks(ctx context.Context, w *Wire, agent string, timeout time.Duration) []Result {
+	var results []Result
+	for _, check := range WireChecks() {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		start := time.Now()
+		err := check.Run(checkCtx, w)
+		cancel()
+		result := Result{Agent: agent, Check: check.Name, Passed: err == nil, Duration: time.Since(start)}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// fetchCard returns the card as a JSON object, fetched once
+func (w *Wire) fetchCard(ctx context.Context) (map[string]any, error) {
+	if w.card != nil {
+		return w.card, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, CardURL(w.CardURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := w.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("card answered %s", resp.Status)
+	}
+	if err := expectMediaType(resp, "application/json"); err != nil {
+		return nil, err
+	}
+	var card map[string]any
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&card); err != nil {
+		return nil, fmt.Errorf("card is not a JSON object: %w", err)
+	}
+	w.card = card
+	return card, nil
+}
+
+// jsonrpcURL returns the JSON-RPC endpoint the card declares
+func (w *Wire) jsonrpcURL(ctx context.Context) (string, error) {
+	card, err := w.fetchCard(ctx)
+	if err != nil {
+		return "", err
+	}
+	preferred, _ := card["preferredTransport"].(string)
+	if u, ok := card["url"].(string); ok && (preferred == "" || preferred == "JSONRPC") {
+		return u, nil
+	}
+	interfaces, _ := card["additionalInterfaces"].([]any)
+	for _, iface := range interfaces {
+		if iface, ok := iface.(map[string]any); ok && iface["transport"] == "JSONRPC" {
+			if u, ok := iface["url"].(string); ok {
+				return u, nil
+			}
+		}
+	}
+	return "", errors.New("the card declares no JSON-RPC interface")
+}
+
+// call POSTs a JSON-RPC request and returns the response
+func (w *Wire) call(ctx context.Context, id any, method string, params any, accept string) (*http.Response, error) {
+	endpoint, err := w.jsonrpcURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", accept)
+	return w.HTTP.Do(req)
+}
+
+// rpc sends a JSON-RPC request and returns the response object, checking its envelope:
+// version 2.0, the id of the request with its JSON type, and either a result or an error
+func (w *Wire) rpc(ctx context.Context, id any, method string, params any) (map[string]any, error) {
+	resp, err := w.call(ctx, id, method, params, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := expectMediaType(resp, "application/json"); err != nil {
+		return nil, err
+	}
+	var msg map[string]any
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&msg); err != nil {
+		return nil, fmt.Errorf("%s response is not a JSON object: %w", method, err)
+	}
+	return msg, checkEnvelope(msg, id)
+}
+
+// textMessage returns a user message as the A2A JSON schema spells it
+func textMessage(text string) protocol.Message {
+	return protocol.NewUserText("", text)
+}
+
+func checkCardWire(ctx context.Context, w *Wire) error {
+	card, err := w.fetchCard(ctx)
+	if err != nil {
+		return err
+	}
+	for _, field := range []string{"name", "description", "url", "version", "protocolVersion"} {
+		if _, err := stringField(card, field); err != nil {
+			return err
+		}
+	}
+	if u, _ := card["url"].(string); !isHTTPURL(u) {
+		return fmt.Errorf("url %q is not an absolute http(s) URL", u)
+	}
+	if _, ok := card["capabilities"].(map[string]any); !ok {
+		return errors.New("capabilities is not an object")
+	}
+	for _, field := range []string{"defaultInputModes", "defaultOutputModes"} {
+		if err := stringArray(card, field); err != nil {
+			return err
+		}
+	}
+	skills, ok := card["skills"].([]any)
+	if !ok {
+		return errors.New("skills is not an array")
+	}
+	for i, skill := range skills {
+		skill, ok := skill.(map[string]any)
+		if !ok {
+			return fmt.Errorf("skills[%d] is not an object", i)
+		}
+		for _, field := range []string{"id", "name", "description"} {
+			if _, err := stringField(skill, field); err != nil {
+				return fmt.Errorf("skills[%d]: %w", i, err)
+			}
+		}
+		if err := stringArray(skill, "tags"); err != nil {
+			return fmt.Errorf("skills[%d]: %w", i, err)
+		}
+	}
+	if preferred, ok := card["preferredTransport"]; ok && !slices.Contains(transports, fmt.Sprint(preferred)) {
+		return fmt.Errorf("preferredTransport %v is not one of %v", preferred, transports)
+	}
+	interfaces, _ := card["additionalInterfaces"].([]any)
+	for i, iface := range interfaces {
+		iface, ok := iface.(map[string]any)
+		if !ok || !slices.Contains(transports, fmt.Sprint(iface["transport"])) {
+			return fmt.Errorf("additionalInterfaces[%d] has no transport among %v", i, transports)
+		}
+		if _, err := stringField(iface, "url"); err != nil {
+			return fmt.Errorf("additionalInterfaces[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func checkTaskWire(ctx context.Context, w *Wire) error {
+	id := "interop-" + uuid.NewString()
+	msg, err := w.rpc(ctx, id, "message/send", map[string]any{"message": textMessage(w.Message)})
+	if err != nil {
+		return err
+	}
+	if msg["error"] != nil {
+		return fmt.Errorf("message/send failed: %v", msg["error"])
+	}
+	result, ok := msg["result"].(map[string]any)
+	if !ok {
+		return errors.New("result is not an object")
+	}
+	switch result["kind"] {
+	case "task":
+		return checkTask(result)
+	case "message":
+		return checkParts(result, "result")
+	default:
+		return fmt.Errorf("result kind %v is neither task nor message", result["kind"])
+	}
+}
+
+func checkErrorsWire(ctx context.Context, w *Wire) error {
+	// A numeric id must come back as a number
+	msg, err := w.rpc(ctx, 42, "interop/unknown-method", map[string]any{})
+	if err != nil {
+		return err
+	}
+	if err := expectErrorCode(msg, -32601); err != nil {
+		return fmt.Errorf("unknown method: %w", err)
+	}
+	msg, err = w.rpc(ctx, 43, "tasks/get", map[string]any{"id": "interop-unknown-task"})
+	if err != nil {
+		return err
+	}
+	if err := expectErrorCode(msg, -32001); err != nil {
+		return fmt.Errorf("unknown task: %w", err)
+	}
+	return nil
+}
+
+func checkSSEWire(ctx context.Context, w *Wire) error {
+	id := "interop-" + uuid.NewString()
+	resp, err := w.call(ctx, id, "message/stream", map[string]any{"message": textMessage(w.Message)}, "text/event-stream")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := expectMediaType(resp, "text/event-stream"); err != nil {
+		return err
+	}
+
+	decoder := sse.NewDecoder(resp.Body)
+	var taskID string
+	events, final := 0, false
+	for {
+		event, err := decoder.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("event %d: %w", events+1, err)
+		}
+		if final {
+			return fmt.Errorf("event %d follows the final event", events+1)
+		}
+		events++
+		if event.Type != "message" {
+			return fmt.Errorf("event %d has type %q; A2A events use the default message type", events, event.Type)
+		}
+		var msg map[string]any
+		if err := json.Unmarshal([]byte(event.Data), &msg); err != nil {
+			return fmt.Errorf("event %d data is not a JSON object: %w", events, err)
+		}
+		if err := checkEnvelope(msg, id); err != nil {
+			return fmt.Errorf("event %d: %w", events, err)
+		}
+		if msg["error"] != nil {
+			return fmt.Errorf("event %d is an error: %v", events, msg["error"])
+		}
+		result, _ := msg["result"].(map[string]any)
+		kind, _ := result["kind"].(string)
+		if !slices.Contains(eventKinds, kind) {
+			return fmt.Errorf("event %d kind %q is not one of %v", events, kind, eventKinds)
+		}
+
+		eventTaskID, _ := result["taskId"].(string)
+		switch kind {
+		case "task":
+			eventTaskID, _ = result["id"].(string)
+			if err := checkTask(result); err != nil {
+				return fmt.Errorf("event %d: %w", events, err)
+			}
+		case "message":
+			// A message answers the request by itself and ends the stream
+			final = true
+			continue
+		case "status-update":
+			final, _ = result["final"].(bool)
+			if err := checkStatus(result); err != nil {
+				return fmt.Errorf("event %d: %w", events, err)
+			}
+		case "artifact-update":
+			artifact, _ := result["artifact"].(map[string]any)
+			if err := checkParts(artifact, "artifact"); err != nil {
+				return fmt.Errorf("event %d: %w", events, err)
+			}
+		}
+		if taskID == "" {
+			taskID = eventTaskID
+		} else if eventTaskID != taskID {
+			return fmt.Errorf("event %d is about task %q, not %q", events, eventTaskID, taskID)
+		}
+	}
+	if events == 0 {
+		return errors.New("the stream has no events")
+	}
+	if !final {
+		return errors.New("the stream ended without a final status update")
+	}
+	return nil
+}
+
+// checkEnvelope checks a JSON-RPC response: version 2.0, the request id with its JSON
+// type, and exactly one of result and error
+func checkEnvelope(msg map[string]any, id any) error {
+	if msg["jsonrpc"] != "2.0" {
+		return fmt.Errorf(`jsonrpc is %v, not "2.0"`, msg["jsonrpc"])
+	}
+	want, _ := json.Marshal(id)
+	got, _ := json.Marshal(msg["id"])
+	if !bytes.Equal(want, got) {
+		return fmt.Errorf("response id %s does not match request id %s", got, want)
+	}
+	_, hasResult := msg["result"]
+	_, hasError := msg["error"]
+	if hasResult == hasError {
+		return errors.New("a response has either a result or an error")
+	}
+	return nil
+}
+
+// expectErrorCode checks that a JSON-RPC response is an error with an integer code and a message
+func expectErrorCode(msg map[string]any, code int) error {
+	rpcErr, ok := msg["error"].(map[string]any)
+	if !ok {
+		return errors.New("no error object in the response")
+	}
+	got, ok := rpcErr["code"].(float64)
+	if !ok || got != float64(int(got)) {
+		return fmt.Errorf("error code %v is not an integer", rpcErr["code"])
+	}
+	if int(got) != code {
+		return fmt.Errorf("error code %d, want %d", int(got), code)
+	}
+	if _, err := stringField(rpcErr, "message"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkTask checks the ids, status and artifacts of a task
+func checkTask(task map[string]any) error {
+	for _, field := range []string{"id", "contextId"} {
+		if _, err := stringField(task, field); err != nil {
+			return fmt.Errorf("task: %w", err)
+		}
+	}
+	if err := checkStatus(task); err != nil {
+		return err
+	}
+	artifacts, _ := task["artifacts"].([]any)
+	for i, artifact := range artifacts {
+		artifact, _ := artifact.(map[string]any)
+		if _, err := stringField(artifact, "artifactId"); err != nil {
+			return fmt.Errorf("artifacts[%d]: %w", i, err)
+		}
+		if err := checkParts(artifact, fmt.Sprintf("artifacts[%d]", i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkStatus checks the state and timestamp of the status of a task or status update
+func checkStatus(v map[string]any) error {
+	status, ok := v["status"].(map[string]any)
+	if !ok {
+		return errors.New("status is not an object")
+	}
+	state, _ := status["state"].(string)
+	if !slices.Contains(taskStates, state) {
+		return fmt.Errorf("status state %q is not one of %v", state, taskStates)
+	}
+	if timestamp, ok := status["timestamp"]; ok {
+		s, _ := timestamp.(string)
+		if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+			return fmt.Errorf("status timestamp %v is not RFC 3339", timestamp)
+		}
+	}
+	return nil
+}
+
+// checkParts checks that an object has parts, each of a known kind
+func checkParts(v map[string]any, what string) error {
+	parts, ok := v["parts"].([]any)
+	if !ok || len(parts) == 0 {
+		return fmt.Errorf("%s has no parts", what)
+	}
+	for i, part := range parts {
+		part, _ := part.(map[string]any)
+		if kind, _ := part["kind"].(string); !slices.Contains(partKinds, kind) {
+			return fmt.Errorf("%s part %d kind %q is not one of %v", what, i, kind, partKinds)
+		}
+	}
+	return nil
+}
+
+func stringField(v map[string]any, field string) (string, error) {
+	s, ok := v[field].(string)
+	if !ok {
+		return "", fmt.Errorf("%s is not a string", field)
+	}
+	return s, nil
+}
+
+func stringArray(v map[string]any, field string) error {
+	values, ok := v[field].([]any)
+	if !ok {
+		return fmt.Errorf("%s is not an array", field)
+	}
+	for i, value := range values {
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s[%d] is not a string", field, i)
+		}
+	}
+	return nil
+}
+
+func expectMediaType(resp *http.Response, want string) error {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != want {
+		return fmt.Errorf("Content-Type is %q, want %s", resp.Header.Get("Content-Type"), want)
+	}
+	return nil
+}
+
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
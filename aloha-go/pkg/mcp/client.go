@@ -0,0 +1,406 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+	"github.com/aloha/a2a-go/pkg/sse"
+)
+
+// Client is a session with an MCP server
+type Client struct {
+	target    string
+	transport transport
+	nextID    atomic.Int64
+	init      InitializeResult
+}
+
+// transport carries JSON-RPC messages to an MCP server
+type transport interface {
+	// call sends a request and returns the response to it
+	call(ctx context.Context, req *message) (*message, error)
+	// notify sends a notification
+	notify(ctx context.Context, msg *message) error
+	close() error
+}
+
+// Connect reaches the MCP server at target and opens a session with it. An http(s) URL
+// is reached with the streamable HTTP transport; anything else is a command line, split
+// on spaces, run as a subprocess speaking over stdio.
+func Connect(ctx context.Context, target string, info Implementation) (*Client, error) {
+	var t transport
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		t = &httpTransport{url: target, http: http.DefaultClient}
+	} else {
+		stdio, err := startStdio(target)
+		if err != nil {
+			return nil, err
+		}
+		t = stdio
+	}
+
+	c := &Client{target: target, transport: t}
+	params := InitializeParams{ProtocolVersion: ProtocolVersion, Capabilities: map[string]any{}, ClientInfo: info}
+	if err := c.request(ctx, "initialize", params, &c.init); err != nil {
+		t.close()
+		return nil, fmt.Errorf("failed to initialize MCP session with %s: %w", target, err)
+	}
+	if !slices.Contains(supportedVersions, c.init.ProtocolVersion) {
+		t.close()
+		return nil, fmt.Errorf("MCP server %s speaks unsupported protocol version %q", target, c.init.ProtocolVersion)
+	}
+	if h, ok := t.(*httpTransport); ok {
+		h.setVersion(c.init.ProtocolVersion)
+	}
+	if err := t.notify(ctx, &message{JSONRPC: "2.0", Method: "notifications/initialized"}); err != nil {
+		t.close()
+		return nil, fmt.Errorf("failed to initialize MCP session with %s: %w", target, err)
+	}
+	return c, nil
+}
+
+// Target returns the URL or command line the client connected to
+func (c *Client) Target() string {
+	return c.target
+}
+
+// Server returns the name and version the server gave when the session was opened
+func (c *Client) Server() Implementation {
+	return c.init.ServerInfo
+}
+
+// ListTools returns every tool the server offers, following pagination
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	var all []Tool
+	cursor := ""
+	for {
+		params := map[string]any{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+		var page ListToolsResult
+		if err := c.request(ctx, "tools/list", params, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Tools...)
+		if page.NextCursor == "" {
+			return all, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// CallTool calls a tool. A tool that fails returns a result with IsError set.
+func (c *Client) CallTool(ctx context.Context, name string, args map[string]any) (*CallToolResult, error) {
+	var result CallToolResult
+	if err := c.request(ctx, "tools/call", CallToolParams{Name: name, Arguments: args}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Ping checks that the server still answers
+func (c *Client) Ping(ctx context.Context) error {
+	return c.request(ctx, "ping", nil, nil)
+}
+
+// Close ends the session, stopping the server if the client started it
+func (c *Client) Close() error {
+	return c.transport.close()
+}
+
+// request sends a request and decodes its result into out, if not nil. Errors answered
+// by the server are returned as *protocol.Error.
+func (c *Client) request(ctx context.Context, method string, params any, out any) error {
+	req := &message{JSONRPC: "2.0", ID: json.RawMessage(strconv.FormatInt(c.nextID.Add(1), 10)), Method: method}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s params: %w", method, err)
+		}
+		req.Params = data
+	}
+	resp, err := c.transport.call(ctx, req)
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, out); err != nil {
+		return fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	return nil
+}
+
+// stdioTransport talks to a server run as a subprocess, over its stdin and stdout
+type stdioTransport struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	pending map[string]chan *message
+	done    chan struct{}
+	err     error
+}
+
+// startStdio starts the server command; its stderr goes to ours
+func startStdio(command string) (*stdioTransport, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, errors.New("empty MCP server command")
+	}
+	cmd := exec.Command(fields[0], fields[1:]...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start MCP server %q: %w", command, err)
+	}
+	t := &stdioTransport{cmd: cmd, stdin: stdin, pending: make(map[string]chan *message), done: make(chan struct{})}
+	go t.read(stdout)
+	return t, nil
+}
+
+// read dispatches the server's messages until its stdout closes
+func (t *stdioTransport) read(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		msg := new(message)
+		if err := json.Unmarshal(line, msg); err != nil {
+			continue
+		}
+		switch {
+		case msg.isResponse():
+			t.mu.Lock()
+			ch := t.pending[string(msg.ID)]
+			delete(t.pending, string(msg.ID))
+			t.mu.Unlock()
+			if ch != nil {
+				ch <- msg
+			}
+		case msg.isRequest():
+			// Servers may ping their clients; nothing else is offered to them
+			if msg.Method == "ping" {
+				t.write(msg.response(struct{}{}, nil))
+			} else {
+				t.write(msg.response(nil, protocol.ErrMethodNotFound.Withf("%s", msg.Method)))
+			}
+		}
+	}
+	t.err = errors.New("MCP server closed its output")
+	if err := scanner.Err(); err != nil {
+		t.err = fmt.Errorf("failed to read from MCP server: %w", err)
+	}
+	close(t.done)
+}
+
+func (t *stdioTransport) write(msg *message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	_, err = t.stdin.Write(append(data, '\n'))
+	return err
+}
+
+func (t *stdioTransport) call(ctx context.Context, req *message) (*message, error) {
+	ch := make(chan *message, 1)
+	t.mu.Lock()
+	t.pending[string(req.ID)] = ch
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		delete(t.pending, string(req.ID))
+		t.mu.Unlock()
+	}()
+
+	if err := t.write(req); err != nil {
+		return nil, fmt.Errorf("failed to write to MCP server: %w", err)
+	}
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-t.done:
+		return nil, t.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *stdioTransport) notify(ctx context.Context, msg *message) error {
+	return t.write(msg)
+}
+
+// close closes the server's stdin, which asks it to exit, and kills it if it has not
+// exited within two seconds
+func (t *stdioTransport) close() error {
+	t.stdin.Close()
+	exited := make(chan error, 1)
+	go func() { exited <- t.cmd.Wait() }()
+	select {
+	case <-exited:
+	case <-time.After(2 * time.Second):
+		t.cmd.Process.Kill()
+		<-exited
+	}
+	return nil
+}
+
+// httpTransport talks to a server over streamable HTTP: each message is POSTed, and a
+// request is answered with either a JSON body or an SSE stream ending with the response
+type httpTransport struct {
+	url  string
+	http *http.Client
+
+	mu        sync.Mutex
+	sessionID string
+	version   string
+}
+
+func (t *httpTransport) setVersion(version string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.version = version
+}
+
+// post sends a message and returns the HTTP response, failing on error statuses
+func (t *httpTransport) post(ctx context.Context, msg *message) (*http.Response, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	t.mu.Lock()
+	if t.sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", t.sessionID)
+	}
+	if t.version != "" {
+		req.Header.Set("MCP-Protocol-Version", t.version)
+	}
+	t.mu.Unlock()
+
+	resp, err := t.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("MCP request failed: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		// Errors may still be JSON-RPC responses, e.g. to a malformed request
+		var errResp message
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxMessageSize)).Decode(&errResp); err == nil && errResp.Error != nil {
+			return nil, errResp.Error
+		}
+		return nil, fmt.Errorf("MCP server answered %s", resp.Status)
+	}
+	if id := resp.Header.Get("Mcp-Session-Id"); id != "" {
+		t.mu.Lock()
+		t.sessionID = id
+		t.mu.Unlock()
+	}
+	return resp, nil
+}
+
+func (t *httpTransport) call(ctx context.Context, req *message) (*message, error) {
+	resp, err := t.post(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/event-stream" {
+		msg := new(message)
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxMessageSize)).Decode(msg); err != nil {
+			return nil, fmt.Errorf("failed to decode MCP response: %w", err)
+		}
+		return msg, nil
+	}
+
+	// The stream may carry the server's notifications before the response
+	decoder := sse.NewDecoder(resp.Body)
+	for {
+		event, err := decoder.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil, errors.New("MCP stream ended without a response")
+			}
+			return nil, fmt.Errorf("failed to read MCP stream: %w", err)
+		}
+		msg := new(message)
+		if err := json.Unmarshal([]byte(event.Data), msg); err != nil {
+			continue
+		}
+		if msg.isResponse() && bytes.Equal(msg.ID, req.ID) {
+			return msg, nil
+		}
+	}
+}
+
+func (t *httpTransport) notify(ctx context.Context, msg *message) error {
+	resp, err := t.post(ctx, msg)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// close ends the session on the server, if it opened one
+func (t *httpTransport) close() error {
+	t.mu.Lock()
+	sessionID := t.sessionID
+	t.mu.Unlock()
+	if sessionID == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, t.url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Mcp-Session-Id", sessionID)
+	resp, err := t.http.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
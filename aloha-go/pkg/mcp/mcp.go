@@ -0,0 +1,134 @@
+// Package mcp speaks the Model Context Protocol, the JSON-RPC protocol through which LLM
+// applications discover and call tools. Server offers a tools.Registry to MCP clients over
+// stdio or streamable HTTP. Client calls the tools of an MCP server, and Client.Tools
+// adapts them to tools.Tool so that the agent can offer them to its model like its own.
+package mcp
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// ProtocolVersion is the MCP revision spoken by default
+const ProtocolVersion = "2025-06-18"
+
+// supportedVersions are the MCP revisions a server accepts, newest first
+var supportedVersions = []string{ProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// maxMessageSize bounds a JSON-RPC message read from a peer
+const maxMessageSize = 4 << 20
+
+// message is a JSON-RPC 2.0 request, notification or response
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *protocol.Error `json:"error,omitempty"`
+}
+
+// isRequest reports whether the message expects a response
+func (m *message) isRequest() bool {
+	return m.Method != "" && len(m.ID) > 0
+}
+
+// isResponse reports whether the message answers a request
+func (m *message) isResponse() bool {
+	return m.Method == "" && len(m.ID) > 0
+}
+
+// response returns the response to the request m carrying result or err
+func (m *message) response(result any, err *protocol.Error) *message {
+	resp := &message{JSONRPC: "2.0", ID: m.ID, Error: err}
+	if err == nil {
+		data, marshalErr := json.Marshal(result)
+		if marshalErr != nil {
+			resp.Error = protocol.ErrInternal.Withf("failed to encode result: %v", marshalErr)
+		} else {
+			resp.Result = data
+		}
+	}
+	return resp
+}
+
+// Implementation names a client or server and its version
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// InitializeParams opens a session: the client proposes a protocol revision
+type InitializeParams struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ClientInfo      Implementation `json:"clientInfo"`
+}
+
+// InitializeResult is the server's answer to initialize: the revision both sides use
+type InitializeResult struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ServerInfo      Implementation `json:"serverInfo"`
+	Instructions    string         `json:"instructions,omitempty"`
+}
+
+// Tool describes a tool a server offers
+type Tool struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// InputSchema is the JSON schema of the arguments, always an object
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+// ListToolsResult is a page of the tools a server offers
+type ListToolsResult struct {
+	Tools      []Tool `json:"tools"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// CallToolParams calls a tool by name
+type CallToolParams struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+}
+
+// Content is one item of a tool result; tools of this repository only return text
+type Content struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	Data     string `json:"data,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// CallToolResult is the outcome of a tool call. A tool that fails reports its error
+// here with IsError set, so that the model can see it, rather than as a protocol error.
+type CallToolResult struct {
+	Content []Content `json:"content"`
+	// StructuredContent is the result as a JSON object, when the tool has one
+	StructuredContent any  `json:"structuredContent,omitempty"`
+	IsError           bool `json:"isError,omitempty"`
+}
+
+// Text returns the text content of the result
+func (r *CallToolResult) Text() string {
+	var texts []string
+	for _, content := range r.Content {
+		if content.Type == "text" {
+			texts = append(texts, content.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// negotiateVersion returns the revision a server answers a client proposing requested with
+func negotiateVersion(requested string) string {
+	if slices.Contains(supportedVersions, requested) {
+		return requested
+	}
+	return ProtocolVersion
+}
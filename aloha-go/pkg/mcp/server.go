@@ -0,0 +1,190 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+	"github.com/aloha/a2a-go/pkg/tools"
+)
+
+// Server offers the tools of a registry to MCP clients
+type Server struct {
+	info     Implementation
+	registry *tools.Registry
+}
+
+// NewServer creates a server named name offering the tools of registry
+func NewServer(name, version string, registry *tools.Registry) *Server {
+	return &Server{info: Implementation{Name: name, Version: version}, registry: registry}
+}
+
+// ServeStdio serves one client over newline-delimited JSON-RPC messages read from r and
+// written to w, the transport of servers run as a subprocess. Requests are answered
+// concurrently, so a slow tool call does not hold up the others. It returns when r ends.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	var mu sync.Mutex
+	encoder := json.NewEncoder(w)
+	write := func(msg *message) {
+		mu.Lock()
+		defer mu.Unlock()
+		encoder.Encode(msg)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		msg := new(message)
+		if err := json.Unmarshal(line, msg); err != nil {
+			write(parseErrorResponse(err))
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if resp := s.handle(ctx, msg); resp != nil {
+				write(resp)
+			}
+		}()
+	}
+	return scanner.Err()
+}
+
+// ServeHTTP implements the streamable HTTP transport: each POST carries one message, and
+// requests are answered with a JSON response. The server never streams or sends requests
+// of its own, so GET is not allowed and sessions are not needed.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "MCP messages are POSTed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	msg := new(message)
+	if err := json.Unmarshal(body, msg); err != nil {
+		writeMessage(w, http.StatusBadRequest, parseErrorResponse(err))
+		return
+	}
+	resp := s.handle(r.Context(), msg)
+	if resp == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	writeMessage(w, http.StatusOK, resp)
+}
+
+// handle answers a request; notifications and responses get no answer
+func (s *Server) handle(ctx context.Context, msg *message) *message {
+	if !msg.isRequest() {
+		return nil
+	}
+	if msg.JSONRPC != "2.0" {
+		return msg.response(nil, protocol.ErrInvalidRequest.Withf(`jsonrpc must be "2.0"`))
+	}
+	result, err := s.call(ctx, msg.Method, msg.Params)
+	return msg.response(result, err)
+}
+
+// call runs an MCP method
+func (s *Server) call(ctx context.Context, method string, params json.RawMessage) (any, *protocol.Error) {
+	switch method {
+	case "initialize":
+		var p InitializeParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return &InitializeResult{
+			ProtocolVersion: negotiateVersion(p.ProtocolVersion),
+			Capabilities:    map[string]any{"tools": map[string]any{}},
+			ServerInfo:      s.info,
+		}, nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools()
+	case "tools/call":
+		var p CallToolParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, &p)
+	default:
+		return nil, protocol.ErrMethodNotFound.Withf("%s", method)
+	}
+}
+
+// listTools lists every tool of the registry on a single page
+func (s *Server) listTools() (*ListToolsResult, *protocol.Error) {
+	result := &ListToolsResult{Tools: []Tool{}}
+	for _, tool := range s.registry.Tools() {
+		schema, err := json.Marshal(tool.Parameters())
+		if err != nil {
+			return nil, protocol.ErrInternal.Withf("schema of %s: %v", tool.Name(), err)
+		}
+		result.Tools = append(result.Tools, Tool{Name: tool.Name(), Description: tool.Description(), InputSchema: schema})
+	}
+	return result, nil
+}
+
+// callTool calls a tool of the registry. Its output is returned both as JSON text and as
+// structured content; a tool error is a result with IsError set.
+func (s *Server) callTool(ctx context.Context, params *CallToolParams) (*CallToolResult, *protocol.Error) {
+	tool, ok := s.registry.Get(params.Name)
+	if !ok {
+		return nil, protocol.ErrInvalidParams.Withf("unknown tool: %s", params.Name)
+	}
+	result, err := tool.Call(ctx, params.Arguments)
+	if err != nil {
+		return &CallToolResult{Content: []Content{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	text := result.JSON()
+	callResult := &CallToolResult{Content: []Content{{Type: "text", Text: text}}}
+	if strings.HasPrefix(text, "{") {
+		callResult.StructuredContent = json.RawMessage(text)
+	}
+	return callResult, nil
+}
+
+// decodeParams decodes the params of a request into v; absent params leave v empty
+func decodeParams(params json.RawMessage, v any) *protocol.Error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return protocol.ErrInvalidParams.Withf("%v", err)
+	}
+	return nil
+}
+
+// parseErrorResponse answers a message that is not valid JSON-RPC
+func parseErrorResponse(err error) *message {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return &message{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: protocol.ErrParse.Withf("%v", err)}
+	}
+	return &message{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: protocol.ErrInvalidRequest.Withf("%v", err)}
+}
+
+// writeMessage writes a JSON-RPC message as the body of an HTTP response
+func writeMessage(w http.ResponseWriter, status int, msg *message) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(msg)
+}
@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/aloha/a2a-go/pkg/tools"
+)
+
+// Tools returns the tools of the server adapted to tools.Tool, calling them through c
+func (c *Client) Tools(ctx context.Context) ([]tools.Tool, error) {
+	listed, err := c.ListTools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	adapted := make([]tools.Tool, 0, len(listed))
+	for _, tool := range listed {
+		var schema jsonSchema
+		if len(tool.InputSchema) > 0 {
+			if err := json.Unmarshal(tool.InputSchema, &schema); err != nil {
+				return nil, fmt.Errorf("invalid input schema of MCP tool %s: %w", tool.Name, err)
+			}
+		}
+		params := schema.toSchema()
+		params.Type = "object"
+		adapted = append(adapted, &remoteTool{client: c, tool: tool, params: params})
+	}
+	return adapted, nil
+}
+
+// remoteTool is a tool of an MCP server
+type remoteTool struct {
+	client *Client
+	tool   Tool
+	params *tools.Schema
+}
+
+func (t *remoteTool) Name() string {
+	return t.tool.Name
+}
+
+func (t *remoteTool) Description() string {
+	return t.tool.Description
+}
+
+func (t *remoteTool) Parameters() *tools.Schema {
+	return t.params
+}
+
+// Call calls the tool on the server. Its output is the structured content of the result
+// when there is some, and its text otherwise; a result with IsError set is an error.
+func (t *remoteTool) Call(ctx context.Context, args map[string]any) (tools.Result, error) {
+	result, err := t.client.CallTool(ctx, t.tool.Name, args)
+	if err != nil {
+		return tools.Result{}, fmt.Errorf("MCP tool %s: %w", t.tool.Name, err)
+	}
+	if result.IsError {
+		return tools.Result{}, errors.New(result.Text())
+	}
+	if result.StructuredContent != nil {
+		return tools.Result{Output: result.StructuredContent}, nil
+	}
+	return tools.Result{Output: tools.TextResult{Result: result.Text()}}, nil
+}
+
+// jsonSchema is a JSON schema as an MCP server describes it, of which tools.Schema keeps
+// the type, description, properties and items
+type jsonSchema struct {
+	Type        json.RawMessage        `json:"type"`
+	Description string                 `json:"description"`
+	Properties  map[string]*jsonSchema `json:"properties"`
+	Items       *jsonSchema            `json:"items"`
+	Required    []string               `json:"required"`
+}
+
+// toSchema converts the schema, listing object properties by name since JSON objects
+// carry no order. A list of types, such as ["integer", "null"], becomes its first type.
+func (s *jsonSchema) toSchema() *tools.Schema {
+	var typ string
+	if json.Unmarshal(s.Type, &typ) != nil {
+		var types []string
+		if json.Unmarshal(s.Type, &types) == nil && len(types) > 0 {
+			typ = types[0]
+		}
+	}
+
+	if typ == "object" || len(s.Properties) > 0 {
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		required := make(map[string]bool, len(s.Required))
+		for _, name := range s.Required {
+			required[name] = true
+		}
+		properties := make([]tools.Property, 0, len(names))
+		for _, name := range names {
+			property := s.Properties[name]
+			if property == nil {
+				property = &jsonSchema{}
+			}
+			if required[name] {
+				properties = append(properties, tools.Required(name, property.toSchema()))
+			} else {
+				properties = append(properties, tools.Optional(name, property.toSchema()))
+			}
+		}
+		schema := tools.Object(properties...)
+		schema.Description = s.Description
+		return schema
+	}
+
+	schema := &tools.Schema{Type: typ, Description: s.Description}
+	if s.Items != nil {
+		schema.Items = s.Items.toSchema()
+	}
+	return schema
+}
@@ -0,0 +1,167 @@
+package mdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/net/ipv4"
+)
+
+// queryInterval is how often Browse repeats its query while waiting for answers
+const queryInterval = time.Second
+
+// Browse queries the local network for agents and returns those that answered within
+// wait, ordered by instance name. Agents that announced their departure are left out.
+func Browse(ctx context.Context, wait time.Duration) ([]Entry, error) {
+	// Queries from an ephemeral port are answered to it directly (legacy unicast)
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mDNS socket: %w", err)
+	}
+	defer conn.Close()
+
+	query, err := browseQuery()
+	if err != nil {
+		return nil, err
+	}
+	ifaces := multicastInterfaces()
+	if len(ifaces) == 0 {
+		return nil, fmt.Errorf("no multicast interface available")
+	}
+	packet := ipv4.NewPacketConn(conn)
+	send := func() error {
+		sent := 0
+		for _, iface := range ifaces {
+			if packet.SetMulticastInterface(&iface) != nil {
+				continue
+			}
+			if _, err := conn.WriteToUDP(query, groupAddr); err == nil {
+				sent++
+			}
+		}
+		if sent == 0 {
+			return fmt.Errorf("failed to send mDNS query on any interface")
+		}
+		return nil
+	}
+	if err := send(); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(wait)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	records := newRecordSet()
+	nextQuery := time.Now().Add(queryInterval)
+	buf := make([]byte, 9000)
+	for time.Now().Before(deadline) && ctx.Err() == nil {
+		conn.SetReadDeadline(minTime(deadline, nextQuery))
+		n, _, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				if !time.Now().Before(nextQuery) {
+					send()
+					nextQuery = time.Now().Add(queryInterval)
+				}
+				continue
+			}
+			return nil, fmt.Errorf("failed to read mDNS answers: %w", err)
+		}
+		records.add(buf[:n])
+	}
+	return records.entries(), nil
+}
+
+// browseQuery builds a PTR query for the agents
+func browseQuery() ([]byte, error) {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{})
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := builder.Question(dnsmessage.Question{Name: serviceName, Type: dnsmessage.TypePTR, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+	return builder.Finish()
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// recordSet gathers the records of the answers received, by lower-case name
+type recordSet struct {
+	instances map[string]string // instance name to its label, from PTR records
+	gone      map[string]bool   // instances whose PTR record had a zero TTL
+	srv       map[string]dnsmessage.SRVResource
+	txt       map[string][]string
+	addrs     map[string][]net.IP
+}
+
+func newRecordSet() *recordSet {
+	return &recordSet{
+		instances: make(map[string]string),
+		gone:      make(map[string]bool),
+		srv:       make(map[string]dnsmessage.SRVResource),
+		txt:       make(map[string][]string),
+		addrs:     make(map[string][]net.IP),
+	}
+}
+
+// add records the answers and additional records of a response; other packets are ignored
+func (s *recordSet) add(packet []byte) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(packet); err != nil || !msg.Header.Response {
+		return
+	}
+	for _, rr := range append(msg.Answers, msg.Additionals...) {
+		name := strings.ToLower(rr.Header.Name.String())
+		switch body := rr.Body.(type) {
+		case *dnsmessage.PTRResource:
+			if name != strings.ToLower(serviceName.String()) {
+				continue
+			}
+			instance := strings.ToLower(body.PTR.String())
+			s.instances[instance] = strings.TrimSuffix(body.PTR.String(), "."+serviceName.String())
+			s.gone[instance] = rr.Header.TTL == 0
+		case *dnsmessage.SRVResource:
+			s.srv[name] = *body
+		case *dnsmessage.TXTResource:
+			s.txt[name] = body.TXT
+		case *dnsmessage.AResource:
+			ip := net.IP(body.A[:]).To16()
+			if !slices.ContainsFunc(s.addrs[name], ip.Equal) {
+				s.addrs[name] = append(s.addrs[name], ip)
+			}
+		}
+	}
+}
+
+// entries returns the instances with an SRV record, ordered by instance name
+func (s *recordSet) entries() []Entry {
+	var entries []Entry
+	for name, instance := range s.instances {
+		srv, ok := s.srv[name]
+		if !ok || s.gone[name] {
+			continue
+		}
+		host := strings.ToLower(srv.Target.String())
+		entries = append(entries, Entry{
+			Instance: instance,
+			Host:     strings.TrimSuffix(srv.Target.String(), "."),
+			Addrs:    s.addrs[host],
+			Port:     int(srv.Port),
+			TXT:      decodeTXT(s.txt[name]),
+		})
+	}
+	slices.SortFunc(entries, func(a, b Entry) int { return strings.Compare(a.Instance, b.Instance) })
+	return entries
+}
@@ -0,0 +1,143 @@
+// Package mdns advertises and discovers A2A agents on the local network with multicast
+// DNS (RFC 6762) and DNS-based service discovery (RFC 6763). Agents are published under
+// the _a2a._tcp service type, with the URL of their agent card in a TXT record.
+package mdns
+
+import (
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// ServiceType is the DNS-SD service type of A2A agents
+const ServiceType = "_a2a._tcp"
+
+// TXT record keys published with each agent
+const (
+	TXTCardURL = "url"     // URL serving the agent card
+	TXTVersion = "version" // agent version
+)
+
+// recordTTL is the time to live of the records, in seconds, as RFC 6762 recommends
+const recordTTL = 120
+
+var (
+	// groupAddr is the IPv4 mDNS multicast group and port
+	groupAddr = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+
+	// serviceName is the name browsed for agents
+	serviceName = dnsmessage.MustNewName(ServiceType + ".local.")
+)
+
+// Entry is an agent found on the local network
+type Entry struct {
+	// Instance is the agent's service instance name, e.g. "Dice Agent"
+	Instance string            `json:"instance"`
+	Host     string            `json:"host"`
+	Addrs    []net.IP          `json:"addrs,omitempty"`
+	Port     int               `json:"port"`
+	TXT      map[string]string `json:"txt,omitempty"`
+}
+
+// CardURL returns the URL of the agent card from the TXT record, or one built from the
+// agent's first address and port if the record has none
+func (e Entry) CardURL() string {
+	if url := e.TXT[TXTCardURL]; url != "" {
+		return url
+	}
+	host := strings.TrimSuffix(e.Host, ".")
+	if len(e.Addrs) > 0 {
+		host = e.Addrs[0].String()
+	}
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(e.Port))
+}
+
+// LocalCardURL returns an http URL for a card served on port, at the first IPv4 address
+// of the host reachable over multicast, or at its .local name if it has none
+func LocalCardURL(port int) string {
+	host := strings.TrimSuffix(hostName(), ".")
+	if ips := localIPv4(); len(ips) > 0 {
+		host = ips[0].String()
+	}
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// instanceName returns the DNS name of a service instance. Dots would split the
+// instance label, so they are replaced.
+func instanceName(instance string) (dnsmessage.Name, error) {
+	return dnsmessage.NewName(strings.ReplaceAll(instance, ".", "-") + "." + ServiceType + ".local.")
+}
+
+// hostName returns the .local host name to publish, from the system host name
+func hostName() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "a2a-agent"
+	}
+	host, _, _ = strings.Cut(host, ".")
+	return host + ".local."
+}
+
+// multicastInterfaces returns the interfaces that are up and support multicast
+func multicastInterfaces() []net.Interface {
+	all, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+	var ifaces []net.Interface
+	for _, iface := range all {
+		if iface.Flags&net.FlagUp != 0 && iface.Flags&net.FlagMulticast != 0 {
+			ifaces = append(ifaces, iface)
+		}
+	}
+	return ifaces
+}
+
+// localIPv4 returns the IPv4 addresses of the multicast interfaces
+func localIPv4() []net.IP {
+	var ips []net.IP
+	for _, iface := range multicastInterfaces() {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				if ip := ipNet.IP.To4(); ip != nil && !ip.IsLoopback() {
+					ips = append(ips, ip)
+				}
+			}
+		}
+	}
+	return ips
+}
+
+// encodeTXT encodes key=value pairs as TXT strings, in sorted key order
+func encodeTXT(txt map[string]string) []string {
+	keys := make([]string, 0, len(txt))
+	for key := range txt {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	strs := make([]string, len(keys))
+	for i, key := range keys {
+		strs[i] = key + "=" + txt[key]
+	}
+	return strs
+}
+
+// decodeTXT decodes TXT strings into key=value pairs; keys without a value map to ""
+func decodeTXT(strs []string) map[string]string {
+	txt := make(map[string]string, len(strs))
+	for _, s := range strs {
+		key, value, _ := strings.Cut(s, "=")
+		if key != "" {
+			txt[strings.ToLower(key)] = value
+		}
+	}
+	return txt
+}
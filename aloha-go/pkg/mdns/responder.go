@@ -0,0 +1,231 @@
+package mdns
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/net/ipv4"
+)
+
+// Service is an agent to advertise
+type Service struct {
+	Instance string            // service instance name, e.g. "Dice Agent"
+	Port     int               // port serving the agent card
+	TXT      map[string]string // published key=value pairs, e.g. TXTCardURL
+}
+
+// Responder answers mDNS queries for an advertised agent until it is closed
+type Responder struct {
+	conn     *net.UDPConn
+	instance dnsmessage.Name
+	host     dnsmessage.Name
+	port     uint16
+	txt      []string
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// Advertise publishes the service on every multicast interface: it announces it, then
+// answers queries for agents, the instance and its host until Close is called
+func Advertise(svc Service) (*Responder, error) {
+	instance, err := instanceName(svc.Instance)
+	if err != nil {
+		return nil, fmt.Errorf("invalid instance name %q: %w", svc.Instance, err)
+	}
+	host, err := dnsmessage.NewName(hostName())
+	if err != nil {
+		return nil, fmt.Errorf("invalid host name: %w", err)
+	}
+	if svc.Port <= 0 || svc.Port > 65535 {
+		return nil, fmt.Errorf("invalid port %d", svc.Port)
+	}
+
+	conn, err := net.ListenMulticastUDP("udp4", nil, groupAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for mDNS queries: %w", err)
+	}
+	packet := ipv4.NewPacketConn(conn)
+	joined := 0
+	for _, iface := range multicastInterfaces() {
+		// The default interface has been joined already by ListenMulticastUDP
+		if err := packet.JoinGroup(&iface, &net.UDPAddr{IP: groupAddr.IP}); err == nil || strings.Contains(err.Error(), "address already in use") {
+			joined++
+		}
+	}
+	if joined == 0 {
+		conn.Close()
+		return nil, errors.New("no multicast interface available")
+	}
+
+	r := &Responder{
+		conn:     conn,
+		instance: instance,
+		host:     host,
+		port:     uint16(svc.Port),
+		txt:      encodeTXT(svc.TXT),
+		done:     make(chan struct{}),
+	}
+	go r.serve()
+	go r.announce()
+	return r, nil
+}
+
+// Close stops answering and tells the network the agent is gone
+func (r *Responder) Close() error {
+	var err error
+	r.closeOnce.Do(func() {
+		close(r.done)
+		// A goodbye is an announcement with a zero TTL
+		if msg, buildErr := r.response(0, 0, nil, true); buildErr == nil {
+			r.conn.WriteToUDP(msg, groupAddr)
+		}
+		err = r.conn.Close()
+	})
+	return err
+}
+
+// announce sends two unsolicited responses a second apart, as RFC 6762 section 8.3 asks
+func (r *Responder) announce() {
+	for i := 0; i < 2; i++ {
+		if msg, err := r.response(0, recordTTL, nil, true); err == nil {
+			r.conn.WriteToUDP(msg, groupAddr)
+		}
+		select {
+		case <-r.done:
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// serve answers the queries received until the responder is closed
+func (r *Responder) serve() {
+	buf := make([]byte, 9000)
+	for {
+		n, src, err := r.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-r.done:
+				return
+			default:
+				continue
+			}
+		}
+		r.answer(buf[:n], src)
+	}
+}
+
+// answer replies to a query for the service, the instance or its host. Queries from a
+// port other than 5353 or asking for a unicast response are answered to the sender only.
+func (r *Responder) answer(query []byte, src *net.UDPAddr) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil || header.Response {
+		return
+	}
+	questions, err := parser.AllQuestions()
+	if err != nil {
+		return
+	}
+
+	var matched []dnsmessage.Question
+	unicast := src.Port != groupAddr.Port
+	for _, q := range questions {
+		if r.matches(q) {
+			matched = append(matched, q)
+			unicast = unicast || q.Class&(1<<15) != 0
+		}
+	}
+	if len(matched) == 0 {
+		return
+	}
+
+	// Legacy unicast replies echo the query ID and questions (RFC 6762 section 6.7)
+	var id uint16
+	var echoed []dnsmessage.Question
+	if src.Port != groupAddr.Port {
+		id, echoed = header.ID, matched
+	}
+	msg, err := r.response(id, recordTTL, echoed, false)
+	if err != nil {
+		return
+	}
+	if unicast {
+		r.conn.WriteToUDP(msg, src)
+	} else {
+		r.conn.WriteToUDP(msg, groupAddr)
+	}
+}
+
+// matches reports whether a question asks for records of the responder
+func (r *Responder) matches(q dnsmessage.Question) bool {
+	name := strings.ToLower(q.Name.String())
+	switch {
+	case name == strings.ToLower(serviceName.String()):
+		return q.Type == dnsmessage.TypePTR || q.Type == dnsmessage.TypeALL
+	case name == strings.ToLower(r.instance.String()):
+		return q.Type == dnsmessage.TypeSRV || q.Type == dnsmessage.TypeTXT || q.Type == dnsmessage.TypeALL
+	case name == strings.ToLower(r.host.String()):
+		return q.Type == dnsmessage.TypeA || q.Type == dnsmessage.TypeALL
+	}
+	return false
+}
+
+// response builds a response carrying all the records of the service. Replies answer
+// with the PTR record and add the SRV, TXT and A records; announcements answer with all.
+// A zero ttl builds a goodbye.
+func (r *Responder) response(id uint16, ttl uint32, questions []dnsmessage.Question, announce bool) ([]byte, error) {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, Response: true, Authoritative: true})
+	builder.EnableCompression()
+
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+	for _, q := range questions {
+		// The unicast-response bit is not echoed
+		q.Class &^= 1 << 15
+		if err := builder.Question(q); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := builder.StartAnswers(); err != nil {
+		return nil, err
+	}
+	if err := builder.PTRResource(dnsmessage.ResourceHeader{Name: serviceName, Class: dnsmessage.ClassINET, TTL: ttl}, dnsmessage.PTRResource{PTR: r.instance}); err != nil {
+		return nil, err
+	}
+	if !announce {
+		if err := builder.StartAdditionals(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Records unique to this agent set the cache-flush bit, except in legacy unicast replies
+	unique := dnsmessage.ClassINET
+	if len(questions) == 0 {
+		unique |= 1 << 15
+	}
+	if err := builder.SRVResource(dnsmessage.ResourceHeader{Name: r.instance, Class: unique, TTL: ttl}, dnsmessage.SRVResource{Target: r.host, Port: r.port}); err != nil {
+		return nil, err
+	}
+	if len(r.txt) > 0 {
+		if err := builder.TXTResource(dnsmessage.ResourceHeader{Name: r.instance, Class: unique, TTL: ttl}, dnsmessage.TXTResource{TXT: r.txt}); err != nil {
+			return nil, err
+		}
+	}
+	for _, ip := range localIPv4() {
+		var a dnsmessage.AResource
+		copy(a.A[:], ip)
+		if err := builder.AResource(dnsmessage.ResourceHeader{Name: r.host, Class: unique, TTL: ttl}, a); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Finish()
+}
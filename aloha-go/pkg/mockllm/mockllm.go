@@ -0,0 +1,151 @@
+// Package mockllm is a fake Ollama server for tests: it lists one model and answers
+// each chat request with a scripted assistant message, so that agents can be run
+// end to end without Ollama.
+package mockllm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ollama/ollama/api"
+)
+
+// Model is the only model the server lists
+const Model = "mock"
+
+// Handler returns the assistant message answering a chat request
+type Handler func(req *api.ChatRequest) api.Message
+
+// Server is a fake Ollama server listening on a local ephemeral port
+type Server struct {
+	server  *httptest.Server
+	handler Handler
+
+	mu       sync.Mutex
+	requests []api.ChatRequest
+}
+
+// New starts a server answering chat requests with handler
+func New(handler Handler) *Server {
+	s := &Server{handler: handler}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/tags", s.handleTags)
+	mux.HandleFunc("POST /api/chat", s.handleChat)
+	s.server = httptest.NewServer(mux)
+	return s
+}
+
+// URL returns the base URL of the server, e.g. for OLLAMA_BASE_URL
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Client returns an Ollama client for the server
+func (s *Server) Client() *api.Client {
+	u, _ := url.Parse(s.server.URL)
+	return api.NewClient(u, s.server.Client())
+}
+
+// Requests returns the chat requests received so far, in order
+func (s *Server) Requests() []api.ChatRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]api.ChatRequest(nil), s.requests...)
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// handleTags handles GET /api/tags, which clients use to check that Ollama is up
+func (s *Server) handleTags(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, api.ListResponse{Models: []api.ListModelResponse{{Name: Model, Model: Model, ModifiedAt: time.Now()}}})
+}
+
+// handleChat handles POST /api/chat with a single, final response, whether or not streaming was requested
+func (s *Server) handleChat(w http.ResponseWriter, r *http.Request) {
+	var req api.ChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		writeJSON(w, map[string]string{"error": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	s.mu.Unlock()
+
+	message := s.handler(&req)
+	if message.Role == "" {
+		message.Role = "assistant"
+	}
+	writeJSON(w, api.ChatResponse{
+		Model:      req.Model,
+		CreatedAt:  time.Now(),
+		Message:    message,
+		Done:       true,
+		DoneReason: "stop",
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// Script returns a handler answering the chat requests in turn with replies;
+// once they run out, the last reply is repeated
+func Script(replies ...api.Message) Handler {
+	var mu sync.Mutex
+	next := 0
+	return func(req *api.ChatRequest) api.Message {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(replies) == 0 {
+			return Reply("")
+		}
+		reply := replies[min(next, len(replies)-1)]
+		next++
+		return reply
+	}
+}
+
+// Reply returns an assistant message answering with text
+func Reply(text string) api.Message {
+	return api.Message{Role: "assistant", Content: text}
+}
+
+// CallTool returns an assistant message calling a tool with the given arguments
+func CallTool(name string, args map[string]any) api.Message {
+	arguments := api.NewToolCallFunctionArguments()
+	for key, value := range args {
+		arguments.Set(key, value)
+	}
+	return api.Message{
+		Role:      "assistant",
+		ToolCalls: []api.ToolCall{{Function: api.ToolCallFunction{Name: name, Arguments: arguments}}},
+	}
+}
+
+// ToolResults returns a handler that calls a tool for the first request of each
+// conversation and, once the tool has answered, replies with the tool's result.
+// call chooses the tool call from the user's message.
+func ToolResults(call func(prompt string) api.Message) Handler {
+	return func(req *api.ChatRequest) api.Message {
+		var prompt string
+		for _, message := range req.Messages {
+			switch message.Role {
+			case "user":
+				prompt = message.Content
+			case "tool":
+				return Reply(message.Content)
+			}
+		}
+		return call(prompt)
+	}
+}
@@ -0,0 +1,109 @@
+package protocol
+
+// NewMessage creates a message from role with a fresh message ID
+func NewMessage(role string, parts ...Part) Message {
+	return Message{Kind: KindMessage, MessageID: NewUUID(), Role: role, Parts: parts}
+}
+
+// NewUserMessage creates a user message with a fresh message ID
+func NewUserMessage(parts ...Part) Message {
+	return NewMessage(RoleUser, parts...)
+}
+
+// NewAgentMessage creates an agent message with a fresh message ID
+func NewAgentMessage(parts ...Part) Message {
+	return NewMessage(RoleAgent, parts...)
+}
+
+// NewUserText creates a single-text user message in a conversation; contextID may be empty
+func NewUserText(contextID, text string) Message {
+	return NewUserMessage(NewTextPart(text)).InContext(contextID)
+}
+
+// NewAgentText creates a single-text agent message in a conversation; contextID may be empty
+func NewAgentText(contextID, text string) Message {
+	return NewAgentMessage(NewTextPart(text)).InContext(contextID)
+}
+
+// InContext returns a copy of the message in a conversation; contextID may be empty
+func (m Message) InContext(contextID string) Message {
+	m.ContextID = contextID
+	return m
+}
+
+// InTask returns a copy of the message addressed to a task and its conversation
+func (m Message) InTask(taskID, contextID string) Message {
+	m.TaskID, m.ContextID = taskID, contextID
+	return m
+}
+
+// NewTaskStatus creates a status stamped with the current time, to the nanosecond so
+// that the statuses of a task keep their order
+func NewTaskStatus(state TaskState, message *Message) TaskStatus {
+	return TaskStatus{State: state, Timestamp: NowNano(), Message: message}
+}
+
+// NewStatusUpdate creates a status update event for a task. It is marked final when
+// the task ends or stops to wait for the client.
+func NewStatusUpdate(taskID, contextID string, state TaskState, message *Message) TaskStatusUpdateEvent {
+	return TaskStatusUpdateEvent{
+		Kind:      KindStatusUpdate,
+		TaskID:    taskID,
+		ContextID: contextID,
+		Status:    NewTaskStatus(state, message),
+		Final:     state.IsTerminal() || state == TaskStateInputRequired || state == TaskStateAuthRequired,
+	}
+}
+
+// TaskBuilder builds a task step by step:
+//
+//	task := protocol.NewTaskBuilder(msg.ContextID).
+//		History(msg).
+//		Status(protocol.TaskStateCompleted, &reply).
+//		Build()
+type TaskBuilder struct {
+	task Task
+}
+
+// NewTaskBuilder starts a submitted task with a fresh ID in the given conversation,
+// or in a new one if contextID is empty
+func NewTaskBuilder(contextID string) *TaskBuilder {
+	if contextID == "" {
+		contextID = NewUUID()
+	}
+	return &TaskBuilder{task: Task{
+		Kind:      KindTask,
+		ID:        NewUUID(),
+		ContextID: contextID,
+		Status:    NewTaskStatus(TaskStateSubmitted, nil),
+	}}
+}
+
+// ID sets the task ID instead of the generated one; call it before History and Status
+func (b *TaskBuilder) ID(id string) *TaskBuilder {
+	b.task.ID = id
+	return b
+}
+
+// Status sets the task's state and status message, stamped with the current time
+func (b *TaskBuilder) Status(state TaskState, message *Message) *TaskBuilder {
+	if message != nil {
+		m := message.InTask(b.task.ID, b.task.ContextID)
+		message = &m
+	}
+	b.task.Status = NewTaskStatus(state, message)
+	return b
+}
+
+// History appends messages to the task history, addressed to the task
+func (b *TaskBuilder) History(messages ...Message) *TaskBuilder {
+	for _, msg := range messages {
+		b.task.History = append(b.task.History, msg.InTask(b.task.ID, b.task.ContextID))
+	}
+	return b
+}
+
+// Build returns the task
+func (b *TaskBuilder) Build() Task {
+	return b.task
+}
@@ -0,0 +1,78 @@
+package protocol
+
+import "testing"
+
+func TestNewUserText(t *testing.T) {
+	first := NewUserText("ctx-1", "Roll a dice")
+	second := NewUserText("", "Roll a dice")
+	if first.Kind != KindMessage || first.Role != RoleUser {
+		t.Errorf("kind/role = %s/%s, want %s/%s", first.Kind, first.Role, KindMessage, RoleUser)
+	}
+	if first.MessageID == "" || first.MessageID == second.MessageID {
+		t.Errorf("message IDs %q and %q, want fresh ones", first.MessageID, second.MessageID)
+	}
+	if first.ContextID != "ctx-1" || second.ContextID != "" {
+		t.Errorf("context IDs %q and %q, want ctx-1 and none", first.ContextID, second.ContextID)
+	}
+	if len(first.Parts) != 1 || first.Parts[0].Kind != PartKindText || first.Parts[0].Text != "Roll a dice" {
+		t.Errorf("parts = %+v, want one text part", first.Parts)
+	}
+	if agent := NewAgentText("ctx-1", "4"); agent.Role != RoleAgent || agent.ContextID != "ctx-1" {
+		t.Errorf("NewAgentText role/context = %s/%s, want %s/ctx-1", agent.Role, agent.ContextID, RoleAgent)
+	}
+}
+
+func TestNewStatusUpdate(t *testing.T) {
+	tests := []struct {
+		state     TaskState
+		wantFinal bool
+	}{
+		{TaskStateSubmitted, false},
+		{TaskStateWorking, false},
+		{TaskStateInputRequired, true},
+		{TaskStateAuthRequired, true},
+		{TaskStateCompleted, true},
+		{TaskStateFailed, true},
+		{TaskStateCanceled, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.state), func(t *testing.T) {
+			event := NewStatusUpdate("task-1", "ctx-1", tt.state, nil)
+			if event.Kind != KindStatusUpdate || event.TaskID != "task-1" || event.ContextID != "ctx-1" {
+				t.Errorf("event = %+v, want a status update of task-1 in ctx-1", event)
+			}
+			if event.Final != tt.wantFinal {
+				t.Errorf("Final = %v, want %v", event.Final, tt.wantFinal)
+			}
+			if event.Status.State != tt.state || event.Status.Timestamp.IsZero() {
+				t.Errorf("status = %+v, want %s with a timestamp", event.Status, tt.state)
+			}
+		})
+	}
+}
+
+func TestTaskBuilder(t *testing.T) {
+	request := NewUserText("", "Roll a dice")
+	reply := NewAgentText("", "4")
+	task := NewTaskBuilder("").ID("task-1").History(request).Status(TaskStateCompleted, &reply).Build()
+
+	if task.Kind != KindTask || task.ID != "task-1" || task.ContextID == "" {
+		t.Fatalf("task = %+v, want task-1 in a new conversation", task)
+	}
+	if len(task.History) != 1 || task.History[0].TaskID != "task-1" || task.History[0].ContextID != task.ContextID {
+		t.Errorf("history = %+v, want the request addressed to the task", task.History)
+	}
+	if msg := task.Status.Message; msg == nil || msg.TaskID != "task-1" || msg.ContextID != task.ContextID {
+		t.Errorf("status message = %+v, want the reply addressed to the task", msg)
+	}
+	if reply.TaskID != "" {
+		t.Errorf("Status changed the caller's message: %+v", reply)
+	}
+	if task.Status.State != TaskStateCompleted {
+		t.Errorf("state = %s, want %s", task.Status.State, TaskStateCompleted)
+	}
+
+	if got := NewTaskBuilder("ctx-1").Build(); got.ContextID != "ctx-1" || got.ID == "" || got.Status.State != TaskStateSubmitted {
+		t.Errorf("new task = %+v, want a submitted task with an ID in ctx-1", got)
+	}
+}
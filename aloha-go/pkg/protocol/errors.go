@@ -0,0 +1,176 @@
+package protocol
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Error is an A2A protocol error. Its JSON-RPC code identifies the kind of error;
+// errors.Is matches any two errors with the same code, whatever their message.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// Errors defined by JSON-RPC and the A2A specification
+var (
+	ErrParse                        = &Error{Code: -32700, Message: "parse error"}
+	ErrInvalidRequest               = &Error{Code: -32600, Message: "invalid request"}
+	ErrMethodNotFound               = &Error{Code: -32601, Message: "method not found"}
+	ErrInvalidParams                = &Error{Code: -32602, Message: "invalid params"}
+	ErrInternal                     = &Error{Code: -32603, Message: "internal error"}
+	ErrTaskNotFound                 = &Error{Code: -32001, Message: "task not found"}
+	ErrTaskNotCancelable            = &Error{Code: -32002, Message: "task cannot be canceled"}
+	ErrPushNotificationNotSupported = &Error{Code: -32003, Message: "push notification not supported"}
+	ErrUnsupportedOperation         = &Error{Code: -32004, Message: "this operation is not supported"}
+	ErrContentTypeNotSupported      = &Error{Code: -32005, Message: "incompatible content types"}
+	ErrInvalidAgentResponse         = &Error{Code: -32006, Message: "invalid agent response"}
+	ErrExtendedCardNotConfigured    = &Error{Code: -32007, Message: "extended card not configured"}
+	ErrUnauthenticated              = &Error{Code: -31401, Message: "unauthenticated"}
+	ErrUnauthorized                 = &Error{Code: -31403, Message: "permission denied"}
+)
+
+// httpMapping is how the HTTP+JSON binding reports an A2A error: the HTTP status, the
+// canonical status name of Google APIs, and the reason given in the error details
+type httpMapping struct {
+	status int
+	name   string
+	reason string
+}
+
+// httpMappings maps error codes to their HTTP+JSON form
+var httpMappings = map[int]httpMapping{
+	ErrParse.Code:                        {http.StatusBadRequest, "INVALID_ARGUMENT", "PARSE_ERROR"},
+	ErrInvalidRequest.Code:               {http.StatusBadRequest, "INVALID_ARGUMENT", "INVALID_REQUEST"},
+	ErrMethodNotFound.Code:               {http.StatusNotFound, "NOT_FOUND", "METHOD_NOT_FOUND"},
+	ErrInvalidParams.Code:                {http.StatusBadRequest, "INVALID_ARGUMENT", "INVALID_PARAMS"},
+	ErrInternal.Code:                     {http.StatusInternalServerError, "INTERNAL", "INTERNAL_ERROR"},
+	ErrTaskNotFound.Code:                 {http.StatusNotFound, "NOT_FOUND", "TASK_NOT_FOUND"},
+	ErrTaskNotCancelable.Code:            {http.StatusConflict, "FAILED_PRECONDITION", "TASK_NOT_CANCELABLE"},
+	ErrPushNotificationNotSupported.Code: {http.StatusBadRequest, "UNIMPLEMENTED", "PUSH_NOTIFICATION_NOT_SUPPORTED"},
+	ErrUnsupportedOperation.Code:         {http.StatusBadRequest, "UNIMPLEMENTED", "UNSUPPORTED_OPERATION"},
+	ErrContentTypeNotSupported.Code:      {http.StatusUnsupportedMediaType, "INVALID_ARGUMENT", "CONTENT_TYPE_NOT_SUPPORTED"},
+	ErrInvalidAgentResponse.Code:         {http.StatusBadGateway, "INTERNAL", "INVALID_AGENT_RESPONSE"},
+	ErrExtendedCardNotConfigured.Code:    {http.StatusNotFound, "NOT_FOUND", "EXTENDED_AGENT_CARD_NOT_CONFIGURED"},
+	ErrUnauthenticated.Code:              {http.StatusUnauthorized, "UNAUTHENTICATED", "UNAUTHENTICATED"},
+	ErrUnauthorized.Code:                 {http.StatusForbidden, "PERMISSION_DENIED", "PERMISSION_DENIED"},
+}
+
+// statusNames maps the HTTP statuses to the canonical status names of Google APIs, for
+// errors reported with another status than their own
+var statusNames = map[int]string{
+	http.StatusBadRequest:            "INVALID_ARGUMENT",
+	http.StatusUnauthorized:          "UNAUTHENTICATED",
+	http.StatusForbidden:             "PERMISSION_DENIED",
+	http.StatusNotFound:              "NOT_FOUND",
+	http.StatusMethodNotAllowed:      "UNIMPLEMENTED",
+	http.StatusConflict:              "ABORTED",
+	http.StatusRequestEntityTooLarge: "RESOURCE_EXHAUSTED",
+	http.StatusTooManyRequests:       "RESOURCE_EXHAUSTED",
+	http.StatusInternalServerError:   "INTERNAL",
+	http.StatusNotImplemented:        "UNIMPLEMENTED",
+	http.StatusServiceUnavailable:    "UNAVAILABLE",
+	http.StatusGatewayTimeout:        "DEADLINE_EXCEEDED",
+}
+
+// Type URLs of the error details
+const (
+	ErrorInfoType  = "type.googleapis.com/google.rpc.ErrorInfo"
+	ErrorValueType = "type.googleapis.com/google.protobuf.Value"
+)
+
+// ErrorDomain is the domain of the ErrorInfo details
+const ErrorDomain = "a2a-protocol.org"
+
+// ErrorCodeMetadataKey is the ErrorInfo metadata key holding the A2A error code
+const ErrorCodeMetadataKey = "code"
+
+// HTTPError is the body of an HTTP+JSON error response, in the style of Google APIs:
+// {"error": {"code": 404, "message": ..., "status": "NOT_FOUND", "details": [...]}}
+type HTTPError struct {
+	Error HTTPErrorStatus `json:"error"`
+}
+
+// HTTPErrorStatus is the error of an HTTP+JSON error response. Its first detail is an
+// ErrorInfo whose reason names the A2A error and whose metadata holds its code; the
+// error's data, if any, follows as a Value.
+type HTTPErrorStatus struct {
+	Code    int              `json:"code"`
+	Message string           `json:"message"`
+	Status  string           `json:"status"`
+	Details []map[string]any `json:"details,omitempty"`
+}
+
+// ErrorCode returns the A2A error code held by the ErrorInfo details, 0 if there is none
+func (s HTTPErrorStatus) ErrorCode() int {
+	for _, detail := range s.Details {
+		if detail["@type"] != ErrorInfoType {
+			continue
+		}
+		metadata, _ := detail["metadata"].(map[string]any)
+		if code, ok := metadata[ErrorCodeMetadataKey].(string); ok {
+			if n, err := strconv.Atoi(code); err == nil {
+				return n
+			}
+		}
+	}
+	return 0
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is an A2A error with the same code
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// HTTPStatus returns the HTTP status for the error, 500 for codes without a mapping
+func (e *Error) HTTPStatus() int {
+	if mapping, ok := httpMappings[e.Code]; ok {
+		return mapping.status
+	}
+	return http.StatusInternalServerError
+}
+
+// HTTPError returns the body of an HTTP+JSON response reporting the error with the
+// given HTTP status
+func (e *Error) HTTPError(status int) HTTPError {
+	mapping, ok := httpMappings[e.Code]
+	if !ok {
+		mapping = httpMapping{name: "UNKNOWN", reason: "UNKNOWN"}
+	}
+	name := mapping.name
+	if status != mapping.status {
+		if name, ok = statusNames[status]; !ok {
+			name = "UNKNOWN"
+		}
+	}
+	details := []map[string]any{{
+		"@type":  ErrorInfoType,
+		"reason": mapping.reason,
+		"domain": ErrorDomain,
+		"metadata": map[string]string{
+			ErrorCodeMetadataKey: strconv.Itoa(e.Code),
+		},
+	}}
+	if e.Data != nil {
+		details = append(details, map[string]any{"@type": ErrorValueType, "value": e.Data})
+	}
+	return HTTPError{Error: HTTPErrorStatus{Code: status, Message: e.Message, Status: name, Details: details}}
+}
+
+// Withf returns a copy of the error whose message adds a detail to the standard one
+func (e *Error) Withf(format string, args ...any) *Error {
+	return &Error{Code: e.Code, Message: e.Message + ": " + fmt.Sprintf(format, args...), Data: e.Data}
+}
+
+// WithData returns a copy of the error carrying additional data
+func (e *Error) WithData(data any) *Error {
+	return &Error{Code: e.Code, Message: e.Message, Data: data}
+}
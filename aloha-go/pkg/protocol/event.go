@@ -0,0 +1,83 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownEventKind is returned by UnmarshalEvent for objects whose kind is missing
+// or is not that of an event
+var ErrUnknownEventKind = errors.New("unknown event kind")
+
+// Event is an object streamed by an agent: *Task, *Message, *TaskStatusUpdateEvent
+// or *TaskArtifactUpdateEvent
+type Event interface {
+	// EventKind returns the value of the event's kind field
+	EventKind() string
+	// Validate checks the event against the protocol
+	Validate() error
+}
+
+// Artifact is an output produced by a task
+type Artifact struct {
+	ArtifactID  string         `json:"artifactId"`
+	Description string         `json:"description,omitempty"`
+	Extensions  []string       `json:"extensions,omitempty"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+	Name        string         `json:"name,omitempty"`
+	Parts       []Part         `json:"parts"`
+}
+
+// TaskArtifactUpdateEvent carries a new artifact, or a chunk of one, produced by a task
+type TaskArtifactUpdateEvent struct {
+	Kind      string         `json:"kind"`
+	Append    bool           `json:"append,omitempty"`
+	Artifact  Artifact       `json:"artifact"`
+	ContextID string         `json:"contextId"`
+	LastChunk bool           `json:"lastChunk,omitempty"`
+	TaskID    string         `json:"taskId"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// EventKind implements Event
+func (m *Message) EventKind() string { return KindMessage }
+
+// EventKind implements Event
+func (t *Task) EventKind() string { return KindTask }
+
+// EventKind implements Event
+func (e *TaskStatusUpdateEvent) EventKind() string { return KindStatusUpdate }
+
+// EventKind implements Event
+func (e *TaskArtifactUpdateEvent) EventKind() string { return KindArtifactUpdate }
+
+// UnmarshalEvent decodes a streamed event into the concrete type selected by its kind
+func UnmarshalEvent(data []byte) (Event, error) {
+	var envelope struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	var event Event
+	switch envelope.Kind {
+	case KindMessage:
+		event = &Message{}
+	case KindTask:
+		event = &Task{}
+	case KindStatusUpdate:
+		event = &TaskStatusUpdateEvent{}
+	case KindArtifactUpdate:
+		event = &TaskArtifactUpdateEvent{}
+	case "":
+		return nil, fmt.Errorf("%w: the event has no kind", ErrUnknownEventKind)
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownEventKind, envelope.Kind)
+	}
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", envelope.Kind, err)
+	}
+	return event, nil
+}
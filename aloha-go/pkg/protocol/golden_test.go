@@ -0,0 +1,203 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// update rewrites the golden files with the a2a-go SDK's encoding:
+//
+//	go test ./pkg/protocol -update
+var update = flag.Bool("update", false, "rewrite the testdata/*.golden files")
+
+// goldenTime is the status timestamp of the fixtures, with sub-second precision
+var goldenTime = time.Date(2026, 10, 17, 8, 30, 15, 123456789, time.UTC)
+
+// goldenCase is a payload encoded by the SDK, and a pointer to the pkg/protocol type
+// that must decode and re-encode it unchanged
+type goldenCase struct {
+	name   string
+	sdk    any
+	decode any
+}
+
+func goldenCases() []goldenCase {
+	userMessage := &a2a.Message{
+		ID:             "msg-1",
+		ContextID:      "ctx-1",
+		TaskID:         "task-1",
+		Role:           a2a.MessageRoleUser,
+		Extensions:     []string{"https://example.com/ext/locale"},
+		ReferenceTasks: []a2a.TaskID{"task-0"},
+		Metadata:       map[string]any{"locale": "zh-CN", "skillId": "check-prime"},
+		Parts: a2a.ContentParts{
+			a2a.TextPart{Text: "Are 17 and 18 prime?", Metadata: map[string]any{"lang": "en"}},
+			a2a.FilePart{File: a2a.FileBytes{FileMeta: a2a.FileMeta{Name: "numbers.txt", MimeType: "text/plain"}, Bytes: "MTcKMTgK"}},
+			a2a.FilePart{File: a2a.FileURI{FileMeta: a2a.FileMeta{Name: "more.txt"}, URI: "https://example.com/more.txt"}},
+			a2a.DataPart{Data: map[string]any{"numbers": []any{"17", "18"}}},
+		},
+	}
+	agentMessage := &a2a.Message{
+		ID:        "msg-2",
+		ContextID: "ctx-1",
+		TaskID:    "task-1",
+		Role:      a2a.MessageRoleAgent,
+		Parts:     a2a.ContentParts{a2a.TextPart{Text: "17 are prime numbers."}},
+	}
+	artifact := &a2a.Artifact{
+		ID:          "artifact-1",
+		Name:        "prime_check",
+		Description: "Which numbers are prime",
+		Extensions:  []string{"https://example.com/ext/math"},
+		Metadata:    map[string]any{"tool": "check_prime"},
+		Parts: a2a.ContentParts{
+			a2a.TextPart{Text: "17 are prime numbers."},
+			a2a.DataPart{Data: map[string]any{"primes": []any{"17"}, "count": float64(1)}},
+		},
+	}
+	timestamp := goldenTime
+
+	return []goldenCase{
+		{"message", userMessage, new(Message)},
+		{"task", &a2a.Task{
+			ID:        "task-1",
+			ContextID: "ctx-1",
+			Artifacts: []*a2a.Artifact{artifact},
+			History:   []*a2a.Message{userMessage, agentMessage},
+			Metadata:  map[string]any{"caller": "alice", "attempt": float64(2)},
+			Status:    a2a.TaskStatus{State: a2a.TaskStateCompleted, Message: agentMessage, Timestamp: &timestamp},
+		}, new(Task)},
+		{"task_minimal", &a2a.Task{
+			ID:        "task-2",
+			ContextID: "ctx-2",
+			Status:    a2a.TaskStatus{State: a2a.TaskStateSubmitted},
+		}, new(Task)},
+		{"status_update", &a2a.TaskStatusUpdateEvent{
+			TaskID:    "task-1",
+			ContextID: "ctx-1",
+			Final:     true,
+			Status:    a2a.TaskStatus{State: a2a.TaskStateFailed, Message: agentMessage, Timestamp: &timestamp},
+			Metadata:  map[string]any{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		}, new(TaskStatusUpdateEvent)},
+		{"artifact_update", &a2a.TaskArtifactUpdateEvent{
+			TaskID:    "task-1",
+			ContextID: "ctx-1",
+			Artifact:  artifact,
+			Append:    true,
+			LastChunk: true,
+		}, new(TaskArtifactUpdateEvent)},
+	}
+}
+
+// TestGoldenSDKParity checks that the SDK still writes the golden files, and that
+// pkg/protocol decodes them and writes them back byte for byte
+func TestGoldenSDKParity(t *testing.T) {
+	for _, tc := range goldenCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join("testdata", tc.name+".golden")
+			sdkJSON := marshalGolden(t, tc.sdk)
+			if *update {
+				if err := os.WriteFile(path, sdkJSON, 0o644); err != nil {
+					t.Fatalf("write %s: %v", path, err)
+				}
+			}
+
+			golden, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read %s: %v (run go test -update to create it)", path, err)
+			}
+			if !bytes.Equal(sdkJSON, golden) {
+				t.Errorf("SDK encoding differs from %s:\n got: %s\nwant: %s", path, sdkJSON, golden)
+			}
+
+			if err := json.Unmarshal(golden, tc.decode); err != nil {
+				t.Fatalf("decode %s into %T: %v", path, tc.decode, err)
+			}
+			if got := marshalGolden(t, tc.decode); !bytes.Equal(got, golden) {
+				t.Errorf("%T encoding differs from %s:\n got: %s\nwant: %s", tc.decode, path, got, golden)
+			}
+		})
+	}
+}
+
+// TestGoldenUnmarshalEvent checks that UnmarshalEvent picks the event type from the kind
+func TestGoldenUnmarshalEvent(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"message", KindMessage},
+		{"task", KindTask},
+		{"status_update", KindStatusUpdate},
+		{"artifact_update", KindArtifactUpdate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", tt.file+".golden"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			event, err := UnmarshalEvent(data)
+			if err != nil {
+				t.Fatalf("UnmarshalEvent: %v", err)
+			}
+			if got := event.EventKind(); got != tt.want {
+				t.Errorf("EventKind() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGoldenToSDK checks that the payloads decoded by pkg/protocol convert to the
+// SDK's types without losing anything the SDK writes
+func TestGoldenToSDK(t *testing.T) {
+	tests := []struct {
+		file string
+		sdk  func(data []byte) (any, error)
+	}{
+		{"message", sdkOf[Message]},
+		{"task", sdkOf[Task]},
+		{"task_minimal", sdkOf[Task]},
+		{"status_update", sdkOf[TaskStatusUpdateEvent]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			golden, err := os.ReadFile(filepath.Join("testdata", tt.file+".golden"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			converted, err := tt.sdk(golden)
+			if err != nil {
+				t.Fatalf("decode %s: %v", tt.file, err)
+			}
+			if got := marshalGolden(t, converted); !bytes.Equal(got, golden) {
+				t.Errorf("%T encoding differs from the golden file:\n got: %s\nwant: %s", converted, got, golden)
+			}
+		})
+	}
+}
+
+// sdkOf decodes data into T and converts it to the SDK's type
+func sdkOf[T interface{ SDK() R }, R any](data []byte) (any, error) {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v.SDK(), nil
+}
+
+func marshalGolden(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal %T: %v", v, err)
+	}
+	return append(data, '\n')
+}
@@ -0,0 +1,171 @@
+package protocol
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Part kinds
+const (
+	PartKindText = "text"
+	PartKindFile = "file"
+	PartKindData = "data"
+)
+
+// Part represents a message or artifact part. Kind selects which content field is used:
+// Text for "text", File for "file" and Data for "data".
+type Part struct {
+	Kind     string         `json:"kind"`
+	Text     string         `json:"text,omitempty"`
+	File     *FileContent   `json:"file,omitempty"`
+	Data     map[string]any `json:"data,omitempty"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// FileContent is the file of a file part, carried either inline as Bytes
+// (base64-encoded on the wire) or by reference as a URI
+type FileContent struct {
+	MimeType string `json:"mimeType,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Bytes    []byte `json:"bytes,omitempty"`
+	URI      string `json:"uri,omitempty"`
+}
+
+// NewTextPart creates a text part
+func NewTextPart(text string) Part {
+	return Part{Kind: PartKindText, Text: text}
+}
+
+// NewFileBytesPart creates a file part with inline content
+func NewFileBytesPart(name, mimeType string, content []byte) Part {
+	return Part{Kind: PartKindFile, File: &FileContent{Name: name, MimeType: mimeType, Bytes: content}}
+}
+
+// NewFileURIPart creates a file part referring to content by URI
+func NewFileURIPart(name, mimeType, uri string) Part {
+	return Part{Kind: PartKindFile, File: &FileContent{Name: name, MimeType: mimeType, URI: uri}}
+}
+
+// NewDataPart creates a structured data part
+func NewDataPart(data map[string]any) Part {
+	return Part{Kind: PartKindData, Data: data}
+}
+
+// wirePart is the JSON form of every part kind
+type wirePart struct {
+	Kind     string          `json:"kind"`
+	Text     *string         `json:"text,omitempty"`
+	File     *wireFile       `json:"file,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	Metadata map[string]any  `json:"metadata,omitempty"`
+}
+
+// wireFile is the JSON form of FileContent; Bytes stays a base64 string as in the spec
+type wireFile struct {
+	MimeType string  `json:"mimeType,omitempty"`
+	Name     string  `json:"name,omitempty"`
+	Bytes    *string `json:"bytes,omitempty"`
+	URI      *string `json:"uri,omitempty"`
+}
+
+// MarshalJSON writes only the content field that belongs to the part's kind
+func (p Part) MarshalJSON() ([]byte, error) {
+	wire := wirePart{Kind: p.Kind, Metadata: p.Metadata}
+	switch p.Kind {
+	case PartKindText:
+		wire.Text = &p.Text
+	case PartKindFile:
+		if p.File == nil {
+			return nil, fmt.Errorf("file part has no file")
+		}
+		file, err := p.File.wire()
+		if err != nil {
+			return nil, err
+		}
+		wire.File = file
+	case PartKindData:
+		data := p.Data
+		if data == nil {
+			data = map[string]any{}
+		}
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode data part: %w", err)
+		}
+		wire.Data = raw
+	default:
+		return nil, fmt.Errorf("unknown part kind %q", p.Kind)
+	}
+	return json.Marshal(wire)
+}
+
+// UnmarshalJSON reads a part, dispatching on its kind
+func (p *Part) UnmarshalJSON(data []byte) error {
+	var wire wirePart
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	part := Part{Kind: wire.Kind, Metadata: wire.Metadata}
+	switch wire.Kind {
+	case PartKindText:
+		if wire.Text == nil {
+			return fmt.Errorf("text part has no text")
+		}
+		part.Text = *wire.Text
+	case PartKindFile:
+		if wire.File == nil {
+			return fmt.Errorf("file part has no file")
+		}
+		file, err := wire.File.content()
+		if err != nil {
+			return err
+		}
+		part.File = file
+	case PartKindData:
+		if len(wire.Data) == 0 {
+			return fmt.Errorf("data part has no data")
+		}
+		if err := json.Unmarshal(wire.Data, &part.Data); err != nil {
+			return fmt.Errorf("data part must hold a JSON object: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown part kind %q", wire.Kind)
+	}
+	*p = part
+	return nil
+}
+
+// wire converts the file to its JSON form, which holds exactly one of bytes and uri
+func (f *FileContent) wire() (*wireFile, error) {
+	if (f.Bytes == nil) == (f.URI == "") {
+		return nil, fmt.Errorf("file %q must have exactly one of bytes and uri", f.Name)
+	}
+	file := &wireFile{Name: f.Name, MimeType: f.MimeType}
+	if f.Bytes != nil {
+		encoded := base64.StdEncoding.EncodeToString(f.Bytes)
+		file.Bytes = &encoded
+	} else {
+		file.URI = &f.URI
+	}
+	return file, nil
+}
+
+// content converts a file from its JSON form
+func (f *wireFile) content() (*FileContent, error) {
+	if (f.Bytes == nil) == (f.URI == nil) {
+		return nil, fmt.Errorf("file %q must have exactly one of bytes and uri", f.Name)
+	}
+	file := &FileContent{Name: f.Name, MimeType: f.MimeType}
+	if f.URI != nil {
+		file.URI = *f.URI
+		return file, nil
+	}
+	content, err := base64.StdEncoding.DecodeString(*f.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("file %q has invalid base64 bytes: %w", f.Name, err)
+	}
+	file.Bytes = content
+	return file, nil
+}
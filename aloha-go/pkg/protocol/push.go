@@ -0,0 +1,42 @@
+package protocol
+
+// PushNotificationConfig tells an agent where to send updates of a task
+type PushNotificationConfig struct {
+	ID             string                              `json:"id,omitempty"`
+	URL            string                              `json:"url"`
+	Token          string                              `json:"token,omitempty"`
+	Authentication *PushNotificationAuthenticationInfo `json:"authentication,omitempty"`
+}
+
+// PushNotificationAuthenticationInfo describes how the agent authenticates to the webhook
+type PushNotificationAuthenticationInfo struct {
+	Schemes     []string `json:"schemes"`
+	Credentials string   `json:"credentials,omitempty"`
+}
+
+// TaskPushNotificationConfig binds a push notification config to a task
+type TaskPushNotificationConfig struct {
+	TaskID                 string                 `json:"taskId"`
+	PushNotificationConfig PushNotificationConfig `json:"pushNotificationConfig"`
+}
+
+// GetTaskPushNotificationConfigParams are the params of tasks/pushNotificationConfig/get.
+// Without a config ID the task's only (or first) config is returned.
+type GetTaskPushNotificationConfigParams struct {
+	ID                       string         `json:"id"`
+	PushNotificationConfigID string         `json:"pushNotificationConfigId,omitempty"`
+	Metadata                 map[string]any `json:"metadata,omitempty"`
+}
+
+// ListTaskPushNotificationConfigParams are the params of tasks/pushNotificationConfig/list
+type ListTaskPushNotificationConfigParams struct {
+	ID       string         `json:"id"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// DeleteTaskPushNotificationConfigParams are the params of tasks/pushNotificationConfig/delete
+type DeleteTaskPushNotificationConfigParams struct {
+	ID                       string         `json:"id"`
+	PushNotificationConfigID string         `json:"pushNotificationConfigId"`
+	Metadata                 map[string]any `json:"metadata,omitempty"`
+}
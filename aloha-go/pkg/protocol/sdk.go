@@ -0,0 +1,100 @@
+package protocol
+
+import (
+	"encoding/base64"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// The conversions below hand values built with this package to the a2a-go SDK, whose
+// types the server's executor and the client's transports take.
+
+// SDK converts the message to the a2a-go SDK's type
+func (m Message) SDK() *a2a.Message {
+	msg := &a2a.Message{
+		ID:         m.MessageID,
+		ContextID:  m.ContextID,
+		Extensions: m.Extensions,
+		Metadata:   m.Metadata,
+		Parts:      PartsSDK(m.Parts),
+		Role:       a2a.MessageRole(m.Role),
+		TaskID:     a2a.TaskID(m.TaskID),
+	}
+	for _, id := range m.ReferenceTaskIDs {
+		msg.ReferenceTasks = append(msg.ReferenceTasks, a2a.TaskID(id))
+	}
+	return msg
+}
+
+// SDK converts the part to the a2a-go SDK's type
+func (p Part) SDK() a2a.Part {
+	switch {
+	case p.Kind == PartKindFile && p.File != nil:
+		meta := a2a.FileMeta{MimeType: p.File.MimeType, Name: p.File.Name}
+		if p.File.URI != "" {
+			return a2a.FilePart{File: a2a.FileURI{FileMeta: meta, URI: p.File.URI}, Metadata: p.Metadata}
+		}
+		return a2a.FilePart{File: a2a.FileBytes{FileMeta: meta, Bytes: base64.StdEncoding.EncodeToString(p.File.Bytes)}, Metadata: p.Metadata}
+	case p.Kind == PartKindData:
+		return a2a.DataPart{Data: p.Data, Metadata: p.Metadata}
+	default:
+		return a2a.TextPart{Text: p.Text, Metadata: p.Metadata}
+	}
+}
+
+// PartsSDK converts parts to the a2a-go SDK's type
+func PartsSDK(parts []Part) a2a.ContentParts {
+	converted := make(a2a.ContentParts, 0, len(parts))
+	for _, part := range parts {
+		converted = append(converted, part.SDK())
+	}
+	return converted
+}
+
+// SDK converts the status to the a2a-go SDK's type
+func (s TaskStatus) SDK() a2a.TaskStatus {
+	status := a2a.TaskStatus{State: a2a.TaskState(s.State)}
+	if s.Message != nil {
+		status.Message = s.Message.SDK()
+	}
+	if !s.Timestamp.IsZero() {
+		timestamp := s.Timestamp.Time
+		status.Timestamp = &timestamp
+	}
+	return status
+}
+
+// SDK converts the event to the a2a-go SDK's type
+func (e TaskStatusUpdateEvent) SDK() *a2a.TaskStatusUpdateEvent {
+	return &a2a.TaskStatusUpdateEvent{
+		ContextID: e.ContextID,
+		Final:     e.Final,
+		Status:    e.Status.SDK(),
+		TaskID:    a2a.TaskID(e.TaskID),
+		Metadata:  e.Metadata,
+	}
+}
+
+// SDK converts the task to the a2a-go SDK's type
+func (t Task) SDK() *a2a.Task {
+	task := &a2a.Task{
+		ID:        a2a.TaskID(t.ID),
+		ContextID: t.ContextID,
+		Metadata:  t.Metadata,
+		Status:    t.Status.SDK(),
+	}
+	for _, artifact := range t.Artifacts {
+		task.Artifacts = append(task.Artifacts, &a2a.Artifact{
+			ID:          a2a.ArtifactID(artifact.ArtifactID),
+			Description: artifact.Description,
+			Extensions:  artifact.Extensions,
+			Metadata:    artifact.Metadata,
+			Name:        artifact.Name,
+			Parts:       PartsSDK(artifact.Parts),
+		})
+	}
+	for _, msg := range t.History {
+		task.History = append(task.History, msg.SDK())
+	}
+	return task
+}
@@ -0,0 +1,34 @@
+package protocol
+
+// MessageSendParams are the params of message/send and message/stream
+type MessageSendParams struct {
+	Configuration *MessageSendConfiguration `json:"configuration,omitempty"`
+	Message       Message                   `json:"message"`
+	Metadata      map[string]any            `json:"metadata,omitempty"`
+}
+
+// MessageSendConfiguration controls how the agent handles a sent message
+type MessageSendConfiguration struct {
+	// AcceptedOutputModes are the output media types the client can handle; empty accepts any
+	AcceptedOutputModes []string `json:"acceptedOutputModes,omitempty"`
+	// Blocking makes message/send wait for the task to end or need input; it defaults to true
+	Blocking *bool `json:"blocking,omitempty"`
+	// HistoryLength limits the task history returned to the most recent messages
+	HistoryLength *int `json:"historyLength,omitempty"`
+	// PushNotificationConfig registers a webhook for updates of the task the message creates
+	PushNotificationConfig *PushNotificationConfig `json:"pushNotificationConfig,omitempty"`
+}
+
+// IsBlocking reports whether message/send should wait for the task, as it does by default
+func (c *MessageSendConfiguration) IsBlocking() bool {
+	return c == nil || c.Blocking == nil || *c.Blocking
+}
+
+// TaskQueryParams are the params of tasks/get. On REST the history length is the
+// historyLength query parameter of GET /v1/tasks/{id}.
+type TaskQueryParams struct {
+	ID string `json:"id"`
+	// HistoryLength limits the returned history to the most recent messages; nil returns all
+	HistoryLength *int           `json:"historyLength,omitempty"`
+	Metadata      map[string]any `json:"metadata,omitempty"`
+}
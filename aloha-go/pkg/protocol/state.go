@@ -0,0 +1,90 @@
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TaskState is the lifecycle state of a task
+type TaskState string
+
+// Task state constants
+const (
+	TaskStateSubmitted     TaskState = "submitted"
+	TaskStateWorking       TaskState = "working"
+	TaskStateCompleted     TaskState = "completed"
+	TaskStateFailed        TaskState = "failed"
+	TaskStateCanceled      TaskState = "canceled"
+	TaskStateInputRequired TaskState = "input-required"
+	TaskStateRejected      TaskState = "rejected"
+	TaskStateAuthRequired  TaskState = "auth-required"
+	TaskStateUnknown       TaskState = "unknown"
+)
+
+// taskTransitions lists the states each non-terminal state may move to.
+// A task may also report its current state again, e.g. with a new status message.
+var taskTransitions = map[TaskState][]TaskState{
+	TaskStateSubmitted: {TaskStateWorking, TaskStateInputRequired, TaskStateAuthRequired,
+		TaskStateCompleted, TaskStateFailed, TaskStateCanceled, TaskStateRejected},
+	TaskStateWorking: {TaskStateInputRequired, TaskStateAuthRequired,
+		TaskStateCompleted, TaskStateFailed, TaskStateCanceled},
+	TaskStateInputRequired: {TaskStateWorking, TaskStateCompleted, TaskStateFailed, TaskStateCanceled},
+	TaskStateAuthRequired:  {TaskStateWorking, TaskStateFailed, TaskStateCanceled, TaskStateRejected},
+}
+
+// Valid reports whether the state is one defined by the protocol
+func (s TaskState) Valid() bool {
+	switch s {
+	case TaskStateSubmitted, TaskStateWorking, TaskStateInputRequired, TaskStateAuthRequired,
+		TaskStateCompleted, TaskStateCanceled, TaskStateFailed, TaskStateRejected, TaskStateUnknown:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether a task in this state can no longer change
+func (s TaskState) IsTerminal() bool {
+	return s == TaskStateCompleted || s == TaskStateCanceled || s == TaskStateFailed || s == TaskStateRejected
+}
+
+// CanTransitionTo reports whether a task may move from s to next.
+// Terminal states allow no transition; from unknown any valid state is accepted.
+func (s TaskState) CanTransitionTo(next TaskState) bool {
+	if !s.Valid() || !next.Valid() || s.IsTerminal() {
+		return false
+	}
+	if s == next || s == TaskStateUnknown {
+		return true
+	}
+	for _, allowed := range taskTransitions[s] {
+		if next == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTransition returns an error describing why a task may not move from s to next
+func (s TaskState) CheckTransition(next TaskState) error {
+	if s.CanTransitionTo(next) {
+		return nil
+	}
+	if s.IsTerminal() {
+		return fmt.Errorf("task is already %s and cannot become %s", s, next)
+	}
+	return fmt.Errorf("invalid task state transition from %s to %s", s, next)
+}
+
+// UnmarshalJSON rejects states not defined by the protocol
+func (s *TaskState) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	state := TaskState(value)
+	if !state.Valid() {
+		return fmt.Errorf("unknown task state %q", value)
+	}
+	*s = state
+	return nil
+}
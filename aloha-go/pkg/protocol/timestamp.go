@@ -0,0 +1,113 @@
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timestamp is a point in time in a protocol payload. It marshals to RFC 3339, with
+// fractional seconds when Nano is set, and unmarshals any format ParseTime accepts as
+// well as epoch numbers.
+type Timestamp struct {
+	time.Time
+	// Nano keeps the fractional seconds when marshaling; it is set when a parsed time has them
+	Nano bool
+}
+
+// Now returns the current time in UTC, marshaling to whole seconds
+func Now() Timestamp {
+	return Timestamp{Time: time.Now().UTC()}
+}
+
+// NowNano returns the current time in UTC, marshaling with nanoseconds
+func NowNano() Timestamp {
+	return Timestamp{Time: time.Now().UTC(), Nano: true}
+}
+
+// NewTimestamp wraps t, keeping its fractional seconds if it has any
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t, Nano: t.Nanosecond() != 0}
+}
+
+// String returns the timestamp as it is marshaled
+func (t Timestamp) String() string {
+	if t.Nano {
+		return t.Time.Format(time.RFC3339Nano)
+	}
+	return t.Time.Format(time.RFC3339)
+}
+
+// MarshalJSON implements json.Marshaler
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if y := t.Year(); y < 0 || y > 9999 {
+		return nil, fmt.Errorf("timestamp year %d outside of [0,9999]", y)
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Besides strings it accepts the epoch numbers
+// Java agents write by default: seconds with a fraction, or integer milliseconds.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		parsed, numErr := parseEpoch(string(data))
+		if numErr != nil {
+			return fmt.Errorf("timestamp must be a string or an epoch number, got %s", data)
+		}
+		*t = NewTimestamp(parsed)
+		return nil
+	}
+	parsed, err := ParseTime(s)
+	if err != nil {
+		return err
+	}
+	*t = NewTimestamp(parsed)
+	return nil
+}
+
+// timeLayouts are the formats ParseTime tries in order. Times without a zone are UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,                      // Go, Java Instant and OffsetDateTime
+	"2006-01-02T15:04:05.999999999",       // Python naive isoformat(), Java LocalDateTime
+	"2006-01-02 15:04:05.999999999Z07:00", // Python str(datetime)
+	"2006-01-02 15:04:05.999999999",       // Python str(datetime) without a zone
+	"2006-01-02T15:04:05.999999999Z0700",  // Java SimpleDateFormat with a +0000 offset
+}
+
+// ParseTime parses a timestamp written by any A2A implementation: RFC 3339 with or
+// without fractional seconds, the Python datetime formats with "T" or a space and
+// with or without an offset, and Java offsets without a colon. Times without a zone
+// are taken as UTC.
+func ParseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q: expected an ISO 8601 time", s)
+}
+
+// parseEpoch parses epoch seconds with a fraction, or integer epoch milliseconds
+func parseEpoch(s string) (time.Time, error) {
+	if !strings.ContainsAny(s, ".eE") {
+		millis, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(millis).UTC(), nil
+	}
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		return time.Time{}, fmt.Errorf("invalid epoch time %s", s)
+	}
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(math.Round(frac*1e6))*1e3).UTC(), nil
+}
@@ -1,18 +1,26 @@
 package protocol
 
-import (
-	"time"
+import "github.com/google/uuid"
 
-	"github.com/google/uuid"
+// Object kinds
+const (
+	KindMessage        = "message"
+	KindTask           = "task"
+	KindStatusUpdate   = "status-update"
+	KindArtifactUpdate = "artifact-update"
 )
 
-// Task state constants
+// Message roles
 const (
-	TaskStateSubmitted = "submitted"
-	TaskStateWorking   = "working"
-	TaskStateCompleted = "completed"
-	TaskStateFailed    = "failed"
-	TaskStateCanceled  = "canceled"
+	RoleUser  = "user"
+	RoleAgent = "agent"
+)
+
+// Transport protocols an agent card can declare
+const (
+	TransportJSONRPC  = "JSONRPC"
+	TransportGRPC     = "GRPC"
+	TransportHTTPJSON = "HTTP+JSON"
 )
 
 // NewUUID generates a new UUID string
@@ -20,72 +28,104 @@ func NewUUID() string {
 	return uuid.New().String()
 }
 
-// Now returns the current time in ISO8601 format
-func Now() string {
-	return time.Now().UTC().Format(time.RFC3339)
-}
+// The JSON fields of the payload types are declared in the order the a2a-go SDK
+// writes them, so payloads round-trip between the SDK and this package unchanged.
 
 // Message represents an A2A message
 type Message struct {
-	Kind      string `json:"kind"`
-	MessageID string `json:"messageId"`
-	Role      string `json:"role"`
-	Parts     []Part `json:"parts"`
-	ContextID string `json:"contextId,omitempty"`
-	TaskID    string `json:"taskId,omitempty"`
-}
-
-// Part represents a message part
-type Part struct {
-	Kind string `json:"kind"`
-	Text string `json:"text,omitempty"`
+	Kind             string         `json:"kind"`
+	MessageID        string         `json:"messageId"`
+	ContextID        string         `json:"contextId,omitempty"`
+	Extensions       []string       `json:"extensions,omitempty"`
+	Metadata         map[string]any `json:"metadata,omitempty"`
+	Parts            []Part         `json:"parts"`
+	ReferenceTaskIDs []string       `json:"referenceTaskIds,omitempty"`
+	Role             string         `json:"role"`
+	TaskID           string         `json:"taskId,omitempty"`
 }
 
 // Task represents an A2A task
 type Task struct {
-	Kind      string     `json:"kind"`
-	ID        string     `json:"id"`
-	ContextID string     `json:"contextId"`
-	Status    TaskStatus `json:"status"`
-	History   []Message  `json:"history,omitempty"`
+	Kind      string         `json:"kind"`
+	ID        string         `json:"id"`
+	Artifacts []Artifact     `json:"artifacts,omitempty"`
+	ContextID string         `json:"contextId"`
+	History   []Message      `json:"history,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	Status    TaskStatus     `json:"status"`
 }
 
 // TaskStatus represents the status of a task
 type TaskStatus struct {
-	State     string   `json:"state"`
-	Timestamp string   `json:"timestamp"`
-	Message   *Message `json:"message,omitempty"`
-}
-
-// Event represents an A2A event
-type Event struct {
-	Kind      string      `json:"kind"`
-	TaskID    string      `json:"taskId,omitempty"`
-	ContextID string      `json:"contextId,omitempty"`
-	Status    *TaskStatus `json:"status,omitempty"`
-	Final     bool        `json:"final,omitempty"`
+	Message   *Message  `json:"message,omitempty"`
+	State     TaskState `json:"state"`
+	Timestamp Timestamp `json:"timestamp,omitzero"`
 }
 
 // TaskStatusUpdateEvent represents a task status update event
 type TaskStatusUpdateEvent struct {
-	Kind      string     `json:"kind"`
-	TaskID    string     `json:"taskId"`
-	ContextID string     `json:"contextId"`
-	Status    TaskStatus `json:"status"`
-	Final     bool       `json:"final"`
+	Kind      string         `json:"kind"`
+	ContextID string         `json:"contextId"`
+	Final     bool           `json:"final"`
+	Status    TaskStatus     `json:"status"`
+	TaskID    string         `json:"taskId"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
 }
 
 // AgentCard represents an agent's capabilities
 type AgentCard struct {
-	Name               string       `json:"name"`
-	Description        string       `json:"description"`
-	URL                string       `json:"url"`
-	Version            string       `json:"version"`
-	Capabilities       Capability   `json:"capabilities"`
-	DefaultInputModes  []string     `json:"defaultInputModes"`
-	DefaultOutputModes []string     `json:"defaultOutputModes"`
-	Skills             []Skill      `json:"skills"`
-	PreferredTransport string       `json:"preferredTransport"`
+	ProtocolVersion                   string                    `json:"protocolVersion"`
+	Name                              string                    `json:"name"`
+	Description                       string                    `json:"description"`
+	URL                               string                    `json:"url"`
+	PreferredTransport                string                    `json:"preferredTransport"`
+	AdditionalInterfaces              []AgentInterface          `json:"additionalInterfaces,omitempty"`
+	Provider                          *AgentProvider            `json:"provider,omitempty"`
+	Version                           string                    `json:"version"`
+	DocumentationURL                  string                    `json:"documentationUrl,omitempty"`
+	Capabilities                      Capability                `json:"capabilities"`
+	SecuritySchemes                   map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+	Security                          []map[string][]string     `json:"security,omitempty"`
+	DefaultInputModes                 []string                  `json:"defaultInputModes"`
+	DefaultOutputModes                []string                  `json:"defaultOutputModes"`
+	Skills                            []Skill                   `json:"skills"`
+	SupportsAuthenticatedExtendedCard bool                      `json:"supportsAuthenticatedExtendedCard,omitempty"`
+}
+
+// AgentInterface is an additional URL and transport the agent is served on
+type AgentInterface struct {
+	URL       string `json:"url"`
+	Transport string `json:"transport"`
+}
+
+// AgentProvider identifies the organization offering the agent
+type AgentProvider struct {
+	Organization string `json:"organization"`
+	URL          string `json:"url"`
+}
+
+// Security scheme types
+const (
+	SecuritySchemeAPIKey        = "apiKey"
+	SecuritySchemeHTTP          = "http"
+	SecuritySchemeOAuth2        = "oauth2"
+	SecuritySchemeOpenIDConnect = "openIdConnect"
+	SecuritySchemeMutualTLS     = "mutualTLS"
+)
+
+// SecurityScheme describes how to authenticate to the agent. Type selects the
+// fields that apply, as in OpenAPI: In and Name for apiKey, Scheme and BearerFormat
+// for http, Flows for oauth2 and OpenIDConnectURL for openIdConnect.
+type SecurityScheme struct {
+	Type              string         `json:"type"`
+	Description       string         `json:"description,omitempty"`
+	In                string         `json:"in,omitempty"`
+	Name              string         `json:"name,omitempty"`
+	Scheme            string         `json:"scheme,omitempty"`
+	BearerFormat      string         `json:"bearerFormat,omitempty"`
+	Flows             map[string]any `json:"flows,omitempty"`
+	OAuth2MetadataURL string         `json:"oauth2MetadataUrl,omitempty"`
+	OpenIDConnectURL  string         `json:"openIdConnectUrl,omitempty"`
 }
 
 // Capability represents agent capabilities
@@ -102,4 +142,3 @@ type Skill struct {
 	Tags        []string `json:"tags"`
 	Examples    []string `json:"examples"`
 }
-
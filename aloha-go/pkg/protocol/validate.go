@@ -0,0 +1,229 @@
+package protocol
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ValidationError is one spec violation, located by the JSON path of the offending field
+type ValidationError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Error implements error
+func (e ValidationError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+// ValidationErrors lists every violation found in a payload
+type ValidationErrors []ValidationError
+
+// Error implements error
+func (e ValidationErrors) Error() string {
+	reasons := make([]string, len(e))
+	for i, err := range e {
+		reasons[i] = err.Error()
+	}
+	return "invalid A2A payload: " + strings.Join(reasons, "; ")
+}
+
+// validator collects violations under a field path
+type validator struct {
+	path string
+	errs *ValidationErrors
+}
+
+// newValidator starts validating the object named root
+func newValidator(root string) validator {
+	return validator{path: root, errs: &ValidationErrors{}}
+}
+
+// at descends into a field
+func (v validator) at(field string) validator {
+	return validator{path: v.path + "." + field, errs: v.errs}
+}
+
+// index descends into a list element
+func (v validator) index(field string, i int) validator {
+	return validator{path: fmt.Sprintf("%s.%s[%d]", v.path, field, i), errs: v.errs}
+}
+
+// check records a violation of field unless ok
+func (v validator) check(ok bool, field, reason string, args ...any) {
+	if !ok {
+		*v.errs = append(*v.errs, ValidationError{Field: v.path + "." + field, Reason: fmt.Sprintf(reason, args...)})
+	}
+}
+
+// required records a violation if the field is empty
+func (v validator) required(field, value string) {
+	v.check(value != "", field, "is required")
+}
+
+// oneOf records a violation if the field is not one of the allowed values
+func (v validator) oneOf(field, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.check(false, field, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+// result returns the collected violations, or nil if there are none
+func (v validator) result() error {
+	if len(*v.errs) == 0 {
+		return nil
+	}
+	return *v.errs
+}
+
+// Validate checks the message against the protocol, returning ValidationErrors if it is malformed
+func (m Message) Validate() error {
+	v := newValidator("message")
+	m.validate(v)
+	return v.result()
+}
+
+func (m Message) validate(v validator) {
+	v.oneOf("kind", m.Kind, KindMessage)
+	v.required("messageId", m.MessageID)
+	v.oneOf("role", m.Role, RoleUser, RoleAgent)
+	v.check(len(m.Parts) > 0, "parts", "must not be empty")
+	for i, part := range m.Parts {
+		part.validate(v.index("parts", i))
+	}
+}
+
+// Validate checks the part against the protocol, returning ValidationErrors if it is malformed
+func (p Part) Validate() error {
+	v := newValidator("part")
+	p.validate(v)
+	return v.result()
+}
+
+func (p Part) validate(v validator) {
+	switch p.Kind {
+	case PartKindText:
+	case PartKindFile:
+		if p.File == nil {
+			v.check(false, "file", "is required")
+			return
+		}
+		v.check((p.File.Bytes == nil) != (p.File.URI == ""), "file", "must have exactly one of bytes and uri")
+	case PartKindData:
+		v.check(p.Data != nil, "data", "is required")
+	default:
+		v.oneOf("kind", p.Kind, PartKindText, PartKindFile, PartKindData)
+	}
+}
+
+// Validate checks the task against the protocol, returning ValidationErrors if it is malformed
+func (t Task) Validate() error {
+	v := newValidator("task")
+	v.oneOf("kind", t.Kind, KindTask)
+	v.required("id", t.ID)
+	v.required("contextId", t.ContextID)
+	t.Status.validate(v.at("status"))
+	for i, artifact := range t.Artifacts {
+		artifact.validate(v.index("artifacts", i))
+	}
+	for i, msg := range t.History {
+		msg.validate(v.index("history", i))
+	}
+	return v.result()
+}
+
+func (s TaskStatus) validate(v validator) {
+	v.check(s.State.Valid(), "state", "must be a task state defined by the protocol, got %q", s.State)
+	if s.Message != nil {
+		s.Message.validate(v.at("message"))
+	}
+}
+
+// Validate checks the event against the protocol, returning ValidationErrors if it is malformed
+func (e TaskStatusUpdateEvent) Validate() error {
+	v := newValidator("event")
+	v.oneOf("kind", e.Kind, KindStatusUpdate)
+	v.required("taskId", e.TaskID)
+	v.required("contextId", e.ContextID)
+	e.Status.validate(v.at("status"))
+	return v.result()
+}
+
+// Validate checks the event against the protocol, returning ValidationErrors if it is malformed
+func (e TaskArtifactUpdateEvent) Validate() error {
+	v := newValidator("event")
+	v.oneOf("kind", e.Kind, KindArtifactUpdate)
+	v.required("taskId", e.TaskID)
+	v.required("contextId", e.ContextID)
+	e.Artifact.validate(v.at("artifact"))
+	return v.result()
+}
+
+func (a Artifact) validate(v validator) {
+	v.required("artifactId", a.ArtifactID)
+	v.check(len(a.Parts) > 0, "parts", "must not be empty")
+	for i, part := range a.Parts {
+		part.validate(v.index("parts", i))
+	}
+}
+
+// Validate checks the card against the protocol, returning ValidationErrors if it is malformed
+func (c AgentCard) Validate() error {
+	v := newValidator("agentCard")
+	v.required("protocolVersion", c.ProtocolVersion)
+	v.required("name", c.Name)
+	v.required("description", c.Description)
+	v.required("url", c.URL)
+	v.required("version", c.Version)
+	if c.PreferredTransport != "" {
+		v.oneOf("preferredTransport", c.PreferredTransport, TransportJSONRPC, TransportGRPC, TransportHTTPJSON)
+	}
+	for i, iface := range c.AdditionalInterfaces {
+		iv := v.index("additionalInterfaces", i)
+		iv.required("url", iface.URL)
+		iv.required("transport", iface.Transport)
+	}
+	if c.Provider != nil {
+		v.at("provider").required("organization", c.Provider.Organization)
+		v.at("provider").required("url", c.Provider.URL)
+	}
+	// Map keys are visited in order so the violations are reported deterministically
+	for _, name := range slices.Sorted(maps.Keys(c.SecuritySchemes)) {
+		scheme := c.SecuritySchemes[name]
+		sv := v.at("securitySchemes").at(name)
+		sv.oneOf("type", scheme.Type, SecuritySchemeAPIKey, SecuritySchemeHTTP, SecuritySchemeOAuth2, SecuritySchemeOpenIDConnect, SecuritySchemeMutualTLS)
+		switch scheme.Type {
+		case SecuritySchemeAPIKey:
+			sv.oneOf("in", scheme.In, "header", "query", "cookie")
+			sv.required("name", scheme.Name)
+		case SecuritySchemeHTTP:
+			sv.required("scheme", scheme.Scheme)
+		case SecuritySchemeOAuth2:
+			sv.check(len(scheme.Flows) > 0, "flows", "is required")
+		case SecuritySchemeOpenIDConnect:
+			sv.required("openIdConnectUrl", scheme.OpenIDConnectURL)
+		}
+	}
+	for i, requirement := range c.Security {
+		for _, name := range slices.Sorted(maps.Keys(requirement)) {
+			_, ok := c.SecuritySchemes[name]
+			v.index("security", i).check(ok, name, "is not declared in securitySchemes")
+		}
+	}
+	v.check(len(c.DefaultInputModes) > 0, "defaultInputModes", "must not be empty")
+	v.check(len(c.DefaultOutputModes) > 0, "defaultOutputModes", "must not be empty")
+	v.check(len(c.Skills) > 0, "skills", "must not be empty")
+	for i, skill := range c.Skills {
+		sv := v.index("skills", i)
+		sv.required("id", skill.ID)
+		sv.required("name", skill.Name)
+		sv.required("description", skill.Description)
+		sv.check(len(skill.Tags) > 0, "tags", "must not be empty")
+	}
+	return v.result()
+}
@@ -0,0 +1,80 @@
+package protocol
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ProtocolVersion is the A2A protocol version implemented by this module
+const ProtocolVersion = "0.3.0"
+
+// SupportedProtocolVersions are the protocol versions this module can talk, newest first
+var SupportedProtocolVersions = []string{ProtocolVersion}
+
+// VersionNegotiation is the outcome of comparing the supported versions with a peer's
+type VersionNegotiation struct {
+	// Version is the version to speak with the peer
+	Version string
+	// Warning explains a tolerated mismatch, such as a different patch release; empty if none
+	Warning string
+}
+
+// NegotiateVersion picks the version to speak with a peer that declares remote.
+// Versions with the same major and minor number are compatible: an exact match is
+// preferred, and a patch difference or an undeclared version only yields a warning.
+// It returns an error when no supported version is compatible with remote.
+func NegotiateVersion(supported []string, remote string) (VersionNegotiation, error) {
+	if len(supported) == 0 {
+		return VersionNegotiation{}, fmt.Errorf("no supported protocol versions")
+	}
+	if remote == "" {
+		return VersionNegotiation{
+			Version: supported[0],
+			Warning: fmt.Sprintf("peer does not declare a protocol version, assuming %s", supported[0]),
+		}, nil
+	}
+	remoteVersion, err := parseVersion(remote)
+	if err != nil {
+		return VersionNegotiation{}, err
+	}
+
+	var compatible string
+	for _, version := range supported {
+		if version == remote {
+			return VersionNegotiation{Version: version}, nil
+		}
+		local, err := parseVersion(version)
+		if err != nil {
+			return VersionNegotiation{}, err
+		}
+		if compatible == "" && local[0] == remoteVersion[0] && local[1] == remoteVersion[1] {
+			compatible = version
+		}
+	}
+	if compatible == "" {
+		return VersionNegotiation{}, fmt.Errorf("protocol version %s is not compatible with supported versions %s",
+			remote, strings.Join(supported, ", "))
+	}
+	return VersionNegotiation{
+		Version: compatible,
+		Warning: fmt.Sprintf("peer declares protocol version %s, speaking compatible version %s", remote, compatible),
+	}, nil
+}
+
+// parseVersion splits a major.minor[.patch] version into its numbers
+func parseVersion(version string) ([3]int, error) {
+	var parsed [3]int
+	fields := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(fields) < 2 || len(fields) > 3 {
+		return parsed, fmt.Errorf("invalid protocol version %q", version)
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parsed, fmt.Errorf("invalid protocol version %q", version)
+		}
+		parsed[i] = n
+	}
+	return parsed, nil
+}
@@ -0,0 +1,161 @@
+// Package registry talks to the agent registry service: agents register their cards with
+// it and renew them before they expire, and clients look agents up by skill tag.
+//
+// The service answers on:
+//
+//	POST   /v1/agents             register or renew {"url": ..., "card": {...}}
+//	GET    /v1/agents?tag=dice    list the live agents, optionally those with every tag
+//	DELETE /v1/agents?url=...     deregister the agent whose card is served at url
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// AgentsPath is the path of the registry's agents collection
+const AgentsPath = "/v1/agents"
+
+// Registration is an agent known to the registry
+type Registration struct {
+	// URL is where the agent card is served; it identifies the registration
+	URL          string         `json:"url"`
+	Card         *a2a.AgentCard `json:"card"`
+	RegisteredAt time.Time      `json:"registeredAt"`
+	// ExpiresAt is when the registration lapses unless the agent registers again
+	ExpiresAt time.Time `json:"expiresAt"`
+	// TTLSeconds is how long each registration or renewal lasts
+	TTLSeconds int64 `json:"ttlSeconds"`
+}
+
+// TTL returns how long each registration or renewal lasts
+func (r *Registration) TTL() time.Duration {
+	return time.Duration(r.TTLSeconds) * time.Second
+}
+
+// ListResponse is the body of GET /v1/agents
+type ListResponse struct {
+	Agents []Registration `json:"agents"`
+}
+
+// Tags returns the distinct skill tags of the card, in skill order
+func Tags(card *a2a.AgentCard) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, skill := range card.Skills {
+		for _, tag := range skill.Tags {
+			if key := strings.ToLower(tag); !seen[key] {
+				seen[key] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// HasTags reports whether some skill of the card carries each tag, ignoring case
+func HasTags(card *a2a.AgentCard, tags []string) bool {
+	have := make(map[string]bool)
+	for _, tag := range Tags(card) {
+		have[strings.ToLower(tag)] = true
+	}
+	for _, tag := range tags {
+		if !have[strings.ToLower(tag)] {
+			return false
+		}
+	}
+	return true
+}
+
+// Client calls a registry service
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewClient creates a client for the registry at baseURL, e.g. http://localhost:12100.
+// A nil httpClient uses http.DefaultClient.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), http: httpClient}
+}
+
+// Register registers the agent whose card is served at cardURL, or renews its registration
+func (c *Client) Register(ctx context.Context, cardURL string, card *a2a.AgentCard) (*Registration, error) {
+	body, err := json.Marshal(Registration{URL: cardURL, Card: card})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode registration: %w", err)
+	}
+	var registration Registration
+	if err := c.do(ctx, http.MethodPost, c.baseURL+AgentsPath, body, &registration); err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
+// Deregister removes the agent whose card is served at cardURL
+func (c *Client) Deregister(ctx context.Context, cardURL string) error {
+	return c.do(ctx, http.MethodDelete, c.baseURL+AgentsPath+"?"+url.Values{"url": {cardURL}}.Encode(), nil, nil)
+}
+
+// Find returns the live agents having a skill with each tag, or all live agents without tags
+func (c *Client) Find(ctx context.Context, tags ...string) ([]Registration, error) {
+	query := url.Values{}
+	for _, tag := range tags {
+		query.Add("tag", tag)
+	}
+	target := c.baseURL + AgentsPath
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	var resp ListResponse
+	if err := c.do(ctx, http.MethodGet, target, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Agents, nil
+}
+
+// do sends a request and decodes the JSON answer into out, if not nil.
+// Error answers are returned as *protocol.Error.
+func (c *Client) do(ctx context.Context, method, target string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("registry request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var errResp struct {
+			Error *protocol.Error `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil && errResp.Error != nil {
+			return errResp.Error
+		}
+		return fmt.Errorf("registry answered %s", resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode registry answer: %w", err)
+	}
+	return nil
+}
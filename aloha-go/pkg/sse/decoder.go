@@ -0,0 +1,143 @@
+// Package sse decodes Server-Sent Events streams as specified by the WHATWG HTML standard
+package sse
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is one dispatched Server-Sent Event
+type Event struct {
+	// ID is the last event ID seen on the stream when this event was dispatched
+	ID string
+	// Type is the event name from the "event" field; "message" when none was given
+	Type string
+	// Data is the event payload; multiple "data" lines are joined with "\n"
+	Data string
+	// Retry is the reconnection time requested by the server, or 0 if none was given
+	Retry time.Duration
+}
+
+// Decoder reads events from an SSE stream
+type Decoder struct {
+	r           *bufio.Reader
+	lastEventID string
+	skipLF      bool
+	started     bool
+}
+
+// NewDecoder creates a decoder reading from r
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// LastEventID returns the most recent event ID, for use in a Last-Event-ID reconnect header
+func (d *Decoder) LastEventID() string {
+	return d.lastEventID
+}
+
+// Next returns the next event. Comments and events without data are skipped.
+// It returns io.EOF when the stream ends; a trailing event that was not
+// terminated by a blank line is discarded, as the specification requires.
+func (d *Decoder) Next() (Event, error) {
+	var data strings.Builder
+	var eventType string
+	var retry time.Duration
+	hasData := false
+
+	for {
+		line, err := d.readLine()
+		if err != nil {
+			return Event{}, err
+		}
+
+		// A blank line dispatches the buffered event
+		if line == "" {
+			if !hasData {
+				eventType, retry = "", 0
+				continue
+			}
+			if eventType == "" {
+				eventType = "message"
+			}
+			return Event{
+				ID:    d.lastEventID,
+				Type:  eventType,
+				Data:  strings.TrimSuffix(data.String(), "\n"),
+				Retry: retry,
+			}, nil
+		}
+
+		// Lines starting with a colon are comments, often used as keep-alives
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, found := strings.Cut(line, ":")
+		if found {
+			value = strings.TrimPrefix(value, " ")
+		}
+
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				d.lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 63); err == nil {
+				retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
+
+// readLine reads one line terminated by CRLF, LF or CR, without the terminator
+func (d *Decoder) readLine() (string, error) {
+	var line []byte
+	for {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(line) > 0 {
+				// An unterminated last line cannot complete an event
+				return "", io.EOF
+			}
+			return "", err
+		}
+
+		if d.skipLF {
+			d.skipLF = false
+			if b == '\n' {
+				continue
+			}
+		}
+
+		switch b {
+		case '\r':
+			d.skipLF = true
+			return d.finishLine(line), nil
+		case '\n':
+			return d.finishLine(line), nil
+		}
+		line = append(line, b)
+	}
+}
+
+// finishLine strips the UTF-8 byte order mark from the first line of the stream
+func (d *Decoder) finishLine(line []byte) string {
+	s := string(line)
+	if !d.started {
+		d.started = true
+		s = strings.TrimPrefix(s, "\uFEFF")
+	}
+	return s
+}
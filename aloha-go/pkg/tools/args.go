@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// maxExactFloat is the largest integer a JSON number is sure to carry exactly
+const maxExactFloat = 1 << 53
+
+// ParseNumber reads an integer given as a decimal string, which is exact at any size,
+// as a JSON number, which is exact only up to 2^53, or as a Go integer
+func ParseNumber(value any) (*big.Int, error) {
+	switch v := value.(type) {
+	case string:
+		num, ok := new(big.Int).SetString(strings.TrimSpace(v), 10)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a decimal integer", v)
+		}
+		return num, nil
+	case float64:
+		if v != math.Trunc(v) {
+			return nil, fmt.Errorf("%v is not an integer", v)
+		}
+		if math.Abs(v) > maxExactFloat {
+			return nil, fmt.Errorf("%v is too large for a JSON number, pass it as a string", v)
+		}
+		return big.NewInt(int64(v)), nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	case nil:
+		return nil, fmt.Errorf("missing")
+	default:
+		return nil, fmt.Errorf("expected a string or a number, got %T", value)
+	}
+}
+
+// int64Arg reads a required integer argument
+func int64Arg(args map[string]any, name string) (int64, error) {
+	num, err := ParseNumber(args[name])
+	if err != nil {
+		return 0, invalidf("invalid '%s' parameter: %v", name, err)
+	}
+	if !num.IsInt64() {
+		return 0, invalidf("'%s' is out of range, got %s", name, num)
+	}
+	return num.Int64(), nil
+}
+
+// intArg reads a required integer argument that fits in an int
+func intArg(args map[string]any, name string) (int, error) {
+	value, err := int64Arg(args, name)
+	if err != nil {
+		return 0, err
+	}
+	if value < math.MinInt || value > math.MaxInt {
+		return 0, invalidf("'%s' is out of range, got %d", name, value)
+	}
+	return int(value), nil
+}
+
+// optionalIntArg reads an integer argument, defaulting to def when it is not given
+func optionalIntArg(args map[string]any, name string, def int) (int, error) {
+	if args[name] == nil {
+		return def, nil
+	}
+	return intArg(args, name)
+}
+
+// numberListArg reads a list of integers of any size
+func numberListArg(args map[string]any, name string) ([]*big.Int, error) {
+	var values []any
+	switch v := args[name].(type) {
+	case []any:
+		values = v
+	case []string:
+		for _, s := range v {
+			values = append(values, s)
+		}
+	case []*big.Int:
+		for _, n := range v {
+			values = append(values, n)
+		}
+	default:
+		return nil, invalidf("invalid '%s' parameter: expected a list of integers", name)
+	}
+
+	numbers := make([]*big.Int, len(values))
+	for i, value := range values {
+		num, err := ParseNumber(value)
+		if err != nil {
+			return nil, invalidf("invalid number at index %d: %v", i, err)
+		}
+		numbers[i] = num
+	}
+	return numbers, nil
+}
+
+// stringListArg reads a list of strings; other scalar values are formatted as strings
+func stringListArg(args map[string]any, name string) ([]string, error) {
+	switch v := args[name].(type) {
+	case []string:
+		return v, nil
+	case []any:
+		strs := make([]string, len(v))
+		for i, value := range v {
+			if s, ok := value.(string); ok {
+				strs[i] = s
+			} else {
+				strs[i] = fmt.Sprint(value)
+			}
+		}
+		return strs, nil
+	default:
+		return nil, invalidf("invalid '%s' parameter: expected a list of strings", name)
+	}
+}
@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		value   any
+		want    string
+		wantErr string
+	}{
+		{"17", "17", ""},
+		{" 17 ", "17", ""},
+		{"-5", "-5", ""},
+		{"170141183460469231731687303715884105727", "170141183460469231731687303715884105727", ""},
+		{float64(42), "42", ""},
+		{float64(1 << 53), "9007199254740992", ""},
+		{42, "42", ""},
+		{int64(-42), "-42", ""},
+		{big.NewInt(7), "7", ""},
+		{"17.5", "", "not a decimal integer"},
+		{"0x11", "", "not a decimal integer"},
+		{4.5, "", "not an integer"},
+		{float64(1<<53) * 2, "", "too large for a JSON number"},
+		{nil, "", "missing"},
+		{true, "", "expected a string or a number"},
+	}
+	for _, tt := range tests {
+		got, err := ParseNumber(tt.value)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseNumber(%#v) error = %v, want it to contain %q", tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseNumber(%#v): %v", tt.value, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseNumber(%#v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseNumberCopiesBigInt(t *testing.T) {
+	n := big.NewInt(7)
+	got, err := ParseNumber(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.SetInt64(8)
+	if n.Int64() != 7 {
+		t.Errorf("ParseNumber returned the *big.Int it was given, not a copy")
+	}
+}
+
+func TestIntArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		def     int
+		want    int
+		wantErr string
+	}{
+		{"number", map[string]any{"n": float64(3)}, 1, 3, ""},
+		{"string", map[string]any{"n": "3"}, 1, 3, ""},
+		{"missing uses default", map[string]any{}, 1, 1, ""},
+		{"null uses default", map[string]any{"n": nil}, 1, 1, ""},
+		{"not a number", map[string]any{"n": "three"}, 1, 0, "invalid 'n' parameter"},
+		{"out of int64", map[string]any{"n": "9223372036854775808"}, 1, 0, "'n' is out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := optionalIntArg(tt.args, "n", tt.def)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("optionalIntArg error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				if _, ok := err.(*ValidationError); !ok {
+					t.Errorf("optionalIntArg error %T is not a ValidationError", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("optionalIntArg = %d, %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestListArgs(t *testing.T) {
+	numbers, err := numberListArg(map[string]any{"n": []any{"1", float64(2), 3}}, "n")
+	if err != nil {
+		t.Fatalf("numberListArg: %v", err)
+	}
+	if len(numbers) != 3 || numbers[0].Int64() != 1 || numbers[1].Int64() != 2 || numbers[2].Int64() != 3 {
+		t.Errorf("numberListArg = %v, want [1 2 3]", numbers)
+	}
+	if _, err := numberListArg(map[string]any{"n": "1,2,3"}, "n"); err == nil {
+		t.Error("numberListArg accepted a string, want a list")
+	}
+
+	tests := []struct {
+		value   any
+		want    []string
+		wantErr bool
+	}{
+		{[]string{"a", "b"}, []string{"a", "b"}, false},
+		{[]any{"a", float64(2), true}, []string{"a", "2", "true"}, false},
+		{"a,b", nil, true},
+		{nil, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := stringListArg(map[string]any{"s": tt.value}, "s")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("stringListArg(%#v) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("stringListArg(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
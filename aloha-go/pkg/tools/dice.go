@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+)
+
+// Dice limits. The statistics histogram has one bin per side, hence its smaller limit.
+const (
+	maxSides      = 1000000
+	maxDiceCount  = 100
+	maxStatsRolls = 100000
+	maxStatsSides = 1000
+)
+
+// RollDice rolls an N-sided dice and returns the result
+func RollDice(sides int) (int, error) {
+	if sides <= 0 {
+		return 0, fmt.Errorf("dice must have at least 1 side")
+	}
+	return random.IntN(sides) + 1, nil
+}
+
+// RollDiceMulti rolls count N-sided dice, as in the XdY notation "3d6", and returns
+// the individual results and their sum
+func RollDiceMulti(count, sides int) ([]int, int, error) {
+	if count <= 0 {
+		return nil, 0, fmt.Errorf("at least 1 dice must be rolled")
+	}
+	if sides <= 0 {
+		return nil, 0, fmt.Errorf("dice must have at least 1 side")
+	}
+
+	rolls := make([]int, count)
+	sum := 0
+	for i := range rolls {
+		rolls[i] = random.IntN(sides) + 1
+		sum += rolls[i]
+	}
+	return rolls, sum, nil
+}
+
+// DiceStats summarizes the distribution of a series of dice rolls
+type DiceStats struct {
+	Count     int            `json:"count"`
+	Sides     int            `json:"sides"`
+	Sum       int            `json:"sum"`
+	Mean      float64        `json:"mean"`
+	Min       int            `json:"min"`
+	Max       int            `json:"max"`
+	Histogram []HistogramBin `json:"histogram"`
+}
+
+// HistogramBin is how many rolls showed a face
+type HistogramBin struct {
+	Face  int `json:"face"`
+	Count int `json:"count"`
+}
+
+// RollDiceStats rolls count N-sided dice and returns the distribution of the results
+func RollDiceStats(count, sides int) (DiceStats, error) {
+	if count <= 0 {
+		return DiceStats{}, fmt.Errorf("at least 1 dice must be rolled")
+	}
+	if sides <= 0 {
+		return DiceStats{}, fmt.Errorf("dice must have at least 1 side")
+	}
+
+	stats := DiceStats{Count: count, Sides: sides, Min: sides, Max: 1, Histogram: make([]HistogramBin, sides)}
+	for i := range stats.Histogram {
+		stats.Histogram[i].Face = i + 1
+	}
+	for range count {
+		roll := random.IntN(sides) + 1
+		stats.Histogram[roll-1].Count++
+		stats.Sum += roll
+		stats.Min = min(stats.Min, roll)
+		stats.Max = max(stats.Max, roll)
+	}
+	stats.Mean = float64(stats.Sum) / float64(count)
+	return stats, nil
+}
+
+// checkSides validates the number of sides of a dice
+func checkSides(sides, limit int) error {
+	if sides <= 0 {
+		return invalidf("'sides' must be positive, got %d", sides)
+	}
+	if sides > limit {
+		return invalidf("'sides' must be <= %d, got %d", limit, sides)
+	}
+	return nil
+}
+
+// checkCount validates the number of dice to roll
+func checkCount(count, limit int) error {
+	if count <= 0 || count > limit {
+		return invalidf("'count' must be between 1 and %d, got %d", limit, count)
+	}
+	return nil
+}
+
+// diceArgs reads and validates the count and sides arguments of a multi-dice tool
+func diceArgs(args map[string]any, countLimit, sidesLimit int) (count, sides int, err error) {
+	if count, err = intArg(args, "count"); err != nil {
+		return 0, 0, err
+	}
+	if sides, err = intArg(args, "sides"); err != nil {
+		return 0, 0, err
+	}
+	if err := checkCount(count, countLimit); err != nil {
+		return 0, 0, err
+	}
+	return count, sides, checkSides(sides, sidesLimit)
+}
+
+// RollDiceTool is roll_dice: rolls one N-sided dice. Its output is an IntResult.
+type RollDiceTool struct{}
+
+func (RollDiceTool) Name() string { return "roll_dice" }
+
+func (RollDiceTool) Description() string {
+	return "Rolls an N-sided dice and returns a random number between 1 and N"
+}
+
+func (RollDiceTool) Parameters() *Schema {
+	return Object(Required("sides", Integer("The number of sides on the dice (must be positive)")))
+}
+
+func (RollDiceTool) Call(ctx context.Context, args map[string]any) (Result, error) {
+	sides, err := intArg(args, "sides")
+	if err != nil {
+		return Result{}, err
+	}
+	if err := checkSides(sides, maxSides); err != nil {
+		return Result{}, err
+	}
+	result, err := RollDice(sides)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Output: IntResult{Result: int64(result)}}, nil
+}
+
+// MultiRollResult is the output of roll_dice_multi
+type MultiRollResult struct {
+	Rolls []int `json:"rolls"`
+	Sum   int   `json:"sum"`
+}
+
+// RollDiceMultiTool is roll_dice_multi: rolls several dice in XdY notation. Its output is a MultiRollResult.
+type RollDiceMultiTool struct{}
+
+func (RollDiceMultiTool) Name() string { return "roll_dice_multi" }
+
+func (RollDiceMultiTool) Description() string {
+	return "Rolls several N-sided dice, as in the XdY notation 3d6, and returns each result and their sum"
+}
+
+func (RollDiceMultiTool) Parameters() *Schema {
+	return Object(
+		Required("count", Integer("The number of dice to roll (the X in XdY)")),
+		Required("sides", Integer("The number of sides on each dice (the Y in XdY)")),
+	)
+}
+
+func (RollDiceMultiTool) Call(ctx context.Context, args map[string]any) (Result, error) {
+	count, sides, err := diceArgs(args, maxDiceCount, maxSides)
+	if err != nil {
+		return Result{}, err
+	}
+	rolls, sum, err := RollDiceMulti(count, sides)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Output: MultiRollResult{Rolls: rolls, Sum: sum}}, nil
+}
+
+// RollDiceStatsTool is roll_dice_stats: rolls many dice and returns their distribution.
+// Its output is a DiceStats, also returned as the dice-statistics data artifact.
+type RollDiceStatsTool struct{}
+
+func (RollDiceStatsTool) Name() string { return "roll_dice_stats" }
+
+func (RollDiceStatsTool) Description() string {
+	return "Rolls N dice with S sides and returns the distribution of the results: mean, min, max and a histogram"
+}
+
+func (RollDiceStatsTool) Parameters() *Schema {
+	return Object(
+		Required("count", Integer(fmt.Sprintf("The number of dice to roll (at most %d)", maxStatsRolls))),
+		Required("sides", Integer(fmt.Sprintf("The number of sides on each dice (at most %d)", maxStatsSides))),
+	)
+}
+
+func (RollDiceStatsTool) Call(ctx context.Context, args map[string]any) (Result, error) {
+	count, sides, err := diceArgs(args, maxStatsRolls, maxStatsSides)
+	if err != nil {
+		return Result{}, err
+	}
+	stats, err := RollDiceStats(count, sides)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Output: stats, Artifact: "dice-statistics"}, nil
+}
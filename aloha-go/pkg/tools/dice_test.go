@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// seedRand makes the random tools deterministic for the rest of the test
+func seedRand(t *testing.T, seed uint64) {
+	t.Helper()
+	previous := random
+	SetRand(NewSeededRand(seed))
+	t.Cleanup(func() { SetRand(previous) })
+}
+
+func TestRollDice(t *testing.T) {
+	seedRand(t, 1)
+	tests := []struct {
+		sides   int
+		wantErr bool
+	}{
+		{1, false},
+		{6, false},
+		{20, false},
+		{1000000, false},
+		{0, true},
+		{-6, true},
+	}
+	for _, tt := range tests {
+		for range 100 {
+			got, err := RollDice(tt.sides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RollDice(%d) error = %v, wantErr %v", tt.sides, err, tt.wantErr)
+			}
+			if err == nil && (got < 1 || got > tt.sides) {
+				t.Fatalf("RollDice(%d) = %d, want 1..%d", tt.sides, got, tt.sides)
+			}
+		}
+	}
+}
+
+func TestRollDiceMulti(t *testing.T) {
+	seedRand(t, 2)
+	tests := []struct {
+		count, sides int
+		wantErr      bool
+	}{
+		{1, 6, false},
+		{3, 6, false},
+		{100, 20, false},
+		{0, 6, true},
+		{3, 0, true},
+	}
+	for _, tt := range tests {
+		rolls, sum, err := RollDiceMulti(tt.count, tt.sides)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("RollDiceMulti(%d, %d) error = %v, wantErr %v", tt.count, tt.sides, err, tt.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if len(rolls) != tt.count {
+			t.Errorf("RollDiceMulti(%d, %d) rolled %d dice", tt.count, tt.sides, len(rolls))
+		}
+		total := 0
+		for _, roll := range rolls {
+			if roll < 1 || roll > tt.sides {
+				t.Errorf("RollDiceMulti(%d, %d) rolled %d", tt.count, tt.sides, roll)
+			}
+			total += roll
+		}
+		if sum != total {
+			t.Errorf("RollDiceMulti(%d, %d) sum = %d, want %d", tt.count, tt.sides, sum, total)
+		}
+	}
+}
+
+func TestRollDiceStats(t *testing.T) {
+	seedRand(t, 3)
+	tests := []struct {
+		count, sides int
+		wantErr      bool
+	}{
+		{1, 1, false},
+		{1000, 6, false},
+		{500, 20, false},
+		{0, 6, true},
+		{10, 0, true},
+	}
+	for _, tt := range tests {
+		stats, err := RollDiceStats(tt.count, tt.sides)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("RollDiceStats(%d, %d) error = %v, wantErr %v", tt.count, tt.sides, err, tt.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if len(stats.Histogram) != tt.sides {
+			t.Fatalf("RollDiceStats(%d, %d) has %d bins, want one per side", tt.count, tt.sides, len(stats.Histogram))
+		}
+		rolls, sum := 0, 0
+		for i, bin := range stats.Histogram {
+			if bin.Face != i+1 {
+				t.Errorf("bin %d is face %d", i, bin.Face)
+			}
+			rolls += bin.Count
+			sum += bin.Count * bin.Face
+		}
+		if rolls != tt.count || sum != stats.Sum {
+			t.Errorf("histogram holds %d rolls summing to %d, want %d rolls summing to %d", rolls, sum, tt.count, stats.Sum)
+		}
+		if stats.Min < 1 || stats.Max > tt.sides || stats.Min > stats.Max {
+			t.Errorf("min %d, max %d out of 1..%d", stats.Min, stats.Max, tt.sides)
+		}
+		if want := float64(stats.Sum) / float64(tt.count); stats.Mean != want {
+			t.Errorf("mean = %v, want %v", stats.Mean, want)
+		}
+	}
+}
+
+func TestDiceTools(t *testing.T) {
+	seedRand(t, 4)
+	tests := []struct {
+		name    string
+		tool    Tool
+		args    map[string]any
+		wantErr bool
+	}{
+		{"roll", RollDiceTool{}, map[string]any{"sides": float64(6)}, false},
+		{"roll string sides", RollDiceTool{}, map[string]any{"sides": "20"}, false},
+		{"roll missing sides", RollDiceTool{}, map[string]any{}, true},
+		{"roll fractional sides", RollDiceTool{}, map[string]any{"sides": 6.5}, true},
+		{"roll too many sides", RollDiceTool{}, map[string]any{"sides": maxSides + 1}, true},
+		{"multi", RollDiceMultiTool{}, map[string]any{"count": 3, "sides": 6}, false},
+		{"multi too many dice", RollDiceMultiTool{}, map[string]any{"count": maxDiceCount + 1, "sides": 6}, true},
+		{"multi no dice", RollDiceMultiTool{}, map[string]any{"count": 0, "sides": 6}, true},
+		{"stats", RollDiceStatsTool{}, map[string]any{"count": 1000, "sides": 6}, false},
+		{"stats too many sides", RollDiceStatsTool{}, map[string]any{"count": 10, "sides": maxStatsSides + 1}, true},
+		{"stats too many rolls", RollDiceStatsTool{}, map[string]any{"count": maxStatsRolls + 1, "sides": 6}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.tool.Call(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Call(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if err != nil {
+				var invalid *ValidationError
+				if !errors.As(err, &invalid) {
+					t.Errorf("Call(%v) error %T is not a ValidationError", tt.args, err)
+				}
+				return
+			}
+			if result.Output == nil {
+				t.Error("Call returned no output")
+			}
+		})
+	}
+}
+
+func TestRollDiceStatsToolArtifact(t *testing.T) {
+	result, err := RollDiceStatsTool{}.Call(context.Background(), map[string]any{"count": 10, "sides": 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Artifact != "dice-statistics" {
+		t.Errorf("Artifact = %q, want dice-statistics", result.Artifact)
+	}
+	if _, ok := result.Output.(DiceStats); !ok {
+		t.Errorf("Output is %T, want DiceStats", result.Output)
+	}
+}
+
+func TestSeededRandIsReproducible(t *testing.T) {
+	roll := func(seed uint64) []int {
+		SetRand(NewSeededRand(seed))
+		rolls, _, err := RollDiceMulti(10, 20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rolls
+	}
+	seedRand(t, 0)
+	first, second := roll(42), roll(42)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("seed 42 rolled %v, then %v", first, second)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"context"
+	"math/big"
+	"strings"
+)
+
+// Prime check limits
+const (
+	maxPrimeNumbers = 1000
+	maxPrimeDigits  = 1000
+)
+
+// CheckPrime checks which numbers in the list are prime
+func CheckPrime(numbers []int) string {
+	bigNumbers := make([]*big.Int, len(numbers))
+	for i, n := range numbers {
+		bigNumbers[i] = big.NewInt(int64(n))
+	}
+	return CheckPrimeBig(bigNumbers)
+}
+
+// CheckPrimeBig checks which numbers in the list are prime, whatever their size
+func CheckPrimeBig(numbers []*big.Int) string {
+	if len(numbers) == 0 {
+		return "No numbers provided to check."
+	}
+
+	var primes []string
+	for _, n := range numbers {
+		if IsPrime(n) {
+			primes = append(primes, n.String())
+		}
+	}
+
+	if len(primes) == 0 {
+		return "None of the numbers are prime."
+	}
+	return strings.Join(primes, ", ") + " are prime numbers."
+}
+
+// IsPrime checks if a number is prime. Numbers below 2^32 use trial division; larger ones
+// use Miller-Rabin and Baillie-PSW, which is exact below 2^64 and has no known
+// counterexample above.
+func IsPrime(n *big.Int) bool {
+	if n.Sign() <= 0 {
+		return false
+	}
+	if n.IsInt64() && n.Int64() < 1<<32 {
+		return isPrime(int(n.Int64()))
+	}
+	return n.ProbablyPrime(20)
+}
+
+// isPrime checks if a number is prime
+func isPrime(n int) bool {
+	if n <= 1 {
+		return false
+	}
+	if n == 2 {
+		return true
+	}
+	if n%2 == 0 {
+		return false
+	}
+
+	// Check odd divisors up to sqrt(n)
+	for i := 3; i*i <= n; i += 2 {
+		if n%i == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// primeArgs reads and validates the numbers of a prime check
+func primeArgs(args map[string]any) ([]*big.Int, error) {
+	numbers, err := numberListArg(args, "numbers")
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) > maxPrimeNumbers {
+		return nil, invalidf("'numbers' list too large (max %d), got %d", maxPrimeNumbers, len(numbers))
+	}
+	for _, num := range numbers {
+		if num.Sign() < 0 {
+			return nil, invalidf("All numbers must be non-negative, got %s", num)
+		}
+		if digits := len(num.String()); digits > maxPrimeDigits {
+			return nil, invalidf("numbers must have at most %d digits, got %d", maxPrimeDigits, digits)
+		}
+	}
+	return numbers, nil
+}
+
+// CheckPrimeTool is check_prime: tells which numbers of a list are prime. Its output is a
+// TextResult. Results are deterministic, so it is Cacheable.
+type CheckPrimeTool struct{}
+
+func (CheckPrimeTool) Name() string { return "check_prime" }
+
+func (CheckPrimeTool) Description() string {
+	return "Checks if the given numbers are prime and returns which ones are prime"
+}
+
+func (CheckPrimeTool) Parameters() *Schema {
+	return Object(Required("numbers", Array(String(""),
+		"List of non-negative integers to check for primality, as decimal strings so that numbers of any size stay exact")))
+}
+
+func (CheckPrimeTool) Call(ctx context.Context, args map[string]any) (Result, error) {
+	numbers, err := primeArgs(args)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Output: TextResult{Result: CheckPrimeBig(numbers)}}, nil
+}
+
+// CacheKey implements Cacheable: the canonical decimal form of the numbers, in order
+func (CheckPrimeTool) CacheKey(args map[string]any) (string, error) {
+	numbers, err := primeArgs(args)
+	if err != nil {
+		return "", err
+	}
+	key := make([]string, len(numbers))
+	for i, n := range numbers {
+		key[i] = n.String()
+	}
+	return strings.Join(key, ","), nil
+}
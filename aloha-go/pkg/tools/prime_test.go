@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestIsPrime(t *testing.T) {
+	tests := []struct {
+		n    string
+		want bool
+	}{
+		{"0", false},
+		{"1", false},
+		{"2", true},
+		{"3", true},
+		{"4", false},
+		{"17", true},
+		{"18", false},
+		{"7919", true},
+		{"7921", false},                // 89 * 89
+		{"4294967291", true},           // largest prime below 2^32
+		{"4294967297", false},          // 641 * 6700417, just above 2^32
+		{"18446744073709551557", true}, // largest prime below 2^64
+		{"170141183460469231731687303715884105727", true},  // 2^127 - 1
+		{"170141183460469231731687303715884105729", false}, // 2^127 + 1, divisible by 3
+		{"-7", false},
+	}
+	for _, tt := range tests {
+		n, _ := new(big.Int).SetString(tt.n, 10)
+		if got := IsPrime(n); got != tt.want {
+			t.Errorf("IsPrime(%s) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestCheckPrime(t *testing.T) {
+	tests := []struct {
+		numbers []int
+		want    string
+	}{
+		{[]int{17}, "17 are prime numbers."},
+		{[]int{2, 3, 4, 5}, "2, 3, 5 are prime numbers."},
+		{[]int{1, 4, 9}, "None of the numbers are prime."},
+		{nil, "No numbers provided to check."},
+	}
+	for _, tt := range tests {
+		if got := CheckPrime(tt.numbers); got != tt.want {
+			t.Errorf("CheckPrime(%v) = %q, want %q", tt.numbers, got, tt.want)
+		}
+	}
+}
+
+func TestCheckPrimeTool(t *testing.T) {
+	tooMany := make([]any, maxPrimeNumbers+1)
+	for i := range tooMany {
+		tooMany[i] = "1"
+	}
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    string
+		wantErr string
+	}{
+		{"decimal strings", map[string]any{"numbers": []any{"17", "18"}}, "17 are prime numbers.", ""},
+		{"JSON numbers", map[string]any{"numbers": []any{float64(7), float64(8)}}, "7 are prime numbers.", ""},
+		{"beyond 2^53", map[string]any{"numbers": []string{"170141183460469231731687303715884105727"}}, "170141183460469231731687303715884105727 are prime numbers.", ""},
+		{"missing", map[string]any{}, "", "expected a list of integers"},
+		{"not a number", map[string]any{"numbers": []any{"seventeen"}}, "", "invalid number at index 0"},
+		{"negative", map[string]any{"numbers": []any{"-3"}}, "", "must be non-negative"},
+		{"inexact JSON number", map[string]any{"numbers": []any{float64(1 << 60)}}, "", "pass it as a string"},
+		{"too many numbers", map[string]any{"numbers": tooMany}, "", "list too large"},
+		{"too many digits", map[string]any{"numbers": []string{"1" + strings.Repeat("0", maxPrimeDigits)}}, "", "at most 1000 digits"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CheckPrimeTool{}.Call(context.Background(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Call error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Call: %v", err)
+			}
+			if got := result.Output.(TextResult).Result; got != tt.want {
+				t.Errorf("Call = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckPrimeToolCacheKey(t *testing.T) {
+	tests := []struct {
+		a, b  []any
+		equal bool
+	}{
+		{[]any{"17", "18"}, []any{float64(17), 18}, true},
+		{[]any{"017"}, []any{" 17 "}, true},
+		{[]any{"17", "18"}, []any{"18", "17"}, false},
+		{[]any{"17"}, []any{"17", "17"}, false},
+	}
+	for _, tt := range tests {
+		keyA, err := CheckPrimeTool{}.CacheKey(map[string]any{"numbers": tt.a})
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyB, err := CheckPrimeTool{}.CacheKey(map[string]any{"numbers": tt.b})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (keyA == keyB) != tt.equal {
+			t.Errorf("CacheKey(%v) = %q, CacheKey(%v) = %q, want equal %v", tt.a, keyA, tt.b, keyB, tt.equal)
+		}
+	}
+}
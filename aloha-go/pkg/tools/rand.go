@@ -0,0 +1,72 @@
+package tools
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand/v2"
+	"sync"
+)
+
+// Rand is the random source of the dice and other random tools. Its methods are safe for concurrent use.
+type Rand struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// NewCryptoRand returns a random source reading crypto/rand
+func NewCryptoRand() *Rand {
+	return &Rand{rng: rand.New(cryptoSource{})}
+}
+
+// NewSeededRand returns a deterministic random source: the same seed always
+// yields the same results, in the order the tools are called
+func NewSeededRand(seed uint64) *Rand {
+	return &Rand{rng: rand.New(rand.NewPCG(seed, seed))}
+}
+
+// IntN returns a random number in [0, n)
+func (r *Rand) IntN(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rng.IntN(n)
+}
+
+// Uint64N returns a random number in [0, n), or any uint64 if n is 0
+func (r *Rand) Uint64N(n uint64) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n == 0 {
+		return r.rng.Uint64()
+	}
+	return r.rng.Uint64N(n)
+}
+
+// Read fills p with random bytes, so that UUIDs follow the same source as the dice
+func (r *Rand) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := 0; i < len(p); i += 8 {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], r.rng.Uint64())
+		copy(p[i:], b[:])
+	}
+	return len(p), nil
+}
+
+// cryptoSource is a rand.Source backed by crypto/rand
+type cryptoSource struct{}
+
+// Uint64 implements rand.Source
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	crand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// random is the random source used by the random tools, replaced by SetRand
+var random = NewCryptoRand()
+
+// SetRand replaces the random source of the random tools
+func SetRand(r *Rand) {
+	random = r
+}
@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Random tool limits
+const (
+	maxChoiceOptions = 1000
+	maxUUIDCount     = 100
+)
+
+// FlipCoin flips a coin and returns "heads" or "tails"
+func FlipCoin() string {
+	if random.IntN(2) == 1 {
+		return "tails"
+	}
+	return "heads"
+}
+
+// Choose picks one of the options at random
+func Choose(options []string) (string, error) {
+	if len(options) == 0 {
+		return "", fmt.Errorf("no options to choose from")
+	}
+	return options[random.IntN(len(options))], nil
+}
+
+// RandomInt returns a random integer in [min, max]
+func RandomInt(min, max int64) (int64, error) {
+	if min > max {
+		return 0, fmt.Errorf("min %d is greater than max %d", min, max)
+	}
+
+	// The span wraps to 0 for the full int64 range, which Uint64N treats as any value
+	span := uint64(max-min) + 1
+	return min + int64(random.Uint64N(span)), nil
+}
+
+// GenerateUUIDs returns count random (version 4) UUIDs
+func GenerateUUIDs(count int) ([]string, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("at least 1 UUID must be generated")
+	}
+
+	uuids := make([]string, count)
+	for i := range uuids {
+		id, err := uuid.NewRandomFromReader(random)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate UUID: %w", err)
+		}
+		uuids[i] = id.String()
+	}
+	return uuids, nil
+}
+
+// FlipCoinTool is flip_coin: answers heads or tails. Its output is a TextResult.
+type FlipCoinTool struct{}
+
+func (FlipCoinTool) Name() string { return "flip_coin" }
+
+func (FlipCoinTool) Description() string { return "Flips a coin and returns heads or tails" }
+
+func (FlipCoinTool) Parameters() *Schema { return Object() }
+
+func (FlipCoinTool) Call(ctx context.Context, args map[string]any) (Result, error) {
+	return Result{Output: TextResult{Result: FlipCoin()}}, nil
+}
+
+// ChooseTool is choose: picks one of several options. Its output is a TextResult.
+type ChooseTool struct{}
+
+func (ChooseTool) Name() string { return "choose" }
+
+func (ChooseTool) Description() string {
+	return "Picks one of the given options at random and returns it"
+}
+
+func (ChooseTool) Parameters() *Schema {
+	return Object(Required("options", Array(String(""), "The options to pick one from")))
+}
+
+func (ChooseTool) Call(ctx context.Context, args map[string]any) (Result, error) {
+	options, err := stringListArg(args, "options")
+	if err != nil {
+		return Result{}, err
+	}
+	if len(options) == 0 {
+		return Result{}, invalidf("'options' must not be empty")
+	}
+	if len(options) > maxChoiceOptions {
+		return Result{}, invalidf("'options' list too large (max %d), got %d", maxChoiceOptions, len(options))
+	}
+	choice, err := Choose(options)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Output: TextResult{Result: choice}}, nil
+}
+
+// RandomIntTool is random_int: a random integer in a range. Its output is an IntResult.
+type RandomIntTool struct{}
+
+func (RandomIntTool) Name() string { return "random_int" }
+
+func (RandomIntTool) Description() string {
+	return "Returns a random integer between min and max, inclusive"
+}
+
+func (RandomIntTool) Parameters() *Schema {
+	return Object(
+		Required("min", Integer("The smallest value that may be returned")),
+		Required("max", Integer("The largest value that may be returned")),
+	)
+}
+
+func (RandomIntTool) Call(ctx context.Context, args map[string]any) (Result, error) {
+	minValue, err := int64Arg(args, "min")
+	if err != nil {
+		return Result{}, err
+	}
+	maxValue, err := int64Arg(args, "max")
+	if err != nil {
+		return Result{}, err
+	}
+	if minValue > maxValue {
+		return Result{}, invalidf("'min' must not be greater than 'max', got %d and %d", minValue, maxValue)
+	}
+	result, err := RandomInt(minValue, maxValue)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Output: IntResult{Result: result}}, nil
+}
+
+// UUIDResult is the output of generate_uuid
+type UUIDResult struct {
+	UUIDs []string `json:"uuids"`
+}
+
+// GenerateUUIDTool is generate_uuid: random (version 4) UUIDs. Its output is a UUIDResult.
+type GenerateUUIDTool struct{}
+
+func (GenerateUUIDTool) Name() string { return "generate_uuid" }
+
+func (GenerateUUIDTool) Description() string {
+	return "Generates random (version 4) UUIDs and returns them as a list"
+}
+
+func (GenerateUUIDTool) Parameters() *Schema {
+	return Object(Optional("count", Integer(fmt.Sprintf("How many UUIDs to generate (1 to %d, default 1)", maxUUIDCount))))
+}
+
+func (GenerateUUIDTool) Call(ctx context.Context, args map[string]any) (Result, error) {
+	count, err := optionalIntArg(args, "count", 1)
+	if err != nil {
+		return Result{}, err
+	}
+	if err := checkCount(count, maxUUIDCount); err != nil {
+		return Result{}, err
+	}
+	uuids, err := GenerateUUIDs(count)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Output: UUIDResult{UUIDs: uuids}}, nil
+}
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFlipCoin(t *testing.T) {
+	seedRand(t, 5)
+	seen := map[string]int{}
+	for range 200 {
+		seen[FlipCoin()]++
+	}
+	if len(seen) != 2 || seen["heads"] == 0 || seen["tails"] == 0 {
+		t.Errorf("200 flips gave %v, want both heads and tails", seen)
+	}
+}
+
+func TestChoose(t *testing.T) {
+	seedRand(t, 6)
+	tests := []struct {
+		options []string
+		wantErr bool
+	}{
+		{[]string{"pizza"}, false},
+		{[]string{"pizza", "sushi", "tacos"}, false},
+		{nil, true},
+	}
+	for _, tt := range tests {
+		got, err := Choose(tt.options)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("Choose(%v) error = %v, wantErr %v", tt.options, err, tt.wantErr)
+		}
+		if err == nil && !slices.Contains(tt.options, got) {
+			t.Errorf("Choose(%v) = %q, not one of the options", tt.options, got)
+		}
+	}
+}
+
+func TestRandomInt(t *testing.T) {
+	seedRand(t, 7)
+	tests := []struct {
+		min, max int64
+		wantErr  bool
+	}{
+		{1, 1, false},
+		{1, 100, false},
+		{-50, 50, false},
+		{math.MinInt64, math.MaxInt64, false},
+		{math.MaxInt64 - 1, math.MaxInt64, false},
+		{10, 1, true},
+	}
+	for _, tt := range tests {
+		for range 50 {
+			got, err := RandomInt(tt.min, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RandomInt(%d, %d) error = %v, wantErr %v", tt.min, tt.max, err, tt.wantErr)
+			}
+			if err == nil && (got < tt.min || got > tt.max) {
+				t.Fatalf("RandomInt(%d, %d) = %d, out of range", tt.min, tt.max, got)
+			}
+		}
+	}
+}
+
+func TestGenerateUUIDs(t *testing.T) {
+	seedRand(t, 8)
+	tests := []struct {
+		count   int
+		wantErr bool
+	}{
+		{1, false},
+		{5, false},
+		{0, true},
+	}
+	for _, tt := range tests {
+		ids, err := GenerateUUIDs(tt.count)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("GenerateUUIDs(%d) error = %v, wantErr %v", tt.count, err, tt.wantErr)
+		}
+		if err == nil && len(ids) != tt.count {
+			t.Fatalf("GenerateUUIDs(%d) returned %d UUIDs", tt.count, len(ids))
+		}
+		seen := map[string]bool{}
+		for _, id := range ids {
+			parsed, err := uuid.Parse(id)
+			if err != nil || parsed.Version() != 4 {
+				t.Errorf("GenerateUUIDs(%d) returned %q, not a version 4 UUID", tt.count, id)
+			}
+			if seen[id] {
+				t.Errorf("GenerateUUIDs(%d) returned %q twice", tt.count, id)
+			}
+			seen[id] = true
+		}
+	}
+}
+
+func TestRandomTools(t *testing.T) {
+	seedRand(t, 9)
+	tests := []struct {
+		name    string
+		tool    Tool
+		args    map[string]any
+		wantErr bool
+	}{
+		{"flip", FlipCoinTool{}, nil, false},
+		{"choose", ChooseTool{}, map[string]any{"options": []any{"red", "green"}}, false},
+		{"choose numbers", ChooseTool{}, map[string]any{"options": []any{float64(1), float64(2)}}, false},
+		{"choose nothing", ChooseTool{}, map[string]any{"options": []any{}}, true},
+		{"choose missing", ChooseTool{}, map[string]any{}, true},
+		{"choose too many", ChooseTool{}, map[string]any{"options": make([]string, maxChoiceOptions+1)}, true},
+		{"random int", RandomIntTool{}, map[string]any{"min": float64(1), "max": float64(6)}, false},
+		{"random int beyond 2^53", RandomIntTool{}, map[string]any{"min": "9007199254740993", "max": "9007199254740995"}, false},
+		{"random int reversed", RandomIntTool{}, map[string]any{"min": 6, "max": 1}, true},
+		{"random int out of range", RandomIntTool{}, map[string]any{"min": 0, "max": "9223372036854775808"}, true},
+		{"uuid default count", GenerateUUIDTool{}, map[string]any{}, false},
+		{"uuid count", GenerateUUIDTool{}, map[string]any{"count": 3}, false},
+		{"uuid too many", GenerateUUIDTool{}, map[string]any{"count": maxUUIDCount + 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.tool.Call(context.Background(), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Call(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package tools
+
+// Schema is the subset of JSON Schema used to describe tool arguments
+type Schema struct {
+	Type        string             `json:"type"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+
+	// order keeps the properties in declaration order for PropertyNames
+	order []string
+}
+
+// Property is a named property of an object schema
+type Property struct {
+	Name     string
+	Schema   *Schema
+	Required bool
+}
+
+// Required declares a property that must be given
+func Required(name string, schema *Schema) Property {
+	return Property{Name: name, Schema: schema, Required: true}
+}
+
+// Optional declares a property that may be left out
+func Optional(name string, schema *Schema) Property {
+	return Property{Name: name, Schema: schema}
+}
+
+// Object returns an object schema with the given properties
+func Object(properties ...Property) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, p := range properties {
+		s.Properties[p.Name] = p.Schema
+		s.order = append(s.order, p.Name)
+		if p.Required {
+			s.Required = append(s.Required, p.Name)
+		}
+	}
+	return s
+}
+
+// Integer returns an integer schema
+func Integer(description string) *Schema {
+	return &Schema{Type: "integer", Description: description}
+}
+
+// String returns a string schema
+func String(description string) *Schema {
+	return &Schema{Type: "string", Description: description}
+}
+
+// Array returns an array schema whose elements follow items
+func Array(items *Schema, description string) *Schema {
+	return &Schema{Type: "array", Description: description, Items: items}
+}
+
+// PropertyNames returns the names of an object's properties in declaration order
+func (s *Schema) PropertyNames() []string {
+	return append([]string(nil), s.order...)
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestObjectSchema(t *testing.T) {
+	schema := Object(
+		Required("count", Integer("How many")),
+		Optional("label", String("A label")),
+		Required("values", Array(String(""), "The values")),
+	)
+
+	if got, want := schema.PropertyNames(), []string{"count", "label", "values"}; !slices.Equal(got, want) {
+		t.Errorf("PropertyNames() = %v, want %v", got, want)
+	}
+	if got, want := schema.Required, []string{"count", "values"}; !slices.Equal(got, want) {
+		t.Errorf("Required = %v, want %v", got, want)
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"object","properties":{"count":{"type":"integer","description":"How many"},"label":{"type":"string","description":"A label"},"values":{"type":"array","description":"The values","items":{"type":"string"}}},"required":["count","values"]}`
+	if string(data) != want {
+		t.Errorf("JSON = %s\nwant %s", data, want)
+	}
+}
+
+func TestEmptyObjectSchema(t *testing.T) {
+	data, err := json.Marshal(Object())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"type":"object"}` {
+		t.Errorf("JSON = %s, want an object without properties", data)
+	}
+}
+
+// TestBuiltinSchemas checks that every builtin tool declares an object schema whose
+// required arguments are among its properties
+func TestBuiltinSchemas(t *testing.T) {
+	for _, tool := range Builtin() {
+		t.Run(tool.Name(), func(t *testing.T) {
+			schema := tool.Parameters()
+			if schema.Type != "object" {
+				t.Fatalf("Parameters().Type = %q, want object", schema.Type)
+			}
+			if tool.Description() == "" {
+				t.Error("Description() is empty")
+			}
+			for _, name := range schema.Required {
+				if schema.Properties[name] == nil {
+					t.Errorf("required argument %q is not a property", name)
+				}
+			}
+			for _, name := range schema.PropertyNames() {
+				if property := schema.Properties[name]; property.Type == "array" && property.Items == nil {
+					t.Errorf("array argument %q has no items schema", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Package tools implements the tools of the dice agent: dice, primes, coin flips and other
+// random utilities. Each tool describes its arguments with a JSON schema, so that it can be
+// offered to an LLM for function calling, and can also be called directly.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Tool is a function the agent can call with JSON arguments
+type Tool interface {
+	// Name identifies the tool in function calls, e.g. "roll_dice"
+	Name() string
+	// Description tells the model what the tool does
+	Description() string
+	// Parameters is the JSON schema of the arguments, always an object
+	Parameters() *Schema
+	// Call runs the tool. Arguments are JSON values as decoded into a map, though Go
+	// integers and *big.Int are accepted wherever a JSON number is.
+	Call(ctx context.Context, args map[string]any) (Result, error)
+}
+
+// Cacheable is implemented by deterministic tools whose results may be reused
+type Cacheable interface {
+	// CacheKey returns a key that is equal for all arguments giving the same result
+	CacheKey(args map[string]any) (string, error)
+}
+
+// Result is the outcome of a tool call
+type Result struct {
+	// Output is the result value, given to the model as JSON
+	Output any
+	// Artifact names a data artifact that returns Output to the client as structured
+	// data; empty if the result is only text
+	Artifact string
+}
+
+// JSON returns the output as given to the model
+func (r Result) JSON() string {
+	data, err := json.Marshal(r.Output)
+	if err != nil {
+		return fmt.Sprintf(`{"error": %q}`, err.Error())
+	}
+	return string(data)
+}
+
+// TextResult is the output of tools whose result is a sentence or a single word
+type TextResult struct {
+	Result string `json:"result"`
+}
+
+// IntResult is the output of tools whose result is a single integer
+type IntResult struct {
+	Result int64 `json:"result"`
+}
+
+// ValidationError reports tool arguments that are out of range or malformed
+type ValidationError struct {
+	Message string
+}
+
+// Error implements error
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// invalidf returns a ValidationError with a formatted message
+func invalidf(format string, args ...any) error {
+	return &ValidationError{Message: fmt.Sprintf(format, args...)}
+}
+
+// Registry holds tools by name, in the order they were registered
+type Registry struct {
+	tools  []Tool
+	byName map[string]Tool
+}
+
+// NewRegistry creates a registry holding the given tools; it panics on duplicate names
+func NewRegistry(tools ...Tool) *Registry {
+	r := &Registry{byName: make(map[string]Tool)}
+	for _, tool := range tools {
+		if err := r.Register(tool); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register adds a tool, failing if another tool has the same name
+func (r *Registry) Register(tool Tool) error {
+	if _, exists := r.byName[tool.Name()]; exists {
+		return fmt.Errorf("tool %s is already registered", tool.Name())
+	}
+	r.tools = append(r.tools, tool)
+	r.byName[tool.Name()] = tool
+	return nil
+}
+
+// Get returns the tool with the given name
+func (r *Registry) Get(name string) (Tool, bool) {
+	tool, ok := r.byName[name]
+	return tool, ok
+}
+
+// Tools returns the registered tools in registration order
+func (r *Registry) Tools() []Tool {
+	return append([]Tool(nil), r.tools...)
+}
+
+// Builtin returns all the tools of this package
+func Builtin() []Tool {
+	return []Tool{
+		RollDiceTool{},
+		RollDiceMultiTool{},
+		RollDiceStatsTool{},
+		CheckPrimeTool{},
+		FlipCoinTool{},
+		ChooseTool{},
+		RandomIntTool{},
+		GenerateUUIDTool{},
+	}
+}
@@ -0,0 +1,55 @@
+package tools
+
+import "testing"
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(RollDiceTool{}, CheckPrimeTool{})
+
+	if err := registry.Register(CheckPrimeTool{}); err == nil {
+		t.Error("registering check_prime twice succeeded, want an error")
+	}
+	if err := registry.Register(FlipCoinTool{}); err != nil {
+		t.Fatalf("Register(flip_coin): %v", err)
+	}
+
+	var names []string
+	for _, tool := range registry.Tools() {
+		names = append(names, tool.Name())
+	}
+	if got, want := len(names), 3; got != want || names[0] != "roll_dice" || names[2] != "flip_coin" {
+		t.Errorf("Tools() = %v, want roll_dice, check_prime and flip_coin in order", names)
+	}
+
+	if _, ok := registry.Get("check_prime"); !ok {
+		t.Error("Get(check_prime) found nothing")
+	}
+	if _, ok := registry.Get("roll_dice_multi"); ok {
+		t.Error("Get(roll_dice_multi) found an unregistered tool")
+	}
+}
+
+func TestNewRegistryPanicsOnDuplicates(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewRegistry with duplicate names did not panic")
+		}
+	}()
+	NewRegistry(RollDiceTool{}, RollDiceTool{})
+}
+
+func TestResultJSON(t *testing.T) {
+	tests := []struct {
+		output any
+		want   string
+	}{
+		{IntResult{Result: 4}, `{"result":4}`},
+		{TextResult{Result: "heads"}, `{"result":"heads"}`},
+		{MultiRollResult{Rolls: []int{1, 2}, Sum: 3}, `{"rolls":[1,2],"sum":3}`},
+		{func() {}, `{"error": "json: unsupported type: func()"}`},
+	}
+	for _, tt := range tests {
+		if got := (Result{Output: tt.output}).JSON(); got != tt.want {
+			t.Errorf("JSON() = %s, want %s", got, tt.want)
+		}
+	}
+}
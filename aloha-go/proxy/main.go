@@ -0,0 +1,113 @@
+// Command proxy is a2a-proxy, a recording and replay proxy for debugging A2A traffic.
+// Between a client and an agent, it records every JSON-RPC, REST and SSE exchange to a
+// JSON Lines file. Given a recording, it stands in for the agent and replays its responses.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// agentCardPath is where agents serve their card
+const agentCardPath = "/.well-known/agent-card.json"
+
+func main() {
+	host := flag.String("host", "0.0.0.0", "Address to listen on")
+	port := flag.Int("port", 12200, "Port to listen on")
+	target := flag.String("target", "http://localhost:12001", "URL of the agent's JSON-RPC or REST endpoint to record")
+	record := flag.String("record", "", "Forward requests to --target and append each exchange to this JSON Lines file")
+	replay := flag.String("replay", "", "Answer requests with the responses recorded in this file, without an agent")
+	speed := flag.Float64("replay-speed", 1, "Replay speed factor: 2 replays twice as fast, 0 without delays")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: a2a-proxy --record FILE [--target URL] | --replay FILE [--replay-speed N]\n\nOptions:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  a2a-proxy --target http://localhost:12001 --record session.jsonl\n")
+		fmt.Fprintf(os.Stderr, "  a2a-proxy --replay session.jsonl --replay-speed 0\n")
+	}
+	flag.Parse()
+
+	var handler http.Handler
+	switch {
+	case (*record == "") == (*replay == ""):
+		flag.Usage()
+		os.Exit(2)
+	case *record != "":
+		targetURL, err := url.Parse(*target)
+		if err != nil || (targetURL.Scheme != "http" && targetURL.Scheme != "https") || targetURL.Host == "" {
+			log.Fatalf("proxy.main - ERROR - Invalid --target %q: must be the http(s) URL of the agent", *target)
+		}
+		rec, err := newRecorder(*record)
+		if err != nil {
+			log.Fatalf("proxy.main - ERROR - Failed to open recording: %v", err)
+		}
+		defer rec.Close()
+		handler = recordingProxy(targetURL, rec)
+		logf("INFO", "Recording traffic to %s in %s", *target, *record)
+	default:
+		exchanges, err := loadRecording(*replay)
+		if err != nil {
+			log.Fatalf("proxy.main - ERROR - Failed to load recording: %v", err)
+		}
+		handler = newReplayer(exchanges, *speed)
+		logf("INFO", "Replaying %d exchanges from %s", len(exchanges), *replay)
+	}
+
+	server := &http.Server{Addr: fmt.Sprintf("%s:%d", *host, *port), Handler: handler}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		logf("INFO", "Shutdown signal received, stopping proxy...")
+		server.Shutdown(context.Background())
+	}()
+
+	logf("INFO", "Proxy listening on http://%s:%d", *host, *port)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("proxy.main - ERROR - Server error: %v", err)
+	}
+	logf("INFO", "Proxy stopped")
+}
+
+// logExchange logs a completed exchange on one line
+func logExchange(e *exchange, prefix string) {
+	what := e.Method + " " + e.Path
+	if e.RPCMethod != "" {
+		what += " " + e.RPCMethod
+	}
+	switch {
+	case e.Error != "":
+		logf("WARN", "#%d %s%s failed after %.1fms: %s", e.Seq, prefix, what, e.DurationMs, e.Error)
+	case len(e.Events) > 0:
+		logf("INFO", "#%d %s%s -> %d, %d events in %.1fms", e.Seq, prefix, what, e.Status, len(e.Events), e.DurationMs)
+	default:
+		logf("INFO", "#%d %s%s -> %d in %.1fms", e.Seq, prefix, what, e.Status, e.DurationMs)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a protocol error as {"error": {...}}, with the A2A error code
+func writeError(w http.ResponseWriter, status int, err *protocol.Error) {
+	writeJSON(w, status, map[string]*protocol.Error{"error": err})
+}
+
+// logf logs in the agents' "name - LEVEL - message" format
+func logf(level, format string, args ...any) {
+	log.Printf("proxy.main - %s - %s", level, fmt.Sprintf(format, args...))
+}
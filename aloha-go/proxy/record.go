@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// proxiedKey is the context key of the exchange being recorded for a request
+type proxiedKey struct{}
+
+// proxied is an exchange being recorded, with the origin the client reached the proxy at
+type proxied struct {
+	exchange *exchange
+	origin   string
+}
+
+// recordingProxy forwards every request to the agent at target, streaming responses
+// through as they arrive, and records each exchange once its response has been read
+func recordingProxy(target *url.URL, rec *recorder) http.Handler {
+	targetOrigin := target.Scheme + "://" + target.Host
+	finish := func(e *exchange) {
+		e.DurationMs = float64(time.Since(e.Time).Microseconds()) / 1000
+		rec.write(e)
+		logExchange(e, "")
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+			pr.SetXForwarded()
+			// Left to the transport, responses are decompressed before being recorded
+			pr.Out.Header.Del("Accept-Encoding")
+		},
+		// Streamed events reach the client as soon as the agent sends them
+		FlushInterval: -1,
+		ModifyResponse: func(resp *http.Response) error {
+			p := resp.Request.Context().Value(proxiedKey{}).(*proxied)
+			e := p.exchange
+			e.Status = resp.StatusCode
+			e.ResponseHeaders = redact(resp.Header)
+
+			if isEventStream(resp.Header) {
+				resp.Body = &eventRecorder{body: resp.Body, exchange: e, finish: finish}
+				return nil
+			}
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return err
+			}
+			e.setResponseBody(body)
+			finish(e)
+
+			if resp.Request.URL.Path == agentCardPath && resp.StatusCode == http.StatusOK {
+				body = rewriteCard(body, targetOrigin, p.origin)
+				resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+				resp.ContentLength = int64(len(body))
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			e := r.Context().Value(proxiedKey{}).(*proxied).exchange
+			e.Error = err.Error()
+			finish(e)
+			writeError(w, http.StatusBadGateway, protocol.ErrInternal.Withf("agent at %s unreachable: %v", targetOrigin, err))
+		},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest.Withf("failed to read request body: %v", err))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		e := &exchange{
+			Seq:    rec.next(),
+			Time:   time.Now(),
+			Target: targetOrigin,
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.RawQuery,
+		}
+		e.setRequest(r.Header, body)
+		ctx := context.WithValue(r.Context(), proxiedKey{}, &proxied{exchange: e, origin: "http://" + r.Host})
+		proxy.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// isEventStream reports whether a response is a server-sent event stream
+func isEventStream(header http.Header) bool {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	return mediaType == "text/event-stream"
+}
+
+// eventRecorder passes an event stream through to the client, splitting it into events
+// timed from the start of the exchange. The exchange is finished when the stream closes.
+type eventRecorder struct {
+	body     io.ReadCloser
+	exchange *exchange
+	finish   func(*exchange)
+	pending  []byte
+	once     sync.Once
+}
+
+// Read implements io.Reader
+func (r *eventRecorder) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.pending = append(r.pending, bytes.ReplaceAll(p[:n], []byte("\r\n"), []byte("\n"))...)
+	for {
+		end := bytes.Index(r.pending, []byte("\n\n"))
+		if end < 0 {
+			break
+		}
+		r.add(r.pending[:end])
+		r.pending = r.pending[end+2:]
+	}
+	return n, err
+}
+
+// Close implements io.Closer, recording an unterminated last event
+func (r *eventRecorder) Close() error {
+	err := r.body.Close()
+	r.once.Do(func() {
+		r.add(r.pending)
+		r.finish(r.exchange)
+	})
+	return err
+}
+
+// add records an event received now, ignoring blank ones
+func (r *eventRecorder) add(raw []byte) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return
+	}
+	r.exchange.Events = append(r.exchange.Events, sseEvent{
+		OffsetMs: float64(time.Since(r.exchange.Time).Microseconds()) / 1000,
+		Raw:      string(raw),
+	})
+}
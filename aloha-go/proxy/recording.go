@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// redactedHeaders are masked in recordings so credentials stay out of them
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "X-API-Key", "Cookie", "Set-Cookie"}
+
+// exchange is one request to the agent and its response, as one line of a recording
+type exchange struct {
+	Seq        int       `json:"seq"`
+	Time       time.Time `json:"time"`
+	Target     string    `json:"target"` // origin of the agent, e.g. http://localhost:12002
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Query      string    `json:"query,omitempty"`
+	RPCMethod  string    `json:"rpcMethod,omitempty"` // method of a JSON-RPC request
+	DurationMs float64   `json:"durationMs"`
+	Error      string    `json:"error,omitempty"` // set when the agent could not be reached
+
+	RequestHeaders http.Header     `json:"requestHeaders,omitempty"`
+	RequestJSON    json.RawMessage `json:"requestJson,omitempty"` // the request body when it is valid JSON
+	RequestBody    string          `json:"requestBody,omitempty"` // the request body otherwise
+
+	Status          int             `json:"status,omitempty"`
+	ResponseHeaders http.Header     `json:"responseHeaders,omitempty"`
+	ResponseJSON    json.RawMessage `json:"responseJson,omitempty"` // the response body when it is valid JSON
+	ResponseBody    string          `json:"responseBody,omitempty"` // the response body otherwise
+	Events          []sseEvent      `json:"events,omitempty"`       // the events of an event-stream response
+}
+
+// sseEvent is one server-sent event as received, with its offset from the response start
+type sseEvent struct {
+	OffsetMs float64 `json:"offsetMs"`
+	Raw      string  `json:"raw"` // the event's lines, without the blank line ending it
+}
+
+// key identifies the requests an exchange answers on replay
+func (e *exchange) key() string {
+	return e.Method + " " + e.Path + " " + e.RPCMethod
+}
+
+// setRequest records the request headers and body
+func (e *exchange) setRequest(header http.Header, body []byte) {
+	e.RequestHeaders = redact(header)
+	if len(body) > 0 && json.Valid(body) {
+		e.RequestJSON = json.RawMessage(body)
+		e.RPCMethod = rpcMethod(body)
+	} else {
+		e.RequestBody = string(body)
+	}
+}
+
+// setResponseBody records a response body that is not an event stream
+func (e *exchange) setResponseBody(body []byte) {
+	if len(body) > 0 && json.Valid(body) {
+		e.ResponseJSON = json.RawMessage(body)
+	} else {
+		e.ResponseBody = string(body)
+	}
+}
+
+// responseBody returns the recorded response body
+func (e *exchange) responseBody() []byte {
+	if e.ResponseJSON != nil {
+		return e.ResponseJSON
+	}
+	return []byte(e.ResponseBody)
+}
+
+// rpcMethod returns the method of a JSON-RPC request body, or "" for other bodies
+func rpcMethod(body []byte) string {
+	var req struct {
+		JSONRPC string `json:"jsonrpc"`
+		Method  string `json:"method"`
+	}
+	if json.Unmarshal(body, &req) != nil || req.JSONRPC == "" {
+		return ""
+	}
+	return req.Method
+}
+
+// redact returns a copy of header with credentials masked
+func redact(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range redactedHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, "REDACTED")
+		}
+	}
+	return header
+}
+
+// recorder appends exchanges to a JSON Lines file as they complete, so a recording
+// survives the proxy being killed
+type recorder struct {
+	mu   sync.Mutex
+	file *os.File
+	seq  int
+}
+
+// newRecorder creates the recording file, or appends to it when it exists
+func newRecorder(path string) (*recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &recorder{file: file}, nil
+}
+
+// next returns the sequence number of a new exchange
+func (r *recorder) next() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	return r.seq
+}
+
+// write appends a completed exchange
+func (r *recorder) write(e *exchange) {
+	line, err := json.Marshal(e)
+	if err != nil {
+		logf("WARN", "Failed to encode exchange %d: %v", e.Seq, err)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		logf("WARN", "Failed to record exchange %d: %v", e.Seq, err)
+	}
+}
+
+// Close closes the recording file
+func (r *recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// loadRecording reads the exchanges of a recording, in order
+func loadRecording(path string) ([]*exchange, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var exchanges []*exchange
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var e exchange
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		exchanges = append(exchanges, &e)
+	}
+	return exchanges, scanner.Err()
+}
+
+// rewriteCard points the interfaces of an agent card served by target at the proxy,
+// so clients keep talking through it. Interfaces on other origins are left alone, and
+// cards that are not JSON objects are returned unchanged.
+func rewriteCard(body []byte, target, proxy string) []byte {
+	var card map[string]any
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || json.Unmarshal(body, &card) != nil {
+		return body
+	}
+	rewrite := func(v any) any {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme+"://"+u.Host != target {
+			return v
+		}
+		u.Scheme, u.Host = proxyURL.Scheme, proxyURL.Host
+		return u.String()
+	}
+
+	if v, ok := card["url"]; ok {
+		card["url"] = rewrite(v)
+	}
+	if interfaces, ok := card["additionalInterfaces"].([]any); ok {
+		for _, iface := range interfaces {
+			if iface, ok := iface.(map[string]any); ok && iface["url"] != nil {
+				iface["url"] = rewrite(iface["url"])
+			}
+		}
+	}
+	rewritten, err := json.Marshal(card)
+	if err != nil {
+		return body
+	}
+	return rewritten
+}
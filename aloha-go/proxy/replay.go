@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// replayHeaders are recorded response headers not replayed, as they describe the
+// recorded connection rather than the response
+var replayHeaders = []string{"Content-Length", "Date", "Transfer-Encoding", "Connection"}
+
+// replayer answers requests with the recorded responses, without an agent. Requests
+// match exchanges by HTTP method, path and JSON-RPC method: matching exchanges are
+// replayed in recorded order, then the last one is repeated.
+type replayer struct {
+	speed float64 // replay speed factor; 0 replays without delays
+
+	mu        sync.Mutex
+	remaining map[string][]*exchange
+	last      map[string]*exchange
+}
+
+// newReplayer creates a replayer of a recording's exchanges
+func newReplayer(exchanges []*exchange, speed float64) *replayer {
+	r := &replayer{speed: speed, remaining: make(map[string][]*exchange), last: make(map[string]*exchange)}
+	for _, e := range exchanges {
+		r.remaining[e.key()] = append(r.remaining[e.key()], e)
+	}
+	return r
+}
+
+// next returns the exchange answering a request with the given key, or nil if none does
+func (r *replayer) next(key string) *exchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if queue := r.remaining[key]; len(queue) > 0 {
+		r.remaining[key], r.last[key] = queue[1:], queue[0]
+	}
+	return r.last[key]
+}
+
+// wait sleeps for a recorded offset, scaled by the replay speed, measured from start.
+// It reports false if the client went away.
+func (r *replayer) wait(req *http.Request, start time.Time, offsetMs float64) bool {
+	if r.speed <= 0 {
+		return true
+	}
+	delay := time.Duration(offsetMs/r.speed*float64(time.Millisecond)) - time.Since(start)
+	if delay <= 0 {
+		return true
+	}
+	select {
+	case <-time.After(delay):
+		return true
+	case <-req.Context().Done():
+		return false
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (r *replayer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	start := time.Now()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest.Withf("failed to read request body: %v", err))
+		return
+	}
+	method := rpcMethod(body)
+	id := rpcID(body)
+
+	e := r.next(req.Method + " " + req.URL.Path + " " + method)
+	if e == nil {
+		what := strings.TrimSpace(req.Method + " " + req.URL.Path + " " + method)
+		logf("WARN", "No recorded response for %s", what)
+		if method != "" {
+			writeJSON(w, http.StatusOK, map[string]any{"jsonrpc": "2.0", "id": id, "error": protocol.ErrMethodNotFound.Withf("no recorded response for %s", what)})
+			return
+		}
+		writeError(w, http.StatusNotFound, protocol.ErrMethodNotFound.Withf("no recorded response for %s", what))
+		return
+	}
+	defer logExchange(e, "replayed ")
+
+	if e.Error != "" {
+		r.wait(req, start, e.DurationMs)
+		writeError(w, http.StatusBadGateway, protocol.ErrInternal.Withf("agent at %s unreachable: %s", e.Target, e.Error))
+		return
+	}
+	for name, values := range e.ResponseHeaders {
+		w.Header()[name] = values
+	}
+	for _, name := range replayHeaders {
+		w.Header().Del(name)
+	}
+
+	if isEventStream(e.ResponseHeaders) {
+		w.WriteHeader(e.Status)
+		flusher, _ := w.(http.Flusher)
+		for _, event := range e.Events {
+			if !r.wait(req, start, event.OffsetMs) {
+				return
+			}
+			io.WriteString(w, withEventRPCID(event.Raw, id)+"\n\n")
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		r.wait(req, start, e.DurationMs)
+		return
+	}
+
+	payload := e.responseBody()
+	if req.URL.Path == agentCardPath && e.Status == http.StatusOK {
+		payload = rewriteCard(payload, e.Target, "http://"+req.Host)
+	}
+	if id != nil {
+		payload = withRPCID(payload, id)
+	}
+	r.wait(req, start, e.DurationMs)
+	w.WriteHeader(e.Status)
+	w.Write(payload)
+}
+
+// rpcID returns the id of a JSON-RPC request body, or nil for other bodies
+func rpcID(body []byte) json.RawMessage {
+	var req struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+	}
+	if json.Unmarshal(body, &req) != nil || req.JSONRPC == "" {
+		return nil
+	}
+	return req.ID
+}
+
+// withRPCID sets the id of a recorded JSON-RPC response to the id of the request it
+// now answers; other payloads are returned unchanged
+func withRPCID(payload []byte, id json.RawMessage) []byte {
+	var resp map[string]json.RawMessage
+	if json.Unmarshal(payload, &resp) != nil || resp["jsonrpc"] == nil {
+		return payload
+	}
+	resp["id"] = id
+	rewritten, err := json.Marshal(resp)
+	if err != nil {
+		return payload
+	}
+	return rewritten
+}
+
+// withEventRPCID sets the id of the JSON-RPC responses carried by the data lines of
+// a recorded event
+func withEventRPCID(raw string, id json.RawMessage) string {
+	if id == nil {
+		return raw
+	}
+	lines := strings.Split(raw, "\n")
+	for i, line := range lines {
+		if data, ok := strings.CutPrefix(line, "data:"); ok {
+			data = strings.TrimPrefix(data, " ")
+			lines[i] = "data: " + string(bytes.TrimSpace(withRPCID([]byte(data), id)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
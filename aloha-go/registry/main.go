@@ -0,0 +1,161 @@
+// Command registry is the agent registry service: agents POST their cards to it and
+// renew them periodically, and clients query the live agents by skill tag.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+	"github.com/aloha/a2a-go/pkg/registry"
+)
+
+// maxRegistrationSize bounds the body of a registration
+const maxRegistrationSize = 1 << 20
+
+func main() {
+	port := getEnvInt("REGISTRY_PORT", 12100)
+	host := getEnv("HOST", "0.0.0.0")
+	ttl, err := time.ParseDuration(getEnv("REGISTRY_TTL", "60s"))
+	if err != nil || ttl <= 0 {
+		log.Fatalf("registry.main - ERROR - Invalid REGISTRY_TTL: must be a positive duration such as 60s")
+	}
+
+	agents := newStore(ttl)
+	mux := http.NewServeMux()
+	mux.HandleFunc(registry.AgentsPath, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			handleRegister(agents, w, r)
+		case http.MethodGet:
+			handleFind(agents, w, r)
+		case http.MethodDelete:
+			handleDeregister(agents, w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, protocol.ErrMethodNotFound.Withf("%s %s", r.Method, r.URL.Path))
+		}
+	})
+	server := &http.Server{Addr: fmt.Sprintf("%s:%d", host, port), Handler: mux}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		logf("INFO", "Shutdown signal received, stopping registry...")
+		server.Shutdown(context.Background())
+	}()
+
+	logf("INFO", "Agent registry listening on http://%s:%d%s (registrations expire after %s)", host, port, registry.AgentsPath, ttl)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("registry.main - ERROR - Server error: %v", err)
+	}
+	logf("INFO", "Registry stopped")
+}
+
+// handleRegister handles POST /v1/agents: registers an agent or renews its registration
+func handleRegister(agents *store, w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRegistrationSize+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest.Withf("failed to read request body: %v", err))
+		return
+	}
+	if len(body) > maxRegistrationSize {
+		writeError(w, http.StatusRequestEntityTooLarge, protocol.ErrInvalidRequest.Withf("registration exceeds %d bytes", maxRegistrationSize))
+		return
+	}
+	var req registry.Registration
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, protocol.ErrParse.Withf("%v", err))
+		return
+	}
+	if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidParams.Withf("url must be the http(s) URL serving the agent card, got %q", req.URL))
+		return
+	}
+	if req.Card == nil || req.Card.Name == "" {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidParams.Withf("card with a name required"))
+		return
+	}
+
+	registration, created := agents.Register(req.URL, req.Card)
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+		logf("INFO", "Registered %s (%s) with tags %s", req.Card.Name, req.URL, strings.Join(registry.Tags(req.Card), ", "))
+	}
+	writeJSON(w, status, registration)
+}
+
+// handleFind handles GET /v1/agents: lists the live agents having a skill with every
+// tag parameter; a tag parameter may also list several comma-separated tags
+func handleFind(agents *store, w http.ResponseWriter, r *http.Request) {
+	var tags []string
+	for _, value := range r.URL.Query()["tag"] {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, registry.ListResponse{Agents: agents.Find(tags)})
+}
+
+// handleDeregister handles DELETE /v1/agents?url=...
+func handleDeregister(agents *store, w http.ResponseWriter, r *http.Request) {
+	target := r.URL.Query().Get("url")
+	if target == "" {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidParams.Withf("url required"))
+		return
+	}
+	if !agents.Deregister(target) {
+		writeError(w, http.StatusNotFound, protocol.ErrInvalidParams.Withf("no agent registered at %s", target))
+		return
+	}
+	logf("INFO", "Deregistered %s", target)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a protocol error as {"error": {...}}, with the A2A error code
+func writeError(w http.ResponseWriter, status int, err *protocol.Error) {
+	writeJSON(w, status, map[string]*protocol.Error{"error": err})
+}
+
+// logf logs in the agents' "name - LEVEL - message" format
+func logf(level, format string, args ...any) {
+	log.Printf("registry.main - %s - %s", level, fmt.Sprintf(format, args...))
+}
+
+// Helper functions
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		var intValue int
+		if _, err := fmt.Sscanf(value, "%d", &intValue); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
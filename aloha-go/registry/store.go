@@ -0,0 +1,88 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/registry"
+)
+
+// store holds the registered agents, keyed by the URL serving their card.
+// Registrations that are not renewed within the TTL expire.
+type store struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu     sync.Mutex
+	agents map[string]*registry.Registration
+}
+
+// newStore creates an empty store whose registrations live for ttl
+func newStore(ttl time.Duration) *store {
+	return &store{ttl: ttl, now: time.Now, agents: make(map[string]*registry.Registration)}
+}
+
+// Register adds the agent or renews its registration with the card it sent,
+// and reports whether it was new
+func (s *store) Register(url string, card *a2a.AgentCard) (registry.Registration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	now := s.now()
+	registration, renewed := s.agents[url]
+	if !renewed {
+		registration = &registry.Registration{URL: url, RegisteredAt: now}
+		s.agents[url] = registration
+	}
+	registration.Card = card
+	registration.ExpiresAt = now.Add(s.ttl)
+	registration.TTLSeconds = int64(s.ttl / time.Second)
+	return *registration, !renewed
+}
+
+// Deregister removes the agent and reports whether it was registered
+func (s *store) Deregister(url string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	_, ok := s.agents[url]
+	delete(s.agents, url)
+	return ok
+}
+
+// Find returns the agents having a skill with each tag, ordered by name and URL
+func (s *store) Find(tags []string) []registry.Registration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	agents := []registry.Registration{}
+	for _, registration := range s.agents {
+		if registry.HasTags(registration.Card, tags) {
+			agents = append(agents, *registration)
+		}
+	}
+	slices.SortFunc(agents, func(a, b registry.Registration) int {
+		if c := strings.Compare(a.Card.Name, b.Card.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.URL, b.URL)
+	})
+	return agents
+}
+
+// expire drops the registrations past their expiry; the caller holds mu
+func (s *store) expire() {
+	now := s.now()
+	for url, registration := range s.agents {
+		if now.After(registration.ExpiresAt) {
+			logf("INFO", "Registration of %s (%s) expired", registration.Card.Name, url)
+			delete(s.agents, url)
+		}
+	}
+}
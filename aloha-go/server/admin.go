@@ -0,0 +1,50 @@
+package server
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// adminScope is the scope a bearer token needs to call the admin endpoints
+const adminScope = "admin"
+
+// adminOnly restricts an admin endpoint, or the runtime metrics. With authentication enabled, the caller needs a
+// bearer token granted the admin scope; without it, only local calls are served, so that
+// the endpoint is never open to the network.
+func (a *AlohaServer) adminOnly(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if a.authenticator == nil {
+			if !isLoopback(r.RemoteAddr) {
+				a.logger.Warn("Rejected admin call %s from %s", r.URL.Path, r.RemoteAddr)
+				writeRESTError(w, protocol.ErrUnauthorized.Withf("admin endpoints only serve local calls unless authentication is enabled"))
+				return
+			}
+			next(w, r)
+			return
+		}
+
+		principal, ok := a.authenticator.authenticate(r.Header.Values("Authorization"))
+		switch {
+		case !ok:
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeRESTError(w, protocol.ErrUnauthenticated)
+		case !principal.HasScope(adminScope):
+			a.logger.Warn("Rejected admin call %s from %s: no %s scope", r.URL.Path, principal.Subject, adminScope)
+			writeRESTError(w, protocol.ErrUnauthorized.Withf("the %s scope is required", adminScope))
+		default:
+			next(w, r)
+		}
+	}
+}
+
+// isLoopback reports whether a remote address is on the loopback interface
+func isLoopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
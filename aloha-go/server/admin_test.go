@@ -0,0 +1,48 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminOnly(t *testing.T) {
+	authenticator := &TokenAuthenticator{
+		tokens: map[string]*Principal{
+			"admin-token": {Subject: "ops", Scopes: []string{adminScope}},
+			"user-token":  {Subject: "alice", Scopes: []string{"dice"}},
+		},
+		logger: NewLogger("server.auth"),
+	}
+	tests := []struct {
+		name          string
+		authenticator *TokenAuthenticator
+		remoteAddr    string
+		token         string
+		want          int
+	}{
+		{"local call without auth", nil, "127.0.0.1:40000", "", http.StatusOK},
+		{"local IPv6 call without auth", nil, "[::1]:40000", "", http.StatusOK},
+		{"remote call without auth", nil, "192.0.2.7:40000", "", http.StatusForbidden},
+		{"no token", authenticator, "127.0.0.1:40000", "", http.StatusUnauthorized},
+		{"unknown token", authenticator, "192.0.2.7:40000", "nope", http.StatusUnauthorized},
+		{"token without the admin scope", authenticator, "192.0.2.7:40000", "user-token", http.StatusForbidden},
+		{"admin token", authenticator, "192.0.2.7:40000", "admin-token", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AlohaServer{authenticator: tt.authenticator, logger: NewLogger("server.agent")}
+			handler := a.adminOnly(func(w http.ResponseWriter, r *http.Request) {})
+			req := httptest.NewRequest(http.MethodPost, "/admin/reload-card", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			handler(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -1,21 +1,27 @@
-package main
+package server
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"expvar"
 	"fmt"
-	"io"
+	"iter"
 	"net"
 	"net/http"
-	"os"
-	"os/signal"
+	"strconv"
 	"strings"
 	"sync"
-	"syscall"
+	"sync/atomic"
+	"time"
 
 	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2agrpc"
 	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/push"
+	"github.com/aloha/a2a-go/pkg/flags"
+	"github.com/aloha/a2a-go/pkg/protocol"
+	"github.com/aloha/a2a-go/pkg/registry"
 	"google.golang.org/grpc"
 )
 
@@ -27,39 +33,132 @@ type AlohaServer struct {
 	host          string
 	transportMode string
 
-	executor       *DiceAgentExecutor
+	router         *SkillRouter
 	requestHandler a2asrv.RequestHandler
-	agentCard      *a2a.AgentCard
+	tasks          *taskStore
+	queues         *replayManager
+
+	// agentCard is guarded by cardMu since it can be replaced on reload
+	cardMu    sync.RWMutex
+	agentCard *a2a.AgentCard
+	cardFile  string
+
+	// authenticator is nil when authentication is disabled
+	authenticator *TokenAuthenticator
+
+	// limits bounds the size of requests, message parts and artifacts
+	limits sizeLimits
+
+	// features gates the experimental behaviors
+	features *flags.Set
+
+	// registry is nil unless the agent registers itself, at registryAgentURL if set
+	registry         *registry.Client
+	registryAgentURL string
+
+	// mDNS advertisement, as instance with the card at mdnsAgentURL if set
+	mdnsEnabled  bool
+	mdnsInstance string
+	mdnsAgentURL string
+
+	// Listeners bound by Listen, served by Start
+	grpcListener    net.Listener
+	jsonrpcListener net.Listener
+	restListener    net.Listener
+
+	// Lame-duck mode before a shutdown, and the A2A calls running
+	lameDuck     atomic.Bool
+	inflight     atomic.Int64
+	drainTimeout time.Duration
 
 	logger *Logger
 }
 
 // NewAlohaServer creates a new Aloha Server instance
-func NewAlohaServer(grpcPort, jsonrpcPort, restPort int, host string, transportMode string) *AlohaServer {
-	executor := NewDiceAgentExecutor()
+func NewAlohaServer(grpcPort, jsonrpcPort, restPort int, host string, transportMode string, cardFile string, authenticator *TokenAuthenticator) *AlohaServer {
+	return newAlohaServer(grpcPort, jsonrpcPort, restPort, host, transportMode, cardFile, authenticator, NewDiceAgentExecutor(), nil)
+}
 
+// newAlohaServer creates an Aloha Server hosting the given dice executor, with the
+// experimental behaviors that features turns on; nil uses the flag defaults
+func newAlohaServer(grpcPort, jsonrpcPort, restPort int, host string, transportMode string, cardFile string, authenticator *TokenAuthenticator, executor SkillExecutor, features *flags.Set) *AlohaServer {
 	serverLogger := NewLogger("server.agent")
+	if features == nil {
+		features = newFeatureFlags()
+	}
+
+	// Route requests across all hosted skill executors
+	router := NewSkillRouter()
+	if err := router.Register(executor); err != nil {
+		serverLogger.Fatal("Failed to register executor: %v", err)
+	}
 
 	server := &AlohaServer{
+		tasks:         newTaskStore(),
+		queues:        newReplayManager(defaultReplayBuffer),
 		grpcPort:      grpcPort,
 		jsonrpcPort:   jsonrpcPort,
 		restPort:      restPort,
 		host:          host,
 		transportMode: transportMode,
-		executor:      executor,
+		router:        router,
+		cardFile:      cardFile,
+		authenticator: authenticator,
+		features:      features,
+		limits:        sizeLimits{request: defaultMaxRequestSize, part: defaultMaxPartSize, artifact: defaultMaxArtifactSize},
 		logger:        serverLogger,
 	}
+	router.maxArtifactSize = server.limits.artifact
 
-	// Create agent card
-	server.agentCard = server.createAgentCard()
+	// Create agent card, applying overrides from the card file if configured
+	if err := server.ReloadAgentCard(); err != nil {
+		serverLogger.Warn("Using built-in agent card: %v", err)
+		server.agentCard = server.createAgentCard()
+	}
 
 	// Create transport-agnostic request handler using the SDK
-	server.requestHandler = a2asrv.NewHandler(executor)
+	handlerOptions := []a2asrv.RequestHandlerOption{
+		a2asrv.WithRequestContextInterceptor(messageMetadataInterceptor{}),
+		a2asrv.WithRequestContextInterceptor(principalInterceptor{}),
+		// The SDK's default store cannot list tasks for anonymous callers
+		a2asrv.WithTaskStore(server.tasks),
+		// Clients resubscribing mid-task receive the events they missed
+		a2asrv.WithEventQueueManager(server.queues),
+	}
+	if features.Enabled(featurePushNotifications) {
+		// Task updates are posted to the push notification configs clients register
+		handlerOptions = append(handlerOptions, a2asrv.WithPushNotifications(push.NewInMemoryStore(), push.NewHTTPPushSender(nil)))
+	}
+	if features.Enabled(featureExtendedCard) {
+		handlerOptions = append(handlerOptions, a2asrv.WithExtendedAgentCardProducer(a2asrv.AgentCardProducerFn(server.extendedAgentCard)))
+	}
+	if authenticator != nil {
+		handlerOptions = append(handlerOptions, a2asrv.WithCallInterceptor(authenticator))
+		serverLogger.Info("Bearer token authentication enabled")
+	}
+	server.requestHandler = &limitsHandler{
+		RequestHandler: &sendConfigHandler{
+			RequestHandler: &taskOwnerHandler{
+				RequestHandler: a2asrv.NewHandler(router, handlerOptions...),
+				tasks:          server.tasks,
+			},
+			card: server.AgentCard,
+		},
+		limits: &server.limits,
+	}
 
 	serverLogger.Info("Dice Agent initialized with A2A SDK")
 	return server
 }
 
+// RegisterExecutor hosts an additional skill executor and republishes the agent card with its skills
+func (a *AlohaServer) RegisterExecutor(executor SkillExecutor) error {
+	if err := a.router.Register(executor); err != nil {
+		return err
+	}
+	return a.ReloadAgentCard()
+}
+
 // createAgentCard creates the agent card describing capabilities
 func (a *AlohaServer) createAgentCard() *a2a.AgentCard {
 	// Determine URL and preferred transport based on transport mode
@@ -78,32 +177,19 @@ func (a *AlohaServer) createAgentCard() *a2a.AgentCard {
 		preferredTransport = a2a.TransportProtocolHTTPJSON
 	}
 
-	return &a2a.AgentCard{
-		Name:        "Dice Agent",
-		Description: "An agent that can roll arbitrary dice and check prime numbers",
-		URL:         url,
-		Version:     "1.0.0",
+	card := &a2a.AgentCard{
+		Name:            "Dice Agent",
+		Description:     "An agent that can roll arbitrary dice and check prime numbers",
+		URL:             url,
+		Version:         "1.0.0",
+		ProtocolVersion: string(a2a.Version),
 		Capabilities: a2a.AgentCapabilities{
-			Streaming: true,
+			Streaming:         true,
+			PushNotifications: a.features.Enabled(featurePushNotifications),
 		},
 		DefaultInputModes:  []string{"text"},
 		DefaultOutputModes: []string{"text"},
-		Skills: []a2a.AgentSkill{
-			{
-				ID:          "roll-dice",
-				Name:        "Roll Dice",
-				Description: "Rolls an N-sided dice",
-				Tags:        []string{"dice", "random"},
-				Examples:    []string{"Roll a 20-sided dice"},
-			},
-			{
-				ID:          "check-prime",
-				Name:        "Prime Checker",
-				Description: "Checks if numbers are prime",
-				Tags:        []string{"math", "prime"},
-				Examples:    []string{"Is 17 prime?"},
-			},
-		},
+		Skills:             a.router.Skills(),
 		AdditionalInterfaces: []a2a.AgentInterface{
 			{
 				Transport: a2a.TransportProtocolGRPC,
@@ -118,8 +204,64 @@ func (a *AlohaServer) createAgentCard() *a2a.AgentCard {
 				URL:       fmt.Sprintf("http://localhost:%d", a.restPort),
 			},
 		},
-		PreferredTransport: preferredTransport,
+		PreferredTransport:                preferredTransport,
+		SupportsAuthenticatedExtendedCard: a.features.Enabled(featureExtendedCard),
+	}
+
+	// Advertise bearer authentication when it is enforced
+	if a.authenticator != nil {
+		card.SecuritySchemes = a2a.NamedSecuritySchemes{
+			bearerSchemeName: a2a.HTTPAuthSecurityScheme{Scheme: "Bearer"},
+		}
+		card.Security = []a2a.SecurityRequirements{{bearerSchemeName: a2a.SecuritySchemeScopes{}}}
+	}
+
+	return card
+}
+
+// Listen binds the ports of all transports. A port of 0 picks a free port, which
+// replaces it in the agent card. Start calls Listen if it has not been called.
+func (a *AlohaServer) Listen() error {
+	if a.grpcListener != nil {
+		return nil
+	}
+
+	listeners := []struct {
+		name     string
+		port     *int
+		listener *net.Listener
+	}{
+		{"gRPC", &a.grpcPort, &a.grpcListener},
+		{"JSON-RPC", &a.jsonrpcPort, &a.jsonrpcListener},
+		{"REST", &a.restPort, &a.restListener},
+	}
+	ephemeral := false
+	for i, l := range listeners {
+		listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", a.host, *l.port))
+		if err != nil {
+			for _, bound := range listeners[:i] {
+				(*bound.listener).Close()
+				*bound.listener = nil
+			}
+			return fmt.Errorf("failed to listen on %s port: %w", l.name, err)
+		}
+		if *l.port == 0 {
+			*l.port = listener.Addr().(*net.TCPAddr).Port
+			ephemeral = true
+		}
+		*l.listener = listener
 	}
+
+	// The agent card advertises the ports actually bound
+	if ephemeral {
+		if err := a.ReloadAgentCard(); err != nil {
+			a.logger.Warn("Using built-in agent card: %v", err)
+			a.cardMu.Lock()
+			a.agentCard = a.createAgentCard()
+			a.cardMu.Unlock()
+		}
+	}
+	return nil
 }
 
 // Start starts all transport servers
@@ -128,6 +270,10 @@ func (a *AlohaServer) Start(ctx context.Context) error {
 	a.logger.Info("=== Dice Agent starting ===")
 	a.logger.Info("============================================================")
 
+	if err := a.Listen(); err != nil {
+		return err
+	}
+
 	var wg sync.WaitGroup
 	errChan := make(chan error, 3)
 
@@ -144,7 +290,7 @@ func (a *AlohaServer) Start(ctx context.Context) error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		if err := a.startJSONRPCTransport(ctx); err != nil {
+		if err := a.startJSONRPCTransport(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			errChan <- fmt.Errorf("JSON-RPC transport error: %w", err)
 		}
 	}()
@@ -153,11 +299,29 @@ func (a *AlohaServer) Start(ctx context.Context) error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		if err := a.startRESTTransport(ctx); err != nil {
+		if err := a.startRESTTransport(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			errChan <- fmt.Errorf("REST transport error: %w", err)
 		}
 	}()
 
+	// Keep the agent registered while the transports serve
+	if a.registry != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.keepRegistered(ctx)
+		}()
+	}
+
+	// Advertise the agent on the local network while the transports serve
+	if a.mdnsEnabled {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.advertiseMDNS(ctx)
+		}()
+	}
+
 	a.logger.Info("============================================================")
 	a.logger.Info("Dice Agent is running with the following transports:")
 	a.logger.Info("  - Active Mode:  %s", a.transportMode)
@@ -178,8 +342,9 @@ func (a *AlohaServer) Start(ctx context.Context) error {
 	a.logger.Info("  - SDK: github.com/a2aproject/a2a-go v0.3.7")
 	a.logger.Info("============================================================")
 
-	// Wait for context cancellation
+	// Wait for context cancellation, then for the transports to stop
 	<-ctx.Done()
+	wg.Wait()
 
 	select {
 	case err := <-errChan:
@@ -193,24 +358,20 @@ func (a *AlohaServer) Start(ctx context.Context) error {
 func (a *AlohaServer) startGRPCTransport(ctx context.Context) error {
 	a.logger.Info("Starting gRPC transport on %s:%d", a.host, a.grpcPort)
 
-	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", a.host, a.grpcPort))
-	if err != nil {
-		return fmt.Errorf("failed to listen on gRPC port: %w", err)
-	}
-
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(append(a.grpcLifecycleOptions(), a.grpcSizeOptions()...)...)
 
 	// Register A2A gRPC handler from the SDK
 	grpcHandler := a2agrpc.NewHandler(a.requestHandler)
 	grpcHandler.RegisterWith(grpcServer)
 
-	go func() {
-		<-ctx.Done()
-		grpcServer.GracefulStop()
-	}()
+	stopped := a.stopGRPC(ctx, grpcServer)
 
 	a.logger.Info("gRPC transport listening on %s:%d", a.host, a.grpcPort)
-	return grpcServer.Serve(listener)
+	err := grpcServer.Serve(a.grpcListener)
+	if err == nil || errors.Is(err, grpc.ErrServerStopped) {
+		<-stopped
+	}
+	return err
 }
 
 // startJSONRPCTransport starts the JSON-RPC 2.0 transport using the SDK
@@ -220,23 +381,28 @@ func (a *AlohaServer) startJSONRPCTransport(ctx context.Context) error {
 	mux := http.NewServeMux()
 
 	// Serve agent card at well-known path
-	mux.Handle("/.well-known/agent-card.json", a2asrv.NewStaticAgentCardHandler(a.agentCard))
+	mux.Handle("/.well-known/agent-card.json", a2asrv.NewAgentCardHandler(a2asrv.AgentCardProducerFn(a.AgentCard)))
+	mux.HandleFunc("/admin/reload-card", a.adminOnly(a.handleAdminReloadCard))
+	mux.HandleFunc("/debug/vars", a.adminOnly(expvar.Handler().ServeHTTP))
+	mux.HandleFunc("/healthz", a.handleHealthz)
+	mux.HandleFunc("/readyz", a.handleReadyz)
 
 	// Serve JSON-RPC handler from the SDK at root
-	mux.Handle("/", a2asrv.NewJSONRPCHandler(a.requestHandler))
+	mux.Handle("/", withJSONContentType(a2asrv.NewJSONRPCHandler(a.requestHandler)))
 
-	server := &http.Server{
-		Addr:    fmt.Sprintf("%s:%d", a.host, a.jsonrpcPort),
-		Handler: mux,
-	}
-
-	go func() {
-		<-ctx.Done()
-		server.Shutdown(context.Background())
-	}()
+	server := &http.Server{Handler: a.admitHTTP(a.limitRequestSize(a.serveJSONRPC2(validateJSONRPC(mux)), rejectJSONRPCTooLarge), isJSONRPCCall, rejectJSONRPC)}
 
 	a.logger.Info("JSON-RPC transport listening on %s:%d", a.host, a.jsonrpcPort)
-	return server.ListenAndServe()
+	return a.serveHTTP(ctx, "JSON-RPC", server, a.jsonrpcListener)
+}
+
+// withJSONContentType declares JSON responses for the SDK's JSON-RPC handler, which writes
+// them without a Content-Type; streams replace it with text/event-stream
+func withJSONContentType(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		next.ServeHTTP(w, r)
+	})
 }
 
 // startRESTTransport starts the REST HTTP+JSON transport
@@ -245,27 +411,57 @@ func (a *AlohaServer) startJSONRPCTransport(ctx context.Context) error {
 func (a *AlohaServer) startRESTTransport(ctx context.Context) error {
 	a.logger.Info("Starting REST transport on %s:%d", a.host, a.restPort)
 
+	// Calls outlive ctx until the transport has drained
+	transportCtx := ctx
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
 	mux := http.NewServeMux()
 
 	// Agent card endpoint
-	mux.Handle("/.well-known/agent-card.json", a2asrv.NewStaticAgentCardHandler(a.agentCard))
+	mux.Handle("/.well-known/agent-card.json", a2asrv.NewAgentCardHandler(a2asrv.AgentCardProducerFn(a.AgentCard)))
+	mux.HandleFunc("/admin/reload-card", a.adminOnly(a.handleAdminReloadCard))
+	mux.HandleFunc("/debug/vars", a.adminOnly(expvar.Handler().ServeHTTP))
+	mux.HandleFunc("/healthz", a.handleHealthz)
+	mux.HandleFunc("/readyz", a.handleReadyz)
+
+	// Web dashboard; /ui redirects to /ui/
+	mux.Handle("/ui/", uiHandler())
 
 	// REST: POST /v1/message:send - non-streaming message send
 	mux.HandleFunc("/v1/message:send", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			writeMethodNotAllowed(w, r)
 			return
 		}
-		a.handleRESTMessageSend(ctx, w, r)
+		a.handleRESTMessageSend(restCallContext(ctx, r), w, r)
 	})
 
 	// REST: POST /v1/message:stream - streaming message send (SSE)
 	mux.HandleFunc("/v1/message:stream", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			writeMethodNotAllowed(w, r)
 			return
 		}
-		a.handleRESTMessageStream(ctx, w, r)
+		a.handleRESTMessageStream(restCallContext(ctx, r), w, r)
+	})
+
+	// REST: GET /v1/card - authenticated extended agent card
+	mux.HandleFunc("/v1/card", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w, r)
+			return
+		}
+		a.handleRESTExtendedCard(restCallContext(ctx, r), w)
+	})
+
+	// REST: GET /v1/tasks - list tasks
+	mux.HandleFunc("/v1/tasks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w, r)
+			return
+		}
+		a.handleRESTListTasks(restCallContext(ctx, r), w, r)
 	})
 
 	// REST: GET /v1/tasks/{taskId}
@@ -275,56 +471,55 @@ func (a *AlohaServer) startRESTTransport(ctx context.Context) error {
 			// POST /v1/tasks/{taskId}:cancel
 			taskID := strings.TrimPrefix(path, "/v1/tasks/")
 			taskID = strings.TrimSuffix(taskID, ":cancel")
-			a.handleRESTCancelTask(ctx, w, taskID)
+			a.handleRESTCancelTask(restCallContext(ctx, r), w, taskID)
+			return
+		}
+		if (r.Method == http.MethodPost || r.Method == http.MethodGet) && strings.HasSuffix(path, ":subscribe") {
+			// POST /v1/tasks/{taskId}:subscribe - resubscribe to a running task (SSE)
+			taskID := strings.TrimPrefix(path, "/v1/tasks/")
+			taskID = strings.TrimSuffix(taskID, ":subscribe")
+			a.handleRESTResubscribe(restCallContext(ctx, r), w, taskID)
 			return
 		}
 		if r.Method == http.MethodGet {
 			// GET /v1/tasks/{taskId}
 			taskID := strings.TrimPrefix(path, "/v1/tasks/")
-			a.handleRESTGetTask(ctx, w, taskID)
+			a.handleRESTGetTask(restCallContext(ctx, r), w, r, taskID)
 			return
 		}
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeMethodNotAllowed(w, r)
 	})
 
-	server := &http.Server{
-		Addr:    fmt.Sprintf("%s:%d", a.host, a.restPort),
-		Handler: mux,
-	}
-
-	go func() {
-		<-ctx.Done()
-		server.Shutdown(context.Background())
-	}()
+	server := &http.Server{Handler: a.admitHTTP(a.limitRequestSize(validateREST(mux), rejectRESTTooLarge), isRESTCall, rejectREST)}
 
 	a.logger.Info("REST transport listening on %s:%d", a.host, a.restPort)
-	return server.ListenAndServe()
+	return a.serveHTTP(transportCtx, "REST", server, a.restListener)
+}
+
+// restCallContext attaches the HTTP request headers to the context as an SDK call context,
+// so call interceptors such as authentication see the same metadata as on other transports
+func restCallContext(ctx context.Context, r *http.Request) context.Context {
+	ctx, _ = a2asrv.WithCallContext(ctx, a2asrv.NewRequestMeta(r.Header))
+	return ctx
 }
 
 // handleRESTMessageSend handles non-streaming message send via REST
 func (a *AlohaServer) handleRESTMessageSend(ctx context.Context, w http.ResponseWriter, r *http.Request) {
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+	body, ok := readRESTBody(w, r)
+	if !ok {
 		return
 	}
-	defer r.Body.Close()
 
-	var params a2a.MessageSendParams
-	if err := json.Unmarshal(body, &params); err != nil {
-		// Try to parse as a bare Message (without wrapper)
-		var msg a2a.Message
-		if err2 := json.Unmarshal(body, &msg); err2 != nil {
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
-			return
-		}
-		params = a2a.MessageSendParams{Message: &msg}
+	params, perr := decodeRESTSendParams(body)
+	if perr != nil {
+		writeRESTError(w, perr)
+		return
 	}
 
-	result, err := a.requestHandler.OnSendMessage(ctx, &params)
+	result, err := a.requestHandler.OnSendMessage(ctx, params)
 	if err != nil {
 		a.logger.Error("REST SendMessage error: %v", err)
-		http.Error(w, fmt.Sprintf("Error: %v", err), http.StatusInternalServerError)
+		writeRESTError(w, restError(err, protocol.ErrInternal))
 		return
 	}
 
@@ -334,23 +529,55 @@ func (a *AlohaServer) handleRESTMessageSend(ctx context.Context, w http.Response
 
 // handleRESTMessageStream handles streaming message send via REST (SSE)
 func (a *AlohaServer) handleRESTMessageStream(ctx context.Context, w http.ResponseWriter, r *http.Request) {
-	body, err := io.ReadAll(r.Body)
+	body, ok := readRESTBody(w, r)
+	if !ok {
+		return
+	}
+
+	params, err := decodeRESTSendParams(body)
 	if err != nil {
-		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		writeRESTError(w, err)
 		return
 	}
-	defer r.Body.Close()
 
-	var params a2a.MessageSendParams
-	if err := json.Unmarshal(body, &params); err != nil {
-		var msg a2a.Message
-		if err2 := json.Unmarshal(body, &msg); err2 != nil {
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
-			return
+	a.writeRESTEventStream(w, a.requestHandler.OnSendMessageStream(ctx, params))
+}
+
+// decodeRESTSendParams decodes the body of a REST message send: MessageSendParams, or a
+// bare Message without the wrapper
+func decodeRESTSendParams(body []byte) (*a2a.MessageSendParams, *protocol.Error) {
+	var wrapper struct {
+		Message json.RawMessage `json:"message"`
+	}
+	if err := json.Unmarshal(body, &wrapper); err != nil {
+		return nil, protocol.ErrParse.Withf("%v", err)
+	}
+	if wrapper.Message != nil {
+		var params a2a.MessageSendParams
+		if err := json.Unmarshal(body, &params); err != nil {
+			return nil, protocol.ErrParse.Withf("%v", err)
 		}
-		params = a2a.MessageSendParams{Message: &msg}
+		return &params, nil
 	}
+	var msg a2a.Message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, protocol.ErrParse.Withf("%v", err)
+	}
+	return &a2a.MessageSendParams{Message: &msg}, nil
+}
 
+// handleRESTResubscribe reattaches to the event stream of a running task via REST (SSE)
+func (a *AlohaServer) handleRESTResubscribe(ctx context.Context, w http.ResponseWriter, taskID string) {
+	if taskID == "" {
+		writeRESTError(w, protocol.ErrInvalidParams.Withf("task ID required"))
+		return
+	}
+
+	a.writeRESTEventStream(w, a.requestHandler.OnResubscribeToTask(ctx, &a2a.TaskIDParams{ID: a2a.TaskID(taskID)}))
+}
+
+// writeRESTEventStream writes SDK events to the response as server-sent events
+func (a *AlohaServer) writeRESTEventStream(w http.ResponseWriter, events iter.Seq2[a2a.Event, error]) {
 	// Set SSE headers
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
@@ -358,15 +585,15 @@ func (a *AlohaServer) handleRESTMessageStream(ctx context.Context, w http.Respon
 
 	flusher, ok := w.(http.Flusher)
 	if !ok {
-		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		writeRESTError(w, protocol.ErrInternal.Withf("streaming not supported"))
 		return
 	}
 
-	// Use the streaming handler from the SDK
-	for event, err := range a.requestHandler.OnSendMessageStream(ctx, &params) {
+	for event, err := range events {
 		if err != nil {
 			a.logger.Error("REST stream error: %v", err)
-			errorJSON, _ := json.Marshal(map[string]string{"error": err.Error()})
+			streamErr := restError(err, protocol.ErrInternal)
+			errorJSON, _ := json.Marshal(streamErr.HTTPError(streamErr.HTTPStatus()))
 			fmt.Fprintf(w, "data: %s\n\n", errorJSON)
 			flusher.Flush()
 			return
@@ -383,17 +610,27 @@ func (a *AlohaServer) handleRESTMessageStream(ctx context.Context, w http.Respon
 	}
 }
 
-// handleRESTGetTask handles task retrieval via REST
-func (a *AlohaServer) handleRESTGetTask(ctx context.Context, w http.ResponseWriter, taskID string) {
+// handleRESTGetTask handles task retrieval via REST, with an optional historyLength in the query string
+func (a *AlohaServer) handleRESTGetTask(ctx context.Context, w http.ResponseWriter, r *http.Request, taskID string) {
 	if taskID == "" {
-		http.Error(w, "Task ID required", http.StatusBadRequest)
+		writeRESTError(w, protocol.ErrInvalidParams.Withf("task ID required"))
 		return
 	}
 
-	task, err := a.requestHandler.OnGetTask(ctx, &a2a.TaskQueryParams{ID: a2a.TaskID(taskID)})
+	query := &a2a.TaskQueryParams{ID: a2a.TaskID(taskID)}
+	if value := r.URL.Query().Get("historyLength"); value != "" {
+		length, err := strconv.Atoi(value)
+		if err != nil || length < 0 {
+			writeRESTError(w, protocol.ErrInvalidParams.Withf("invalid historyLength %q: must be a non-negative integer", value))
+			return
+		}
+		query.HistoryLength = &length
+	}
+
+	task, err := a.requestHandler.OnGetTask(ctx, query)
 	if err != nil {
 		a.logger.Error("REST GetTask error: %v", err)
-		http.Error(w, fmt.Sprintf("Error: %v", err), http.StatusNotFound)
+		writeRESTError(w, restError(err, protocol.ErrTaskNotFound))
 		return
 	}
 
@@ -401,76 +638,61 @@ func (a *AlohaServer) handleRESTGetTask(ctx context.Context, w http.ResponseWrit
 	json.NewEncoder(w).Encode(task)
 }
 
-// handleRESTCancelTask handles task cancellation via REST
-func (a *AlohaServer) handleRESTCancelTask(ctx context.Context, w http.ResponseWriter, taskID string) {
-	if taskID == "" {
-		http.Error(w, "Task ID required", http.StatusBadRequest)
-		return
-	}
-
-	task, err := a.requestHandler.OnCancelTask(ctx, &a2a.TaskIDParams{ID: a2a.TaskID(taskID)})
+// handleRESTExtendedCard serves the authenticated extended agent card via REST
+func (a *AlohaServer) handleRESTExtendedCard(ctx context.Context, w http.ResponseWriter) {
+	card, err := a.requestHandler.OnGetExtendedAgentCard(ctx)
 	if err != nil {
-		a.logger.Error("REST CancelTask error: %v", err)
-		http.Error(w, fmt.Sprintf("Error: %v", err), http.StatusInternalServerError)
+		a.logger.Error("REST GetExtendedAgentCard error: %v", err)
+		writeRESTError(w, restError(err, protocol.ErrExtendedCardNotConfigured))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(task)
+	json.NewEncoder(w).Encode(card)
 }
 
-func main() {
-	// Load environment variables
-	grpcPort := getEnvInt("GRPC_PORT", 12000)
-	jsonrpcPort := getEnvInt("JSONRPC_PORT", 12001)
-	restPort := getEnvInt("REST_PORT", 12002)
-	host := getEnv("HOST", "0.0.0.0")
-	transportMode := getEnv("TRANSPORT_MODE", "jsonrpc")
-
-	// Initialize log file output
-	InitLogFile(transportMode)
-
-	serverLogger := NewLogger("server.main")
-
-	// Create server
-	server := NewAlohaServer(grpcPort, jsonrpcPort, restPort, host, transportMode)
-
-	// Setup context with cancellation
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	// Handle shutdown signals
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-
-	go func() {
-		<-sigChan
-		serverLogger.Info("Shutdown signal received, stopping Dice Agent...")
-		cancel()
-	}()
+// handleRESTListTasks handles task listing via REST, with filters and paging in the query string
+func (a *AlohaServer) handleRESTListTasks(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &a2a.ListTasksRequest{
+		ContextID: query.Get("contextId"),
+		Status:    a2a.TaskState(query.Get("status")),
+		PageToken: query.Get("pageToken"),
+	}
+	if value := query.Get("pageSize"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			writeRESTError(w, protocol.ErrInvalidParams.Withf("invalid pageSize: %v", err))
+			return
+		}
+		req.PageSize = size
+	}
 
-	// Start server
-	if err := server.Start(ctx); err != nil && err != http.ErrServerClosed {
-		serverLogger.Fatal("Server error: %v", err)
+	resp, err := a.requestHandler.OnListTasks(ctx, req)
+	if err != nil {
+		a.logger.Error("REST ListTasks error: %v", err)
+		writeRESTError(w, restError(err, protocol.ErrInvalidParams))
+		return
 	}
 
-	serverLogger.Info("Dice Agent stopped")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
 }
 
-// Helper functions
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// handleRESTCancelTask handles task cancellation via REST
+func (a *AlohaServer) handleRESTCancelTask(ctx context.Context, w http.ResponseWriter, taskID string) {
+	if taskID == "" {
+		writeRESTError(w, protocol.ErrInvalidParams.Withf("task ID required"))
+		return
 	}
-	return defaultValue
-}
 
-func getEnvInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		var intValue int
-		if _, err := fmt.Sscanf(value, "%d", &intValue); err == nil {
-			return intValue
-		}
+	task, err := a.requestHandler.OnCancelTask(ctx, &a2a.TaskIDParams{ID: a2a.TaskID(taskID)})
+	if err != nil {
+		a.logger.Error("REST CancelTask error: %v", err)
+		writeRESTError(w, restError(err, protocol.ErrInternal))
+		return
 	}
-	return defaultValue
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(task)
 }
@@ -0,0 +1,319 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// A2A methods as the audit log names them, whatever the transport of the call
+const (
+	auditMethodSend             = "message/send"
+	auditMethodStream           = "message/stream"
+	auditMethodGetTask          = "tasks/get"
+	auditMethodListTasks        = "tasks/list"
+	auditMethodCancelTask       = "tasks/cancel"
+	auditMethodResubscribe      = "tasks/resubscribe"
+	auditMethodGetPushConfig    = "tasks/pushNotificationConfig/get"
+	auditMethodListPushConfigs  = "tasks/pushNotificationConfig/list"
+	auditMethodSetPushConfig    = "tasks/pushNotificationConfig/set"
+	auditMethodDeletePushConfig = "tasks/pushNotificationConfig/delete"
+	auditMethodGetExtendedCard  = "agent/getAuthenticatedExtendedCard"
+)
+
+// Outcomes of an audited call
+const (
+	auditOutcomeOK      = "ok"
+	auditOutcomeError   = "error"
+	auditOutcomeAborted = "aborted" // the caller left a stream before its end
+)
+
+// Audit log targets other than files
+const (
+	auditSyslogTarget          = "syslog"
+	auditSyslogRemotePrefix    = "syslog://"
+	auditSyslogRemoteTCPPrefix = "syslog+tcp://"
+)
+
+// auditFileMode keeps audit files readable by the server's user only
+const auditFileMode = 0o600
+
+// auditEntry is a line of the audit log: who called which method on which task, and
+// how it ended. Message contents are never recorded.
+type auditEntry struct {
+	Time time.Time `json:"time"`
+	// Caller is the authenticated subject, empty for anonymous calls
+	Caller     string          `json:"caller"`
+	Method     string          `json:"method"`
+	TaskID     a2a.TaskID      `json:"taskId,omitempty"`
+	ContextID  string          `json:"contextId,omitempty"`
+	Outcome    string          `json:"outcome"`
+	Error      *protocol.Error `json:"error,omitempty"`
+	DurationMs float64         `json:"durationMs"`
+}
+
+// AuditLog writes an audit entry per A2A call, as JSON lines, to a sink separate from
+// the server log
+type AuditLog struct {
+	mu     sync.Mutex
+	out    io.WriteCloser
+	logger *Logger
+}
+
+// OpenAuditLog opens the audit log sink named by target: "syslog" for the local syslog
+// daemon, syslog://host:port or syslog+tcp://host:port for a remote one, or else the
+// path of a file the entries are appended to
+func OpenAuditLog(target string) (*AuditLog, error) {
+	var out io.WriteCloser
+	var err error
+	switch {
+	case target == auditSyslogTarget:
+		out, err = openSyslog("", "")
+	case strings.HasPrefix(target, auditSyslogRemotePrefix):
+		out, err = openSyslog("udp", strings.TrimPrefix(target, auditSyslogRemotePrefix))
+	case strings.HasPrefix(target, auditSyslogRemoteTCPPrefix):
+		out, err = openSyslog("tcp", strings.TrimPrefix(target, auditSyslogRemoteTCPPrefix))
+	default:
+		out, err = os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_APPEND, auditFileMode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", target, err)
+	}
+	return &AuditLog{out: out, logger: NewLogger("server.audit")}, nil
+}
+
+// record writes an entry. A failed write is logged but does not fail the call.
+func (l *AuditLog) record(entry auditEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		l.logger.Error("Failed to encode audit entry: %v", err)
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.out.Write(append(data, '\n')); err != nil {
+		l.logger.Error("Failed to write audit entry for %s: %v", entry.Method, err)
+	}
+}
+
+// Close closes the audit log sink
+func (l *AuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.out.Close()
+}
+
+// EnableAuditLog records every A2A call in log, on all transports, including the calls
+// rejected by authentication. It must be called before Start.
+func (a *AlohaServer) EnableAuditLog(log *AuditLog) {
+	a.requestHandler = &auditHandler{RequestHandler: a.requestHandler, log: log}
+}
+
+// auditHandler records the calls of the request handler it wraps
+type auditHandler struct {
+	a2asrv.RequestHandler
+	log *AuditLog
+}
+
+// auditCall is an audit entry being filled while its call runs
+type auditCall struct {
+	log   *AuditLog
+	entry auditEntry
+	start time.Time
+}
+
+// begin starts the audit entry of a call on the given task, if known before the call
+func (h *auditHandler) begin(method string, task a2a.TaskInfo) *auditCall {
+	return &auditCall{
+		log:   h.log,
+		entry: auditEntry{Method: method, TaskID: task.TaskID, ContextID: task.ContextID},
+		start: time.Now(),
+	}
+}
+
+// observe completes the entry's task from a result or event of the call
+func (c *auditCall) observe(result a2a.TaskInfoProvider) {
+	info := result.TaskInfo()
+	if c.entry.TaskID == "" {
+		c.entry.TaskID = info.TaskID
+	}
+	if c.entry.ContextID == "" {
+		c.entry.ContextID = info.ContextID
+	}
+}
+
+// end records the entry. The caller is read from ctx once the call has run, since
+// authentication sets it during the call.
+func (c *auditCall) end(ctx context.Context, outcome string, err error) {
+	c.entry.Time = c.start.UTC()
+	c.entry.Caller = callerName(ctx)
+	c.entry.Outcome = outcome
+	if err != nil {
+		c.entry.Outcome = auditOutcomeError
+		c.entry.Error = restError(err, protocol.ErrInternal)
+	}
+	c.entry.DurationMs = float64(time.Since(c.start).Microseconds()) / 1000
+	c.log.record(c.entry)
+}
+
+// auditStream records a streaming call once its events are over
+func auditStream(ctx context.Context, call *auditCall, events iter.Seq2[a2a.Event, error]) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) {
+		for event, err := range events {
+			if err != nil {
+				call.end(ctx, auditOutcomeError, err)
+				yield(nil, err)
+				return
+			}
+			call.observe(event)
+			if !yield(event, nil) {
+				call.end(ctx, auditOutcomeAborted, nil)
+				return
+			}
+		}
+		call.end(ctx, auditOutcomeOK, nil)
+	}
+}
+
+// messageTask returns the task and context a message refers to, if any
+func messageTask(params *a2a.MessageSendParams) a2a.TaskInfo {
+	if params == nil || params.Message == nil {
+		return a2a.TaskInfo{}
+	}
+	return params.Message.TaskInfo()
+}
+
+// OnSendMessage implements a2asrv.RequestHandler
+func (h *auditHandler) OnSendMessage(ctx context.Context, params *a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	call := h.begin(auditMethodSend, messageTask(params))
+	result, err := h.RequestHandler.OnSendMessage(ctx, params)
+	if err == nil {
+		call.observe(result)
+	}
+	call.end(ctx, auditOutcomeOK, err)
+	return result, err
+}
+
+// OnSendMessageStream implements a2asrv.RequestHandler
+func (h *auditHandler) OnSendMessageStream(ctx context.Context, params *a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	call := h.begin(auditMethodStream, messageTask(params))
+	return auditStream(ctx, call, h.RequestHandler.OnSendMessageStream(ctx, params))
+}
+
+// OnResubscribeToTask implements a2asrv.RequestHandler
+func (h *auditHandler) OnResubscribeToTask(ctx context.Context, params *a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	var info a2a.TaskInfo
+	if params != nil {
+		info.TaskID = params.ID
+	}
+	call := h.begin(auditMethodResubscribe, info)
+	return auditStream(ctx, call, h.RequestHandler.OnResubscribeToTask(ctx, params))
+}
+
+// OnGetTask implements a2asrv.RequestHandler
+func (h *auditHandler) OnGetTask(ctx context.Context, query *a2a.TaskQueryParams) (*a2a.Task, error) {
+	var info a2a.TaskInfo
+	if query != nil {
+		info.TaskID = query.ID
+	}
+	call := h.begin(auditMethodGetTask, info)
+	task, err := h.RequestHandler.OnGetTask(ctx, query)
+	if err == nil {
+		call.observe(task)
+	}
+	call.end(ctx, auditOutcomeOK, err)
+	return task, err
+}
+
+// OnListTasks implements a2asrv.RequestHandler
+func (h *auditHandler) OnListTasks(ctx context.Context, req *a2a.ListTasksRequest) (*a2a.ListTasksResponse, error) {
+	var info a2a.TaskInfo
+	if req != nil {
+		info.ContextID = req.ContextID
+	}
+	call := h.begin(auditMethodListTasks, info)
+	resp, err := h.RequestHandler.OnListTasks(ctx, req)
+	call.end(ctx, auditOutcomeOK, err)
+	return resp, err
+}
+
+// OnCancelTask implements a2asrv.RequestHandler
+func (h *auditHandler) OnCancelTask(ctx context.Context, params *a2a.TaskIDParams) (*a2a.Task, error) {
+	var info a2a.TaskInfo
+	if params != nil {
+		info.TaskID = params.ID
+	}
+	call := h.begin(auditMethodCancelTask, info)
+	task, err := h.RequestHandler.OnCancelTask(ctx, params)
+	if err == nil {
+		call.observe(task)
+	}
+	call.end(ctx, auditOutcomeOK, err)
+	return task, err
+}
+
+// OnGetTaskPushConfig implements a2asrv.RequestHandler
+func (h *auditHandler) OnGetTaskPushConfig(ctx context.Context, params *a2a.GetTaskPushConfigParams) (*a2a.TaskPushConfig, error) {
+	var info a2a.TaskInfo
+	if params != nil {
+		info.TaskID = params.TaskID
+	}
+	call := h.begin(auditMethodGetPushConfig, info)
+	config, err := h.RequestHandler.OnGetTaskPushConfig(ctx, params)
+	call.end(ctx, auditOutcomeOK, err)
+	return config, err
+}
+
+// OnListTaskPushConfig implements a2asrv.RequestHandler
+func (h *auditHandler) OnListTaskPushConfig(ctx context.Context, params *a2a.ListTaskPushConfigParams) ([]*a2a.TaskPushConfig, error) {
+	var info a2a.TaskInfo
+	if params != nil {
+		info.TaskID = params.TaskID
+	}
+	call := h.begin(auditMethodListPushConfigs, info)
+	configs, err := h.RequestHandler.OnListTaskPushConfig(ctx, params)
+	call.end(ctx, auditOutcomeOK, err)
+	return configs, err
+}
+
+// OnSetTaskPushConfig implements a2asrv.RequestHandler
+func (h *auditHandler) OnSetTaskPushConfig(ctx context.Context, params *a2a.TaskPushConfig) (*a2a.TaskPushConfig, error) {
+	var info a2a.TaskInfo
+	if params != nil {
+		info.TaskID = params.TaskID
+	}
+	call := h.begin(auditMethodSetPushConfig, info)
+	config, err := h.RequestHandler.OnSetTaskPushConfig(ctx, params)
+	call.end(ctx, auditOutcomeOK, err)
+	return config, err
+}
+
+// OnDeleteTaskPushConfig implements a2asrv.RequestHandler
+func (h *auditHandler) OnDeleteTaskPushConfig(ctx context.Context, params *a2a.DeleteTaskPushConfigParams) error {
+	var info a2a.TaskInfo
+	if params != nil {
+		info.TaskID = params.TaskID
+	}
+	call := h.begin(auditMethodDeletePushConfig, info)
+	err := h.RequestHandler.OnDeleteTaskPushConfig(ctx, params)
+	call.end(ctx, auditOutcomeOK, err)
+	return err
+}
+
+// OnGetExtendedAgentCard implements a2asrv.RequestHandler
+func (h *auditHandler) OnGetExtendedAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
+	call := h.begin(auditMethodGetExtendedCard, a2a.TaskInfo{})
+	card, err := h.RequestHandler.OnGetExtendedAgentCard(ctx)
+	call.end(ctx, auditOutcomeOK, err)
+	return card, err
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package server
+
+import (
+	"io"
+	"log/syslog"
+)
+
+// auditSyslogTag names the audit entries in syslog
+const auditSyslogTag = "aloha-audit"
+
+// openSyslog connects to the syslog daemon at addr over network, or to the local one when
+// both are empty. Entries are logged with the auth facility at info level.
+func openSyslog(network, addr string) (io.WriteCloser, error) {
+	return syslog.Dial(network, addr, syslog.LOG_AUTH|syslog.LOG_INFO, auditSyslogTag)
+}
@@ -0,0 +1,13 @@
+//go:build windows
+
+package server
+
+import (
+	"errors"
+	"io"
+)
+
+// openSyslog fails: Windows has no syslog daemon, so audit logs go to files
+func openSyslog(network, addr string) (io.WriteCloser, error) {
+	return nil, errors.New("syslog is not available on Windows, use a file")
+}
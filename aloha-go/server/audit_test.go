@@ -0,0 +1,117 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"iter"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// nopWriteCloser is an audit sink that keeps the entries in memory
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close implements io.Closer
+func (nopWriteCloser) Close() error { return nil }
+
+// failingHandler fails every call without looking at its params
+type failingHandler struct{}
+
+func (failingHandler) OnGetTask(context.Context, *a2a.TaskQueryParams) (*a2a.Task, error) {
+	return nil, a2a.ErrInvalidParams
+}
+
+func (failingHandler) OnListTasks(context.Context, *a2a.ListTasksRequest) (*a2a.ListTasksResponse, error) {
+	return nil, a2a.ErrInvalidParams
+}
+
+func (failingHandler) OnCancelTask(context.Context, *a2a.TaskIDParams) (*a2a.Task, error) {
+	return nil, a2a.ErrInvalidParams
+}
+
+func (failingHandler) OnSendMessage(context.Context, *a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	return nil, a2a.ErrInvalidParams
+}
+
+func (failingHandler) OnResubscribeToTask(context.Context, *a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) { yield(nil, a2a.ErrInvalidParams) }
+}
+
+func (failingHandler) OnSendMessageStream(context.Context, *a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	return func(yield func(a2a.Event, error) bool) { yield(nil, a2a.ErrInvalidParams) }
+}
+
+func (failingHandler) OnGetTaskPushConfig(context.Context, *a2a.GetTaskPushConfigParams) (*a2a.TaskPushConfig, error) {
+	return nil, a2a.ErrInvalidParams
+}
+
+func (failingHandler) OnListTaskPushConfig(context.Context, *a2a.ListTaskPushConfigParams) ([]*a2a.TaskPushConfig, error) {
+	return nil, a2a.ErrInvalidParams
+}
+
+func (failingHandler) OnSetTaskPushConfig(context.Context, *a2a.TaskPushConfig) (*a2a.TaskPushConfig, error) {
+	return nil, a2a.ErrInvalidParams
+}
+
+func (failingHandler) OnDeleteTaskPushConfig(context.Context, *a2a.DeleteTaskPushConfigParams) error {
+	return a2a.ErrInvalidParams
+}
+
+func (failingHandler) OnGetExtendedAgentCard(context.Context) (*a2a.AgentCard, error) {
+	return nil, a2a.ErrInvalidParams
+}
+
+// TestAuditNilParams checks that calls without params are audited, not a panic
+func TestAuditNilParams(t *testing.T) {
+	var out bytes.Buffer
+	handler := &auditHandler{
+		RequestHandler: failingHandler{},
+		log:            &AuditLog{out: nopWriteCloser{&out}, logger: NewLogger("server.audit")},
+	}
+
+	ctx := context.Background()
+	handler.OnSendMessage(ctx, nil)
+	for range handler.OnSendMessageStream(ctx, nil) {
+	}
+	for range handler.OnResubscribeToTask(ctx, nil) {
+	}
+	handler.OnGetTask(ctx, nil)
+	handler.OnListTasks(ctx, nil)
+	handler.OnCancelTask(ctx, nil)
+	handler.OnGetTaskPushConfig(ctx, nil)
+	handler.OnListTaskPushConfig(ctx, nil)
+	handler.OnSetTaskPushConfig(ctx, nil)
+	handler.OnDeleteTaskPushConfig(ctx, nil)
+
+	var methods []string
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var entry auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("audit entry %s: %v", scanner.Text(), err)
+		}
+		if entry.TaskID != "" || entry.ContextID != "" || entry.Outcome != auditOutcomeError {
+			t.Errorf("%s entry = %+v, want an error on no task", entry.Method, entry)
+		}
+		methods = append(methods, entry.Method)
+	}
+	want := []string{
+		auditMethodSend, auditMethodStream, auditMethodResubscribe, auditMethodGetTask, auditMethodListTasks,
+		auditMethodCancelTask, auditMethodGetPushConfig, auditMethodListPushConfigs, auditMethodSetPushConfig,
+		auditMethodDeletePushConfig,
+	}
+	if len(methods) != len(want) {
+		t.Fatalf("audited %v, want %v", methods, want)
+	}
+	for i := range want {
+		if methods[i] != want[i] {
+			t.Errorf("entry %d method = %s, want %s", i, methods[i], want[i])
+		}
+	}
+}
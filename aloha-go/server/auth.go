@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// principalMetadataKey is the RequestContext.Metadata key holding the authenticated *Principal
+const principalMetadataKey = "principal"
+
+// bearerSchemeName is the agent card security scheme name used when auth is enabled
+const bearerSchemeName = a2a.SecuritySchemeName("bearer")
+
+// Principal is the authenticated caller identity
+type Principal struct {
+	Subject string   `json:"subject"`
+	Scopes  []string `json:"scopes,omitempty"`
+}
+
+// Ensure Principal implements a2asrv.User
+var _ a2asrv.User = (*Principal)(nil)
+
+// Name implements a2asrv.User
+func (p *Principal) Name() string {
+	return p.Subject
+}
+
+// Authenticated implements a2asrv.User
+func (p *Principal) Authenticated() bool {
+	return true
+}
+
+// HasScope reports whether the principal was granted the scope
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// principalFrom returns the authenticated caller attached to the request context, if any
+func principalFrom(reqCtx *a2asrv.RequestContext) (*Principal, bool) {
+	principal, ok := reqCtx.Metadata[principalMetadataKey].(*Principal)
+	return principal, ok
+}
+
+// Ensure TokenAuthenticator implements a2asrv.CallInterceptor
+var _ a2asrv.CallInterceptor = (*TokenAuthenticator)(nil)
+
+// TokenAuthenticator validates bearer tokens and attaches the matching Principal to the call context
+type TokenAuthenticator struct {
+	a2asrv.PassthroughCallInterceptor
+	tokens map[string]*Principal
+	logger *Logger
+}
+
+// LoadTokenAuthenticator reads a JSON file mapping bearer tokens to principals:
+//
+//	{"secret-token": {"subject": "alice", "scopes": ["dice"]}}
+func LoadTokenAuthenticator(path string) (*TokenAuthenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read auth tokens file %s: %w", path, err)
+	}
+
+	tokens := make(map[string]*Principal)
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse auth tokens file %s: %w", path, err)
+	}
+	for token, principal := range tokens {
+		if principal == nil || principal.Subject == "" {
+			return nil, fmt.Errorf("auth token %q has no subject", maskToken(token))
+		}
+	}
+
+	return &TokenAuthenticator{tokens: tokens, logger: NewLogger("server.auth")}, nil
+}
+
+// Before implements a2asrv.CallInterceptor - rejects calls without a known bearer token
+func (t *TokenAuthenticator) Before(ctx context.Context, callCtx *a2asrv.CallContext, req *a2asrv.Request) (context.Context, error) {
+	values, _ := callCtx.RequestMeta().Get("authorization")
+	if principal, ok := t.authenticate(values); ok {
+		callCtx.User = principal
+		return ctx, nil
+	}
+
+	t.logger.Warn("Rejected unauthenticated %s call", callCtx.Method())
+	return ctx, a2a.ErrUnauthenticated
+}
+
+// authenticate returns the principal of the first known bearer token among the
+// Authorization header values
+func (t *TokenAuthenticator) authenticate(values []string) (*Principal, bool) {
+	for _, value := range values {
+		token, ok := strings.CutPrefix(value, "Bearer ")
+		if !ok {
+			continue
+		}
+		if principal, ok := t.tokens[strings.TrimSpace(token)]; ok {
+			return principal, true
+		}
+	}
+	return nil, false
+}
+
+// Ensure principalInterceptor implements a2asrv.RequestContextInterceptor
+var _ a2asrv.RequestContextInterceptor = (*principalInterceptor)(nil)
+
+// principalInterceptor copies the authenticated caller from the call context into RequestContext.Metadata.
+// It must run after messageMetadataInterceptor so clients cannot spoof the principal via message metadata.
+type principalInterceptor struct{}
+
+// Intercept implements a2asrv.RequestContextInterceptor
+func (principalInterceptor) Intercept(ctx context.Context, reqCtx *a2asrv.RequestContext) (context.Context, error) {
+	metadata := maps.Clone(reqCtx.Metadata)
+	delete(metadata, principalMetadataKey)
+
+	if callCtx, ok := a2asrv.CallContextFrom(ctx); ok {
+		if principal, ok := callCtx.User.(*Principal); ok {
+			if metadata == nil {
+				metadata = make(map[string]any)
+			}
+			metadata[principalMetadataKey] = principal
+		}
+	}
+
+	reqCtx.Metadata = metadata
+	return ctx, nil
+}
+
+// maskToken hides all but the first characters of a token for logging
+func maskToken(token string) string {
+	if len(token) <= 4 {
+		return "****"
+	}
+	return token[:4] + "****"
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// Policies applied when a client reads a task's events more slowly than they are produced
+const (
+	overflowBlock      = "block"       // wait for the client, holding up the task
+	overflowDropOldest = "drop-oldest" // drop the oldest event and warn the client
+	overflowFail       = "fail"        // end the client's stream with an error
+)
+
+// defaultStreamBuffer is the number of events buffered per client, as in the SDK's queues
+const defaultStreamBuffer = 32
+
+// droppedEventsMetadataKey is the metadata key of the warning event, holding the number
+// of events dropped
+const droppedEventsMetadataKey = "droppedEvents"
+
+// streamMetrics counts the events that found a client's buffer full, the events dropped,
+// the streams failed, and the clients currently reading, published at /debug/vars under
+// stream_queues
+var streamMetrics = expvar.NewMap("stream_queues")
+
+// errSlowConsumer ends the stream of a client whose buffer is full under the fail policy
+var errSlowConsumer = errors.New("the client reads events too slowly: its stream buffer is full")
+
+// streamBackpressure is the buffer of each client reading a task's events, and what
+// happens once it is full
+type streamBackpressure struct {
+	buffer int
+	policy string
+}
+
+// SetStreamBackpressure sets the events buffered per client streaming a task, and the
+// policy applied when a slow client fills its buffer: block, drop-oldest or fail. It
+// must be called before Start.
+func (a *AlohaServer) SetStreamBackpressure(buffer int, policy string) error {
+	switch policy {
+	case overflowBlock, overflowDropOldest, overflowFail:
+	default:
+		return fmt.Errorf("invalid stream overflow policy %q: expected %s, %s or %s", policy, overflowBlock, overflowDropOldest, overflowFail)
+	}
+	if buffer < 1 {
+		return fmt.Errorf("invalid stream buffer %d: must be at least 1", buffer)
+	}
+	a.queues.backpressure = streamBackpressure{buffer: buffer, policy: policy}
+	return nil
+}
+
+// streamQueue is the queue of a client reading a task's events. A pump moves the events
+// from the task's queue to a buffer the client reads, so that the policy, not the SDK's
+// queue, decides what happens when the buffer is full.
+type streamQueue struct {
+	eventqueue.Queue
+	backpressure streamBackpressure
+	taskID       a2a.TaskID
+
+	events  chan replayEvent
+	dropped atomic.Int64
+	failed  chan struct{}
+
+	// The task's context and last state, for the warning of dropped events
+	mu        sync.Mutex
+	contextID string
+	state     a2a.TaskState
+
+	stop      context.CancelFunc
+	pumped    chan struct{}
+	closeOnce sync.Once
+}
+
+// newStreamQueue starts pumping the events of a task's queue into a client's buffer
+func newStreamQueue(queue eventqueue.Queue, taskID a2a.TaskID, backpressure streamBackpressure) *streamQueue {
+	ctx, stop := context.WithCancel(context.Background())
+	q := &streamQueue{
+		Queue:        queue,
+		backpressure: backpressure,
+		taskID:       taskID,
+		events:       make(chan replayEvent, backpressure.buffer),
+		failed:       make(chan struct{}),
+		state:        a2a.TaskStateWorking,
+		stop:         stop,
+		pumped:       make(chan struct{}),
+	}
+	streamMetrics.Add("clients", 1)
+	go q.pump(ctx)
+	return q
+}
+
+// pump moves events to the buffer until the task's queue is closed or the client leaves
+func (q *streamQueue) pump(ctx context.Context) {
+	defer close(q.pumped)
+	defer close(q.events)
+	defer streamMetrics.Add("clients", -1)
+	for {
+		event, version, err := q.Queue.Read(ctx)
+		if err != nil {
+			return
+		}
+		e := replayEvent{event: event, version: version}
+		select {
+		case q.events <- e:
+			continue
+		default:
+		}
+
+		// The buffer is full
+		streamMetrics.Add("saturated", 1)
+		switch q.backpressure.policy {
+		case overflowDropOldest:
+			// The pump is the only writer of the buffer, so the send cannot block
+			select {
+			case oldest := <-q.events:
+				q.observe(oldest.event)
+				q.dropped.Add(1)
+				streamMetrics.Add("dropped", 1)
+			default:
+			}
+			q.events <- e
+		case overflowFail:
+			streamMetrics.Add("failed", 1)
+			close(q.failed)
+			return
+		default:
+			select {
+			case q.events <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// Read implements eventqueue.Reader. After dropped events, the client first receives a
+// status update warning about them.
+func (q *streamQueue) Read(ctx context.Context) (a2a.Event, a2a.TaskVersion, error) {
+	if n := q.dropped.Swap(0); n > 0 {
+		return q.droppedWarning(n), a2a.TaskVersionMissing, nil
+	}
+	select {
+	case <-q.failed:
+		return nil, a2a.TaskVersionMissing, errSlowConsumer
+	default:
+	}
+	select {
+	case e, ok := <-q.events:
+		if !ok {
+			return nil, a2a.TaskVersionMissing, eventqueue.ErrQueueClosed
+		}
+		q.observe(e.event)
+		return e.event, e.version, nil
+	case <-q.failed:
+		return nil, a2a.TaskVersionMissing, errSlowConsumer
+	case <-ctx.Done():
+		return nil, a2a.TaskVersionMissing, ctx.Err()
+	}
+}
+
+// observe keeps the task's context and last state from an event read or dropped
+func (q *streamQueue) observe(event a2a.Event) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if contextID := event.TaskInfo().ContextID; contextID != "" {
+		q.contextID = contextID
+	}
+	switch e := event.(type) {
+	case *a2a.Task:
+		q.state = e.Status.State
+	case *a2a.TaskStatusUpdateEvent:
+		q.state = e.Status.State
+	}
+}
+
+// droppedWarning returns the status update telling the client that events were dropped.
+// The task keeps its state; the number of events is in the droppedEvents metadata.
+func (q *streamQueue) droppedWarning(n int64) *a2a.TaskStatusUpdateEvent {
+	q.mu.Lock()
+	task := a2a.TaskInfo{TaskID: q.taskID, ContextID: q.contextID}
+	state := q.state
+	q.mu.Unlock()
+
+	text := fmt.Sprintf("%d events were dropped because the client reads them too slowly", n)
+	event := a2a.NewStatusUpdateEvent(task, state, a2a.NewMessageForTask(a2a.MessageRoleAgent, task, a2a.TextPart{Text: text}))
+	event.Metadata = map[string]any{droppedEventsMetadataKey: n}
+	return event
+}
+
+// Close implements eventqueue.Queue, stopping the pump before closing the task's queue
+func (q *streamQueue) Close() error {
+	var err error
+	q.closeOnce.Do(func() {
+		q.stop()
+		<-q.pumped
+		err = q.Queue.Close()
+	})
+	return err
+}
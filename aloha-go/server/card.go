@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// AgentCard returns the currently published agent card.
+// It is served through a2asrv.AgentCardProducerFn so card handlers always see the latest reload.
+func (a *AlohaServer) AgentCard(ctx context.Context) (*a2a.AgentCard, error) {
+	a.cardMu.RLock()
+	defer a.cardMu.RUnlock()
+	return a.agentCard, nil
+}
+
+// ReloadAgentCard rebuilds the agent card and overlays the contents of the
+// configured card file on top of it. Open transports and streaming clients are
+// not affected; only subsequent agent card requests see the new card.
+func (a *AlohaServer) ReloadAgentCard() error {
+	card := a.createAgentCard()
+
+	if a.cardFile != "" {
+		data, err := os.ReadFile(a.cardFile)
+		if err != nil {
+			return fmt.Errorf("failed to read agent card file %s: %w", a.cardFile, err)
+		}
+		// Fields present in the file replace the built-in defaults, missing ones are kept
+		if err := json.Unmarshal(data, card); err != nil {
+			return fmt.Errorf("failed to parse agent card file %s: %w", a.cardFile, err)
+		}
+	}
+
+	a.cardMu.Lock()
+	a.agentCard = card
+	a.cardMu.Unlock()
+
+	a.logger.Info("Agent card loaded: %s (v%s), %d skill(s)", card.Name, card.Version, len(card.Skills))
+	return nil
+}
+
+// handleAdminReloadCard handles POST /admin/reload-card
+func (a *AlohaServer) handleAdminReloadCard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	if err := a.ReloadAgentCard(); err != nil {
+		a.logger.Error("Agent card reload failed: %v", err)
+		writeRESTError(w, protocol.ErrInternal.Withf("%v", err))
+		return
+	}
+
+	card, _ := a.AgentCard(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(card)
+}
@@ -0,0 +1,272 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/cli"
+	"github.com/aloha/a2a-go/pkg/tools"
+	"github.com/spf13/cobra"
+)
+
+// Config is the configuration of the Dice Agent server
+type Config struct {
+	GRPCPort       int
+	JSONRPCPort    int
+	RESTPort       int
+	Host           string
+	TransportMode  string
+	CardFile       string
+	AuthTokensFile string
+	DiceSeed       string // makes dice rolls reproducible, e.g. for integration tests
+
+	RegistryURL      string // agent registry to register with
+	RegistryAgentURL string // card URL registered; empty uses the local one
+
+	MDNSAdvertise bool
+	MDNSInstance  string
+	MDNSAgentURL  string
+
+	MCPServers []string // MCP servers whose tools are offered to the LLM
+
+	FeaturesFile string // feature flags overriding their defaults
+
+	AuditLog string // file or syslog target recording every A2A call
+
+	ReplayBuffer int // events kept per running task for resubscribing clients
+
+	StreamBuffer   int    // events buffered per client streaming a task
+	StreamOverflow string // what happens when a slow client fills its buffer
+
+	MaxRequestSize  int // bytes of a request body or gRPC message
+	MaxPartSize     int // bytes of each part of an incoming message
+	MaxArtifactSize int // bytes of each artifact the agent writes
+
+	LameDuck     time.Duration // lame-duck period between SIGTERM and the shutdown
+	DrainTimeout time.Duration // bounds the wait for running calls once shutting down
+}
+
+// fileConfig is the part of the config file read by aloha serve: flag values by name
+type fileConfig struct {
+	Serve map[string]string `yaml:"serve"`
+}
+
+// Command returns the aloha serve command, which runs the Dice Agent. Its flags default
+// to the environment variables the server has always read, then to the serve section
+// of the config file.
+func Command() *cobra.Command {
+	var cfg Config
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the Dice Agent on the gRPC, JSON-RPC and REST transports",
+		Example: `  aloha serve
+  aloha serve --transport rest --auth-tokens tokens.json
+  aloha serve --auth-tokens tokens.json --audit-log /var/log/aloha/audit.jsonl
+  REGISTRY_URL=http://localhost:12100 aloha serve`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.ApplyEnv(cmd.Flags()); err != nil {
+				return err
+			}
+			var file fileConfig
+			path, _, err := cli.ReadConfig(&file)
+			if err != nil {
+				return err
+			}
+			return cli.ApplyDefaults(cmd.Flags(), file.Serve, path)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Run(cmd.Context(), cfg)
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.IntVar(&cfg.GRPCPort, "grpc-port", 12000, "gRPC port")
+	fs.IntVar(&cfg.JSONRPCPort, "jsonrpc-port", 12001, "JSON-RPC port")
+	fs.IntVar(&cfg.RESTPort, "rest-port", 12002, "REST port")
+	fs.StringVar(&cfg.Host, "host", "0.0.0.0", "Address to listen on")
+	fs.StringVar(&cfg.TransportMode, "transport", "jsonrpc", "Transport the agent card prefers and log files are named after: grpc, jsonrpc or rest")
+	fs.StringVar(&cfg.CardFile, "card-file", "", "JSON agent card whose fields override the built-in one")
+	fs.StringVar(&cfg.AuthTokensFile, "auth-tokens", "", "JSON file mapping bearer tokens to callers and their scopes; enables authentication")
+	fs.StringVar(&cfg.DiceSeed, "dice-seed", "", "Seed making dice rolls, coin flips and random numbers reproducible")
+	fs.StringVar(&cfg.RegistryURL, "registry", "", "Agent registry to register with while the server runs")
+	fs.StringVar(&cfg.RegistryAgentURL, "registry-agent-url", "", "Card URL registered with --registry (default the local card URL)")
+	fs.BoolVar(&cfg.MDNSAdvertise, "mdns", false, "Advertise the agent on the local network with mDNS")
+	fs.StringVar(&cfg.MDNSInstance, "mdns-instance", "", "mDNS instance name (default the agent name)")
+	fs.StringVar(&cfg.MDNSAgentURL, "mdns-agent-url", "", "Card URL advertised with --mdns (default the host address and card port)")
+	fs.StringSliceVar(&cfg.MCPServers, "mcp-server", nil, "MCP server whose tools the LLM may call: an http(s) URL or a command; repeatable")
+	fs.StringVar(&cfg.FeaturesFile, "features-file", "", "YAML or JSON file turning feature flags on or off; ALOHA_FEATURE_* variables override it")
+	fs.StringVar(&cfg.AuditLog, "audit-log", "", "Record every A2A call as JSON lines: a file, syslog, or syslog://host:port (syslog+tcp:// for TCP)")
+	fs.IntVar(&cfg.ReplayBuffer, "replay-buffer", defaultReplayBuffer, "Events kept per running task and replayed to clients resubscribing mid-task (0 disables replay)")
+	fs.IntVar(&cfg.StreamBuffer, "stream-buffer", defaultStreamBuffer, "Events buffered per client streaming a task")
+	fs.StringVar(&cfg.StreamOverflow, "stream-overflow", overflowBlock, "When a slow client fills its stream buffer: block the task, drop-oldest events with a warning, or fail the client's stream")
+	fs.IntVar(&cfg.MaxRequestSize, "max-request-size", defaultMaxRequestSize, "Largest request body or gRPC message accepted, in bytes (0 disables the limit)")
+	fs.IntVar(&cfg.MaxPartSize, "max-part-size", defaultMaxPartSize, "Largest part of an incoming message, in bytes once decoded (0 disables the limit)")
+	fs.IntVar(&cfg.MaxArtifactSize, "max-artifact-size", defaultMaxArtifactSize, "Largest artifact the agent writes, in bytes across its chunks (0 disables the limit)")
+	fs.DurationVar(&cfg.LameDuck, "lame-duck", 5*time.Second, "After SIGTERM, how long /readyz fails and new calls are rejected before the transports stop (0 stops at once)")
+	fs.DurationVar(&cfg.DrainTimeout, "drain-timeout", 25*time.Second, "How long stopping transports wait for running calls and streams (0 waits for them all)")
+	for name, env := range map[string]string{
+		"grpc-port":          "GRPC_PORT",
+		"jsonrpc-port":       "JSONRPC_PORT",
+		"rest-port":          "REST_PORT",
+		"host":               "HOST",
+		"transport":          "TRANSPORT_MODE",
+		"card-file":          "AGENT_CARD_FILE",
+		"auth-tokens":        "AUTH_TOKENS_FILE",
+		"dice-seed":          "DICE_SEED",
+		"registry":           "REGISTRY_URL",
+		"registry-agent-url": "REGISTRY_AGENT_URL",
+		"mdns":               "MDNS_ADVERTISE",
+		"mdns-instance":      "MDNS_INSTANCE",
+		"mdns-agent-url":     "MDNS_AGENT_URL",
+		"mcp-server":         "MCP_SERVERS",
+		"features-file":      "FEATURES_FILE",
+		"audit-log":          "AUDIT_LOG",
+		"replay-buffer":      "REPLAY_BUFFER",
+		"stream-buffer":      "STREAM_BUFFER",
+		"stream-overflow":    "STREAM_OVERFLOW",
+		"max-request-size":   "MAX_REQUEST_SIZE",
+		"max-part-size":      "MAX_PART_SIZE",
+		"max-artifact-size":  "MAX_ARTIFACT_SIZE",
+		"lame-duck":          "LAME_DUCK_PERIOD",
+		"drain-timeout":      "DRAIN_TIMEOUT",
+	} {
+		cli.BindEnv(fs, name, env)
+	}
+	return cmd
+}
+
+// Run runs the Dice Agent until ctx is done or an interrupt or SIGTERM is received.
+// SIGTERM first puts the agent in lame-duck mode for cfg.LameDuck, so that rolling
+// deployments drain it instead of dropping its tasks; a second signal ends it early.
+// SIGHUP reloads the agent card.
+func Run(ctx context.Context, cfg Config) error {
+	// Initialize log file output
+	InitLogFile(cfg.TransportMode)
+
+	serverLogger := NewLogger("server.main")
+
+	if cfg.DiceSeed != "" {
+		value, err := strconv.ParseUint(cfg.DiceSeed, 10, 64)
+		if err != nil {
+			serverLogger.Fatal("Invalid dice seed %q: %v", cfg.DiceSeed, err)
+		}
+		tools.SetRand(tools.NewSeededRand(value))
+		serverLogger.Warn("A dice seed is set: dice rolls are deterministic")
+	}
+
+	// Authentication is enabled when a tokens file is configured
+	var authenticator *TokenAuthenticator
+	if cfg.AuthTokensFile != "" {
+		var err error
+		authenticator, err = LoadTokenAuthenticator(cfg.AuthTokensFile)
+		if err != nil {
+			serverLogger.Fatal("Failed to load auth tokens: %v", err)
+		}
+	}
+
+	// Experimental behaviors are turned on per deployment
+	features, err := loadFeatureFlags(cfg.FeaturesFile)
+	if err != nil {
+		serverLogger.Fatal("Failed to load feature flags: %v", err)
+	}
+	for _, state := range features.States() {
+		serverLogger.Info("Feature %s: %v (%s)", state.Name, state.Enabled, state.Source)
+	}
+
+	// Offer the tools of external MCP servers alongside the builtin ones
+	executor := NewDiceAgentExecutor()
+	executor.streamTokens = features.Enabled(featureStreamingTokens)
+	executor.ConnectMCPServers(ctx, cfg.MCPServers)
+	defer executor.Close()
+
+	// Create server
+	server := newAlohaServer(cfg.GRPCPort, cfg.JSONRPCPort, cfg.RESTPort, cfg.Host, cfg.TransportMode, cfg.CardFile, authenticator, executor, features)
+	server.SetDrainTimeout(cfg.DrainTimeout)
+	server.SetReplayBuffer(cfg.ReplayBuffer)
+	if err := server.SetStreamBackpressure(cfg.StreamBuffer, cfg.StreamOverflow); err != nil {
+		serverLogger.Fatal("%v", err)
+	}
+	server.SetSizeLimits(cfg.MaxRequestSize, cfg.MaxPartSize, cfg.MaxArtifactSize)
+
+	// Prime checks are served by their own executor, behind the same skill router
+	if err := server.RegisterExecutor(NewPrimeAgentExecutor(executor)); err != nil {
+		serverLogger.Fatal("Failed to register prime executor: %v", err)
+	}
+
+	// Record who called what in an audit log separate from the server log
+	if cfg.AuditLog != "" {
+		auditLog, err := OpenAuditLog(cfg.AuditLog)
+		if err != nil {
+			serverLogger.Fatal("%v", err)
+		}
+		defer auditLog.Close()
+		server.EnableAuditLog(auditLog)
+		serverLogger.Info("Audit log: %s", cfg.AuditLog)
+	}
+
+	// Register with the agent registry when one is configured
+	if cfg.RegistryURL != "" {
+		server.EnableRegistration(cfg.RegistryURL, cfg.RegistryAgentURL)
+	}
+
+	// Advertise the agent on the local network with mDNS when enabled
+	if cfg.MDNSAdvertise {
+		server.EnableMDNS(cfg.MDNSInstance, cfg.MDNSAgentURL)
+	}
+
+	// Setup context with cancellation
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Handle shutdown signals
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	go func() {
+		select {
+		case sig := <-sigChan:
+			if sig == syscall.SIGTERM && cfg.LameDuck > 0 {
+				serverLogger.Info("SIGTERM received, lame-duck mode for %s before stopping...", cfg.LameDuck)
+				server.EnterLameDuck()
+				select {
+				case <-time.After(cfg.LameDuck):
+				case <-sigChan:
+				case <-ctx.Done():
+				}
+			}
+			serverLogger.Info("Shutdown signal received, stopping Dice Agent...")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// Reload the agent card on SIGHUP
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	defer signal.Stop(hupChan)
+
+	go func() {
+		for range hupChan {
+			serverLogger.Info("SIGHUP received, reloading agent card...")
+			if err := server.ReloadAgentCard(); err != nil {
+				serverLogger.Error("Agent card reload failed: %v", err)
+			}
+		}
+	}()
+
+	// Start server
+	if err := server.Start(ctx); err != nil && err != http.ErrServerClosed {
+		serverLogger.Error("Server error: %v", err)
+		return err
+	}
+
+	serverLogger.Info("Dice Agent stopped")
+	return nil
+}
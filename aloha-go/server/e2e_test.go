@@ -0,0 +1,113 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/mockllm"
+	"github.com/ollama/ollama/api"
+)
+
+// primeLLM calls check_prime on 17 and 18, then answers with the tool's result
+var primeLLM = mockllm.ToolResults(func(prompt string) api.Message {
+	return mockllm.CallTool("check_prime", map[string]any{"numbers": []any{"17", "18"}})
+})
+
+// TestEndToEnd sends a message over each transport to a server backed by the mock LLM,
+// and checks that the tool's result comes back as the task's artifact
+func TestEndToEnd(t *testing.T) {
+	h, err := startHarness(primeLLM)
+	if err != nil {
+		t.Fatalf("startHarness: %v", err)
+	}
+	defer h.Close()
+
+	sends := map[string]func(ctx context.Context, msg *a2a.Message) (*a2a.Task, error){
+		"jsonrpc": func(ctx context.Context, msg *a2a.Message) (*a2a.Task, error) {
+			return h.sendSDK(ctx, a2a.TransportProtocolJSONRPC, msg)
+		},
+		"grpc": func(ctx context.Context, msg *a2a.Message) (*a2a.Task, error) {
+			return h.sendSDK(ctx, a2a.TransportProtocolGRPC, msg)
+		},
+		"rest": h.sendREST,
+	}
+	for name, send := range sends {
+		t.Run(name, func(t *testing.T) {
+			msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: "Are 17 and 18 prime?"})
+			task, err := send(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+			if task.Status.State != a2a.TaskStateCompleted {
+				t.Fatalf("task state = %s, want %s", task.Status.State, a2a.TaskStateCompleted)
+			}
+			if text := artifactText(task); !strings.Contains(text, "17 are prime numbers.") {
+				t.Errorf("artifact text = %q, want the check_prime result", text)
+			}
+		})
+	}
+
+	if got := len(h.LLM.Requests()); got != 2*len(sends) {
+		t.Errorf("mock LLM received %d chat requests, want %d: a tool call and a reply per message", got, 2*len(sends))
+	}
+}
+
+// sendSDK sends a blocking message with an SDK client over a JSON-RPC or gRPC transport
+func (h *harness) sendSDK(ctx context.Context, transport a2a.TransportProtocol, msg *a2a.Message) (*a2a.Task, error) {
+	client, err := h.Client(ctx, transport)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Destroy()
+	result, err := client.SendMessage(ctx, &a2a.MessageSendParams{Message: msg})
+	if err != nil {
+		return nil, err
+	}
+	task, ok := result.(*a2a.Task)
+	if !ok {
+		return nil, fmt.Errorf("the agent answered with %T, not a task", result)
+	}
+	return task, nil
+}
+
+// sendREST sends a blocking message to POST /v1/message:send
+func (h *harness) sendREST(ctx context.Context, msg *a2a.Message) (*a2a.Task, error) {
+	body, err := json.Marshal(a2a.MessageSendParams{Message: msg})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.RESTURL+"/v1/message:send", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var task a2a.Task
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// artifactText joins the text parts of the task's artifacts
+func artifactText(task *a2a.Task) string {
+	var text strings.Builder
+	for _, artifact := range task.Artifacts {
+		for _, part := range artifact.Parts {
+			if p, ok := part.(a2a.TextPart); ok {
+				text.WriteString(p.Text)
+			}
+		}
+	}
+	return text.String()
+}
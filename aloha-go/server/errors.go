@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// sdkErrors maps the SDK's error sentinels to the protocol errors reported over REST
+var sdkErrors = []struct {
+	sdk   error
+	proto *protocol.Error
+}{
+	{a2a.ErrParseError, protocol.ErrParse},
+	{a2a.ErrInvalidRequest, protocol.ErrInvalidRequest},
+	{a2a.ErrMethodNotFound, protocol.ErrMethodNotFound},
+	{a2a.ErrInvalidParams, protocol.ErrInvalidParams},
+	{a2a.ErrInternalError, protocol.ErrInternal},
+	{a2a.ErrTaskNotFound, protocol.ErrTaskNotFound},
+	{a2a.ErrTaskNotCancelable, protocol.ErrTaskNotCancelable},
+	{a2a.ErrPushNotificationNotSupported, protocol.ErrPushNotificationNotSupported},
+	{a2a.ErrUnsupportedOperation, protocol.ErrUnsupportedOperation},
+	{a2a.ErrUnsupportedContentType, protocol.ErrContentTypeNotSupported},
+	{a2a.ErrInvalidAgentResponse, protocol.ErrInvalidAgentResponse},
+	{a2a.ErrAuthenticatedExtendedCardNotConfigured, protocol.ErrExtendedCardNotConfigured},
+	{a2a.ErrUnauthenticated, protocol.ErrUnauthenticated},
+	{a2a.ErrUnauthorized, protocol.ErrUnauthorized},
+}
+
+// restError converts an error to the protocol error reported to REST callers.
+// Errors the SDK does not classify are reported with the fallback's code.
+func restError(err error, fallback *protocol.Error) *protocol.Error {
+	var protoErr *protocol.Error
+	if errors.As(err, &protoErr) {
+		return protoErr
+	}
+	code := fallback.Code
+	for _, known := range sdkErrors {
+		if errors.Is(err, known.sdk) {
+			code = known.proto.Code
+			break
+		}
+	}
+	return &protocol.Error{Code: code, Message: err.Error()}
+}
+
+// writeRESTError writes a protocol error in the Google API style with its HTTP status
+func writeRESTError(w http.ResponseWriter, err *protocol.Error) {
+	writeRESTErrorStatus(w, err.HTTPStatus(), err)
+}
+
+// writeRESTErrorStatus writes a protocol error in the Google API style with the given
+// HTTP status: {"error": {"code", "message", "status", "details"}}, the A2A error code
+// being in the ErrorInfo details
+func writeRESTErrorStatus(w http.ResponseWriter, status int, err *protocol.Error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(err.HTTPError(status))
+}
+
+// writeMethodNotAllowed rejects a request whose HTTP method the endpoint does not serve
+func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeRESTErrorStatus(w, http.StatusMethodNotAllowed, protocol.ErrMethodNotFound.Withf("%s %s", r.Method, r.URL.Path))
+}
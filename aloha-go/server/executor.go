@@ -1,17 +1,25 @@
-package main
+package server
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/a2aproject/a2a-go/a2a"
 	"github.com/a2aproject/a2a-go/a2asrv"
 	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/aloha/a2a-go/pkg/mcp"
+	"github.com/aloha/a2a-go/pkg/protocol"
+	"github.com/aloha/a2a-go/pkg/tools"
 	"github.com/ollama/ollama/api"
 )
 
@@ -20,7 +28,19 @@ const systemPrompt = `You are a dice rolling agent that can roll arbitrary N-sid
 
 When asked to roll a dice, call the roll_dice tool with the number of sides as an integer parameter.
 
-When asked to check if numbers are prime, call the check_prime tool with a list of integers.
+When asked to roll several dice at once, such as "roll 3d6", call the roll_dice_multi tool with the number of dice and the number of sides.
+
+When asked for statistics or the distribution of many dice rolls, call the roll_dice_stats tool with the number of dice and the number of sides.
+
+When asked to check if numbers are prime, call the check_prime tool with a list of integers written as decimal strings, such as ["17", "170141183460469231731687303715884105727"].
+
+When asked to flip or toss a coin, or for heads or tails, call the flip_coin tool.
+
+When asked to pick or choose one of several options, call the choose tool with the options as a list of strings.
+
+When asked for a random number in a range, call the random_int tool with min and max; do not use a dice for it.
+
+When asked to generate UUIDs, call the generate_uuid tool with how many to generate.
 
 When asked to roll a dice and check if the result is prime:
 1. First call roll_dice to get the result
@@ -32,17 +52,11 @@ Be conversational and friendly in your responses.
 
 你是一个骰子代理，可以投掷任意面数的骰子并检查数字是否为质数�?当被要求投掷骰子时，使用 roll_dice 工具�?当被要求检查质数时，使�?check_prime 工具�?始终使用工具，不要自己计算。`
 
-// ValidationError represents a request validation error
-type ValidationError struct {
-	Message string
-}
-
-func (e *ValidationError) Error() string {
-	return e.Message
-}
+// defaultArtifactChunkSize is the maximum artifact chunk size in bytes
+const defaultArtifactChunkSize = 4096
 
-// Ensure DiceAgentExecutor implements a2asrv.AgentExecutor
-var _ a2asrv.AgentExecutor = (*DiceAgentExecutor)(nil)
+// Ensure DiceAgentExecutor implements SkillExecutor
+var _ SkillExecutor = (*DiceAgentExecutor)(nil)
 
 // DiceAgentExecutor implements the a2asrv.AgentExecutor interface
 type DiceAgentExecutor struct {
@@ -50,56 +64,175 @@ type DiceAgentExecutor struct {
 	ollamaModel  string
 	baseURL      string
 	useLLM       bool
+	streamTokens bool // stream the LLM's answer as it is generated
+	chunkSize    int
+	tools        *tools.Registry
+	toolCache    *toolCache
+	mcpClients   []*mcp.Client
 	logger       *Logger
+
+	// prompt is the LLM's system prompt and patterns answers without the LLM
+	prompt   string
+	patterns func(ctx context.Context, messageText string, data *toolData) (string, error)
 }
 
-// NewDiceAgentExecutor creates a new executor instance
+// NewDiceAgentExecutor creates a new executor instance, connected to the Ollama server
+// at OLLAMA_BASE_URL (or OLLAMA_HOST) if it is reachable
 func NewDiceAgentExecutor() *DiceAgentExecutor {
 	baseURL := os.Getenv("OLLAMA_BASE_URL")
-	if baseURL == "" {
-		baseURL = "http://localhost:11434"
-	}
 
 	model := os.Getenv("OLLAMA_MODEL")
 	if model == "" {
 		model = "qwen2.5"
 	}
 
+	// Try to create Ollama client
+	var client *api.Client
+	var err error
+	if baseURL != "" {
+		client, err = newOllamaClient(baseURL)
+	} else {
+		baseURL = "http://localhost:11434"
+		client, err = api.ClientFromEnvironment()
+	}
+
+	executor := newDiceAgentExecutor(baseURL, model)
+	if err != nil {
+		executor.logger.Warn("Failed to create Ollama client: %v", err)
+		executor.logger.Warn("Will use fallback pattern matching instead")
+		return executor
+	}
+	executor.connectOllama(client)
+	return executor
+}
+
+// newDiceAgentExecutor creates an executor that answers with pattern matching until
+// connectOllama connects it to an LLM
+func newDiceAgentExecutor(baseURL, model string) *DiceAgentExecutor {
+	chunkSize := defaultArtifactChunkSize
+	if value := os.Getenv("ARTIFACT_CHUNK_SIZE"); value != "" {
+		if size, err := strconv.Atoi(value); err == nil && size > 0 {
+			chunkSize = size
+		}
+	}
+
 	executor := &DiceAgentExecutor{
 		baseURL:     baseURL,
 		ollamaModel: model,
-		useLLM:      true,
+		chunkSize:   chunkSize,
+		tools:       tools.NewRegistry(tools.Builtin()...),
 		logger:      NewLogger("server.executor"),
+		prompt:      systemPrompt,
+	}
+	executor.patterns = executor.processWithPatterns
+
+	// Results of deterministic tools are cached when TOOL_CACHE_TTL is set
+	if value := os.Getenv("TOOL_CACHE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			executor.logger.Warn("Ignoring invalid TOOL_CACHE_TTL %q", value)
+		} else if ttl > 0 {
+			size := defaultToolCacheSize
+			if value := os.Getenv("TOOL_CACHE_SIZE"); value != "" {
+				if n, err := strconv.Atoi(value); err == nil && n > 0 {
+					size = n
+				}
+			}
+			executor.toolCache = newToolCache(ttl, size)
+			executor.logger.Info("Tool result cache enabled: ttl=%s, size=%d", ttl, size)
+		}
 	}
 
-	// Try to create Ollama client
-	client, err := api.ClientFromEnvironment()
+	return executor
+}
+
+// newOllamaClient creates an Ollama client for the server at baseURL
+func newOllamaClient(baseURL string) (*api.Client, error) {
+	u, err := url.Parse(baseURL)
 	if err != nil {
-		executor.logger.Warn("Failed to create Ollama client: %v", err)
-		executor.logger.Warn("Will use fallback pattern matching instead")
-		executor.useLLM = false
-		return executor
+		return nil, fmt.Errorf("invalid Ollama URL %q: %w", baseURL, err)
 	}
+	return api.NewClient(u, http.DefaultClient), nil
+}
 
-	executor.ollamaClient = client
+// connectOllama answers with the LLM through client if the Ollama server is reachable,
+// and keeps the pattern matching fallback otherwise
+func (e *DiceAgentExecutor) connectOllama(client *api.Client) {
+	e.ollamaClient = client
 
 	// Validate Ollama connection
-	if err := executor.validateOllamaConnection(); err != nil {
-		executor.logger.Warn("Ollama connection validation failed: %v", err)
-		executor.logger.Warn("Please ensure Ollama is installed and running:")
-		executor.logger.Warn("  1. Install Ollama: https://ollama.ai/download")
-		executor.logger.Warn("  2. Pull %s model: ollama pull %s", model, model)
-		executor.logger.Warn("  3. Start Ollama service: ollama serve")
-		executor.logger.Warn("Will use fallback pattern matching instead")
-		executor.useLLM = false
-		return executor
+	if err := e.validateOllamaConnection(); err != nil {
+		e.logger.Warn("Ollama connection validation failed: %v", err)
+		e.logger.Warn("Please ensure Ollama is installed and running:")
+		e.logger.Warn("  1. Install Ollama: https://ollama.ai/download")
+		e.logger.Warn("  2. Pull %s model: ollama pull %s", e.ollamaModel, e.ollamaModel)
+		e.logger.Warn("  3. Start Ollama service: ollama serve")
+		e.logger.Warn("Will use fallback pattern matching instead")
+		e.useLLM = false
+		return
 	}
 
-	executor.logger.Info("Successfully connected to Ollama")
-	executor.logger.Info("  Base URL: %s", baseURL)
-	executor.logger.Info("  Using model: %s", model)
+	e.useLLM = true
+	e.logger.Info("Successfully connected to Ollama")
+	e.logger.Info("  Base URL: %s", e.baseURL)
+	e.logger.Info("  Using model: %s", e.ollamaModel)
+}
 
-	return executor
+// Skills returns the agent card skills served by this executor; PrimeAgentExecutor
+// serves check-prime
+func (e *DiceAgentExecutor) Skills() []a2a.AgentSkill {
+	return []a2a.AgentSkill{
+		{
+			ID:          "roll-dice",
+			Name:        "Roll Dice",
+			Description: "Rolls an N-sided dice",
+			Tags:        []string{"dice", "random"},
+			Examples:    []string{"Roll a 20-sided dice"},
+		},
+		{
+			ID:          "roll-dice-multi",
+			Name:        "Roll Multiple Dice",
+			Description: "Rolls several N-sided dice in XdY notation and returns each result and the sum",
+			Tags:        []string{"dice", "random"},
+			Examples:    []string{"Roll 3d6", "Roll 2d20"},
+		},
+		{
+			ID:          "dice-stats",
+			Name:        "Dice Statistics",
+			Description: "Rolls N dice with S sides and returns the mean, min, max and a histogram as structured data",
+			Tags:        []string{"dice", "statistics"},
+			Examples:    []string{"Show statistics for 1000 rolls of 6-sided dice", "Roll 500d20 and show the distribution"},
+			OutputModes: []string{"text", "application/json"},
+		},
+		{
+			ID:          "flip-coin",
+			Name:        "Coin Flip",
+			Description: "Flips a coin and answers heads or tails",
+			Tags:        []string{"coin", "random"},
+			Examples:    []string{"Heads or tails?", "Flip a coin"},
+		},
+		{
+			ID:          "choose",
+			Name:        "Random Choice",
+			Description: "Picks one of the given options at random",
+			Tags:        []string{"choice", "random"},
+			Examples:    []string{"Pick one of pizza/sushi/tacos", "Choose between red, green and blue"},
+		},
+		{
+			ID:          "random-int",
+			Name:        "Random Integer",
+			Description: "Returns a random integer between a minimum and a maximum, inclusive",
+			Tags:        []string{"random", "number"},
+			Examples:    []string{"Give me a random number between 1 and 1000000"},
+		},
+		{
+			ID:          "generate-uuid",
+			Name:        "UUID Generator",
+			Description: "Generates random (version 4) UUIDs",
+			Tags:        []string{"random", "uuid"},
+			Examples:    []string{"Generate 3 UUIDs"},
+		},
+	}
 }
 
 // validateOllamaConnection validates that Ollama is accessible
@@ -117,59 +250,77 @@ func (e *DiceAgentExecutor) validateOllamaConnection() error {
 	return nil
 }
 
-// getTools returns the tool definitions for Ollama
-func (e *DiceAgentExecutor) getTools() []api.Tool {
-	rollDiceProperties := api.NewToolPropertiesMap()
-	rollDiceProperties.Set("sides", api.ToolProperty{
-		Type:        api.PropertyType{"integer"},
-		Description: "The number of sides on the dice (must be positive)",
-	})
-
-	checkPrimeProperties := api.NewToolPropertiesMap()
-	checkPrimeProperties.Set("numbers", api.ToolProperty{
-		Type:        api.PropertyType{"array"},
-		Description: "List of integers to check for primality",
-		Items: map[string]interface{}{
-			"type": "integer",
-		},
+// CheckHealth implements HealthChecker: it reports whether Ollama and the MCP servers
+// providing tools are reachable now
+func (e *DiceAgentExecutor) CheckHealth(ctx context.Context) map[string]subsystemHealth {
+	health := checkSubsystem(ctx, false, func(ctx context.Context) (string, error) {
+		if e.ollamaClient == nil {
+			return "", fmt.Errorf("no Ollama client for %s", e.baseURL)
+		}
+		if _, err := e.ollamaClient.List(ctx); err != nil {
+			return "", fmt.Errorf("%s unreachable: %w", e.baseURL, err)
+		}
+		if !e.useLLM {
+			return fmt.Sprintf("%s reachable, but pattern matching is used: it was unreachable at startup", e.baseURL), nil
+		}
+		return fmt.Sprintf("%s, model %s", e.baseURL, e.ollamaModel), nil
 	})
+	checks := map[string]subsystemHealth{"ollama": health}
+	e.checkMCPServers(ctx, checks)
+	return checks
+}
 
-	return []api.Tool{
-		{
-			Type: "function",
-			Function: api.ToolFunction{
-				Name:        "roll_dice",
-				Description: "Rolls an N-sided dice and returns a random number between 1 and N",
-				Parameters: api.ToolFunctionParameters{
-					Type:       "object",
-					Properties: rollDiceProperties,
-					Required:   []string{"sides"},
-				},
-			},
-		},
-		{
+// getTools returns the tool definitions for Ollama, generated from the tool registry
+func (e *DiceAgentExecutor) getTools() []api.Tool {
+	var definitions []api.Tool
+	for _, tool := range e.tools.Tools() {
+		params := tool.Parameters()
+		properties := api.NewToolPropertiesMap()
+		for _, name := range params.PropertyNames() {
+			properties.Set(name, ollamaProperty(params.Properties[name]))
+		}
+		definitions = append(definitions, api.Tool{
 			Type: "function",
 			Function: api.ToolFunction{
-				Name:        "check_prime",
-				Description: "Checks if the given numbers are prime and returns which ones are prime",
+				Name:        tool.Name(),
+				Description: tool.Description(),
 				Parameters: api.ToolFunctionParameters{
-					Type:       "object",
-					Properties: checkPrimeProperties,
-					Required:   []string{"numbers"},
+					Type:       params.Type,
+					Properties: properties,
+					Required:   params.Required,
 				},
 			},
-		},
+		})
 	}
+	return definitions
 }
 
-// processWithLLM processes the message using Ollama LLM
-func (e *DiceAgentExecutor) processWithLLM(ctx context.Context, messageText string) (string, error) {
+// ollamaProperty converts a tool argument schema to an Ollama tool property
+func ollamaProperty(schema *tools.Schema) api.ToolProperty {
+	property := api.ToolProperty{
+		Type:        api.PropertyType{schema.Type},
+		Description: schema.Description,
+	}
+	if schema.Items != nil {
+		property.Items = map[string]interface{}{"type": schema.Items.Type}
+	}
+	return property
+}
+
+// Tools returns the tools the LLM can call, the builtin ones and those of MCP servers
+func (e *DiceAgentExecutor) Tools() []tools.Tool {
+	return e.tools.Tools()
+}
+
+// processWithLLM processes the message using Ollama LLM. With onToken, the answer is
+// streamed and onToken receives its text as it is generated.
+func (e *DiceAgentExecutor) processWithLLM(ctx context.Context, messageText string, data *toolData, onToken func(string) error) (string, error) {
 	if e.ollamaClient == nil {
 		return "", fmt.Errorf("Ollama client not initialized")
 	}
 
 	messages := []api.Message{
-		{Role: "system", Content: systemPrompt},
+		{Role: "system", Content: e.prompt},
 		{Role: "user", Content: messageText},
 	}
 
@@ -179,16 +330,20 @@ func (e *DiceAgentExecutor) processWithLLM(ctx context.Context, messageText stri
 		Tools:    e.getTools(),
 		Stream:   new(bool),
 	}
+	*req.Stream = onToken != nil
 
 	var response string
 	var toolCalls []api.ToolCall
 
+	// Streamed answers arrive in pieces, the others in one response
 	respFunc := func(resp api.ChatResponse) error {
-		if len(resp.Message.ToolCalls) > 0 {
-			toolCalls = resp.Message.ToolCalls
+		toolCalls = append(toolCalls, resp.Message.ToolCalls...)
+		if resp.Message.Content == "" {
+			return nil
 		}
-		if resp.Message.Content != "" {
-			response = resp.Message.Content
+		response += resp.Message.Content
+		if onToken != nil {
+			return onToken(resp.Message.Content)
 		}
 		return nil
 	}
@@ -204,7 +359,7 @@ func (e *DiceAgentExecutor) processWithLLM(ctx context.Context, messageText stri
 		for _, toolCall := range toolCalls {
 			e.logger.Info("Executing tool: %s", toolCall.Function.Name)
 
-			toolResult, err := e.executeTool(toolCall.Function.Name, toolCall.Function.Arguments.ToMap())
+			toolResult, err := e.executeTool(ctx, toolCall.Function.Name, toolCall.Function.Arguments.ToMap(), data)
 			if err != nil {
 				e.logger.Error("Tool execution error: %v", err)
 				return "", fmt.Errorf("tool execution failed: %w", err)
@@ -224,80 +379,75 @@ func (e *DiceAgentExecutor) processWithLLM(ctx context.Context, messageText stri
 		req.Messages = messages
 		req.Tools = nil
 
-		var finalResponse string
-		finalRespFunc := func(resp api.ChatResponse) error {
-			if resp.Message.Content != "" {
-				finalResponse = resp.Message.Content
-			}
-			return nil
-		}
-
-		err = e.ollamaClient.Chat(ctx, req, finalRespFunc)
+		response, toolCalls = "", nil
+		err = e.ollamaClient.Chat(ctx, req, respFunc)
 		if err != nil {
 			return "", fmt.Errorf("Ollama follow-up chat error: %w", err)
 		}
 
-		return finalResponse, nil
+		return response, nil
 	}
 
 	return response, nil
 }
 
-// executeTool executes a tool and returns the result as a string
-func (e *DiceAgentExecutor) executeTool(toolName string, argsJSON map[string]interface{}) (string, error) {
-	switch toolName {
-	case "roll_dice":
-		sides, ok := argsJSON["sides"].(float64)
-		if !ok {
-			return "", fmt.Errorf("invalid 'sides' parameter")
-		}
-		sidesInt := int(sides)
-		if sidesInt <= 0 {
-			return "", &ValidationError{Message: fmt.Sprintf("'sides' must be positive, got %d", sidesInt)}
-		}
-		if sidesInt > 1000000 {
-			return "", &ValidationError{Message: fmt.Sprintf("'sides' must be <= 1000000, got %d", sidesInt)}
-		}
-		result, err := RollDice(sidesInt)
+// executeTool executes a tool within its time limit and returns the result as JSON.
+// Tools with structured results also add them to data.
+func (e *DiceAgentExecutor) executeTool(ctx context.Context, toolName string, argsJSON map[string]interface{}, data *toolData) (string, error) {
+	return e.runTool(ctx, toolName, data, func(data *toolData) (string, error) {
+		result, err := e.callTool(ctx, toolName, argsJSON, data)
 		if err != nil {
 			return "", err
 		}
-		return fmt.Sprintf(`{"result": %d}`, result), nil
+		return result.JSON(), nil
+	})
+}
 
-	case "check_prime":
-		numbersRaw, ok := argsJSON["numbers"].([]interface{})
-		if !ok {
-			return "", fmt.Errorf("invalid 'numbers' parameter")
-		}
-		numbers := make([]int, len(numbersRaw))
-		for i, n := range numbersRaw {
-			numFloat, ok := n.(float64)
-			if !ok {
-				return "", fmt.Errorf("invalid number at index %d", i)
-			}
-			numbers[i] = int(numFloat)
-		}
-		if len(numbers) > 1000 {
-			return "", &ValidationError{Message: fmt.Sprintf("'numbers' list too large (max 1000), got %d", len(numbers))}
-		}
-		for _, num := range numbers {
-			if num < 0 {
-				return "", &ValidationError{Message: fmt.Sprintf("All numbers must be non-negative, got %d", num)}
-			}
+// callTool calls a registered tool, through the tool cache if the tool is deterministic,
+// and adds its result to data if the tool returns it as an artifact
+func (e *DiceAgentExecutor) callTool(ctx context.Context, toolName string, args map[string]interface{}, data *toolData) (tools.Result, error) {
+	tool, ok := e.tools.Get(toolName)
+	if !ok {
+		return tools.Result{}, fmt.Errorf("unknown tool: %s", toolName)
+	}
+
+	call := func() (tools.Result, error) { return tool.Call(ctx, args) }
+	var result tools.Result
+	var err error
+	if cacheable, ok := tool.(tools.Cacheable); ok && e.toolCache != nil {
+		key, keyErr := cacheable.CacheKey(args)
+		if keyErr != nil {
+			return tools.Result{}, keyErr
 		}
-		result := CheckPrime(numbers)
-		resultJSON, _ := json.Marshal(map[string]string{"result": result})
-		return string(resultJSON), nil
+		result, err = e.toolCache.do(toolName, key, call)
+	} else {
+		result, err = call()
+	}
+	if err != nil {
+		return tools.Result{}, err
+	}
 
-	default:
-		return "", fmt.Errorf("unknown tool: %s", toolName)
+	if result.Artifact != "" {
+		if err := data.add(result.Artifact, result.Output); err != nil {
+			return tools.Result{}, err
+		}
 	}
+	return result, nil
 }
 
 // Execute implements a2asrv.AgentExecutor - processes request and writes A2A events to queue.
 func (e *DiceAgentExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
 	taskID := reqCtx.TaskID
 	e.logger.Info("Received new request. taskId=%s", taskID)
+	if len(reqCtx.Metadata) > 0 {
+		e.logger.Debug("Request metadata: %v", reqCtx.Metadata)
+	}
+	if principal, ok := principalFrom(reqCtx); ok {
+		e.logger.Info("Caller: %s (scopes=%v)", principal.Subject, principal.Scopes)
+	}
+
+	// Echo relevant request metadata (locale, trace context) on every response event
+	queue = withResponseMetadata(queue, reqCtx)
 
 	// Extract text from the incoming message
 	messageText := extractTextFromA2AMessage(reqCtx.Message)
@@ -310,21 +460,26 @@ func (e *DiceAgentExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestC
 
 	// Write submitted status for new tasks
 	if reqCtx.StoredTask == nil {
-		event := a2a.NewStatusUpdateEvent(reqCtx, a2a.TaskStateSubmitted, nil)
+		event := statusUpdate(reqCtx, protocol.TaskStateSubmitted, nil)
 		if err := queue.Write(ctx, event); err != nil {
 			return fmt.Errorf("failed to write state submitted: %w", err)
 		}
 	}
 
 	// Write working status
-	event := a2a.NewStatusUpdateEvent(reqCtx, a2a.TaskStateWorking, nil)
+	event := statusUpdate(reqCtx, protocol.TaskStateWorking, nil)
 	if err := queue.Write(ctx, event); err != nil {
 		return fmt.Errorf("failed to write state working: %w", err)
 	}
 	e.logger.Info("Task started working: %s", taskID)
 
-	// Process the message
-	response, err := e.processMessage(ctx, messageText)
+	// Process the message, streaming the LLM's answer as it comes when enabled
+	var data toolData
+	var tokens *tokenArtifact
+	if e.streamTokens {
+		tokens = &tokenArtifact{ctx: ctx, reqCtx: reqCtx, queue: queue}
+	}
+	response, err := e.processMessage(ctx, messageText, &data, tokens)
 	if err != nil {
 		e.logger.Error("Error processing message: %v", err)
 		return e.writeFailedStatus(ctx, reqCtx, queue, fmt.Sprintf("Error processing your request: %s", err.Error()))
@@ -333,15 +488,24 @@ func (e *DiceAgentExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestC
 	e.logger.Info("LLM returned response length=%d", len(response))
 	e.logger.Debug("Response content: %s", response)
 
-	// Write artifact with the response
-	artifactEvent := a2a.NewArtifactEvent(reqCtx, a2a.TextPart{Text: response})
-	if err := queue.Write(ctx, artifactEvent); err != nil {
-		return fmt.Errorf("failed to write artifact: %w", err)
+	// Write artifact with the response, split into append/lastChunk updates when large;
+	// a streamed answer is already out but for its last chunk
+	if tokens.Started() {
+		err = tokens.Close()
+	} else {
+		err = e.writeArtifactChunks(ctx, reqCtx, queue, response)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Write the structured tool results as data artifacts
+	if err := e.writeDataArtifacts(ctx, reqCtx, queue, data); err != nil {
+		return err
 	}
 
 	// Write completed status (final event)
-	completedEvent := a2a.NewStatusUpdateEvent(reqCtx, a2a.TaskStateCompleted, nil)
-	completedEvent.Final = true
+	completedEvent := statusUpdate(reqCtx, protocol.TaskStateCompleted, nil)
 	if err := queue.Write(ctx, completedEvent); err != nil {
 		return fmt.Errorf("failed to write state completed: %w", err)
 	}
@@ -353,9 +517,9 @@ func (e *DiceAgentExecutor) Execute(ctx context.Context, reqCtx *a2asrv.RequestC
 // Cancel implements a2asrv.AgentExecutor - cancels an ongoing task.
 func (e *DiceAgentExecutor) Cancel(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
 	e.logger.Info("Cancel requested for task: %s", reqCtx.TaskID)
+	queue = withResponseMetadata(queue, reqCtx)
 
-	cancelEvent := a2a.NewStatusUpdateEvent(reqCtx, a2a.TaskStateCanceled, nil)
-	cancelEvent.Final = true
+	cancelEvent := statusUpdate(reqCtx, protocol.TaskStateCanceled, nil)
 	if err := queue.Write(ctx, cancelEvent); err != nil {
 		return fmt.Errorf("failed to write cancel event: %w", err)
 	}
@@ -364,69 +528,247 @@ func (e *DiceAgentExecutor) Cancel(ctx context.Context, reqCtx *a2asrv.RequestCo
 	return nil
 }
 
+// writeArtifactChunks streams text as one artifact in chunks of at most chunkSize bytes.
+// The first event creates the artifact, later ones set Append, and the last one sets LastChunk.
+func (e *DiceAgentExecutor) writeArtifactChunks(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue, text string) error {
+	chunks := chunkText(text, e.chunkSize)
+
+	var artifactID a2a.ArtifactID
+	for i, chunk := range chunks {
+		var event *a2a.TaskArtifactUpdateEvent
+		if i == 0 {
+			event = a2a.NewArtifactEvent(reqCtx, a2a.TextPart{Text: chunk})
+			artifactID = event.Artifact.ID
+		} else {
+			event = a2a.NewArtifactUpdateEvent(reqCtx, artifactID, a2a.TextPart{Text: chunk})
+			event.Append = true
+		}
+		event.LastChunk = i == len(chunks)-1
+
+		if err := queue.Write(ctx, event); err != nil {
+			return fmt.Errorf("failed to write artifact chunk %d/%d: %w", i+1, len(chunks), err)
+		}
+	}
+
+	if len(chunks) > 1 {
+		e.logger.Info("Artifact streamed in %d chunks", len(chunks))
+	}
+	return nil
+}
+
+// tokenArtifact streams the LLM's answer as one artifact while it is generated: the first
+// piece creates the artifact and the next ones are appended. The latest piece is held back,
+// so that Close can send it as the last chunk.
+type tokenArtifact struct {
+	ctx     context.Context
+	reqCtx  *a2asrv.RequestContext
+	queue   eventqueue.Queue
+	id      a2a.ArtifactID
+	pending string
+}
+
+// Write sends the piece held back, if any, and holds back text
+func (t *tokenArtifact) Write(text string) error {
+	if t.pending != "" {
+		if err := t.send(false); err != nil {
+			return err
+		}
+	}
+	t.pending = text
+	return nil
+}
+
+// Started reports whether part of the answer was sent; false for a nil tokenArtifact.
+// Until then, the answer can still be written as a whole.
+func (t *tokenArtifact) Started() bool {
+	return t != nil && t.id != ""
+}
+
+// Close sends the piece held back as the last chunk
+func (t *tokenArtifact) Close() error {
+	return t.send(true)
+}
+
+func (t *tokenArtifact) send(last bool) error {
+	var event *a2a.TaskArtifactUpdateEvent
+	if t.id == "" {
+		event = a2a.NewArtifactEvent(t.reqCtx, a2a.TextPart{Text: t.pending})
+		t.id = event.Artifact.ID
+	} else {
+		event = a2a.NewArtifactUpdateEvent(t.reqCtx, t.id, a2a.TextPart{Text: t.pending})
+		event.Append = true
+	}
+	event.LastChunk = last
+	if err := t.queue.Write(t.ctx, event); err != nil {
+		return fmt.Errorf("failed to write answer chunk: %w", err)
+	}
+	return nil
+}
+
+// writeDataArtifacts writes each structured tool result as a named artifact with a single DataPart
+func (e *DiceAgentExecutor) writeDataArtifacts(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue, data toolData) error {
+	for _, artifact := range data {
+		event := a2a.NewArtifactEvent(reqCtx, artifact.part)
+		event.Artifact.Name = artifact.name
+		event.LastChunk = true
+		if err := queue.Write(ctx, event); err != nil {
+			return fmt.Errorf("failed to write %s artifact: %w", artifact.name, err)
+		}
+	}
+	return nil
+}
+
+// statusUpdate builds a status update of the request's task, final when the task ends.
+// The status message, if any, is addressed to the task.
+func statusUpdate(reqCtx *a2asrv.RequestContext, state protocol.TaskState, message *protocol.Message) *a2a.TaskStatusUpdateEvent {
+	taskID := string(reqCtx.TaskID)
+	if message != nil {
+		m := message.InTask(taskID, reqCtx.ContextID)
+		message = &m
+	}
+	return protocol.NewStatusUpdate(taskID, reqCtx.ContextID, state, message).SDK()
+}
+
 // writeFailedStatus writes a failed status event
 func (e *DiceAgentExecutor) writeFailedStatus(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue, errorMessage string) error {
-	msg := a2a.NewMessage(a2a.MessageRoleAgent, a2a.TextPart{Text: errorMessage})
-	event := a2a.NewStatusUpdateEvent(reqCtx, a2a.TaskStateFailed, msg)
-	event.Final = true
+	msg := protocol.NewAgentText(reqCtx.ContextID, errorMessage)
+	event := statusUpdate(reqCtx, protocol.TaskStateFailed, &msg)
 	if err := queue.Write(ctx, event); err != nil {
 		return fmt.Errorf("failed to write failed status: %w", err)
 	}
 	return nil
 }
 
-// processMessage processes the user message and generates a response
-func (e *DiceAgentExecutor) processMessage(ctx context.Context, messageText string) (string, error) {
+// processMessage processes the user message and generates a response. With tokens, the
+// LLM's answer is streamed to it as it is generated; pattern matching answers are not.
+func (e *DiceAgentExecutor) processMessage(ctx context.Context, messageText string, data *toolData, tokens *tokenArtifact) (string, error) {
 	if e.useLLM && e.ollamaClient != nil {
 		e.logger.Info("Invoking LLM with tools")
-		response, err := e.processWithLLM(ctx, messageText)
-		if err != nil {
-			e.logger.Warn("LLM processing failed: %v, falling back to pattern matching", err)
-		} else {
+		var onToken func(string) error
+		if tokens != nil {
+			onToken = tokens.Write
+		}
+		response, err := e.processWithLLM(ctx, messageText, data, onToken)
+		switch {
+		case err == nil:
 			return response, nil
+		case tokens.Started():
+			// Part of the answer is out: a fallback answer cannot replace it
+			return "", err
+		default:
+			e.logger.Warn("LLM processing failed: %v, falling back to pattern matching", err)
 		}
 	}
 
 	// Fallback to pattern matching
 	e.logger.Info("Processing message with pattern matching (fallback)")
+	return e.runTool(ctx, "pattern_matching", data, func(data *toolData) (string, error) {
+		return e.patterns(ctx, messageText, data)
+	})
+}
+
+// processWithPatterns answers the message by matching it against the known requests and
+// calling the matching tool
+func (e *DiceAgentExecutor) processWithPatterns(ctx context.Context, messageText string, data *toolData) (string, error) {
 	messageLower := strings.ToLower(messageText)
 
-	if strings.Contains(messageLower, "roll") && strings.Contains(messageLower, "dice") {
-		sides := extractDiceSides(messageText)
-		if sides <= 0 {
-			return "", &ValidationError{Message: fmt.Sprintf("'sides' must be positive, got %d", sides)}
+	if strings.Contains(messageLower, "roll") && containsAny(messageLower, "statistic", "distribution", "histogram") {
+		count, sides, ok := extractDiceNotation(messageLower)
+		if !ok {
+			count, sides = extractDiceCount(messageLower), extractDiceSides(messageText)
 		}
-		if sides > 1000000 {
-			return "", &ValidationError{Message: fmt.Sprintf("'sides' must be <= 1000000, got %d", sides)}
+		result, err := e.callTool(ctx, "roll_dice_stats", map[string]interface{}{"count": count, "sides": sides}, data)
+		if err != nil {
+			return "", err
 		}
-		result, err := RollDice(sides)
+		stats := result.Output.(tools.DiceStats)
+		return fmt.Sprintf("I rolled %d %d-sided dice: mean %.2f, min %d, max %d. The histogram is in the %s artifact.",
+			count, sides, stats.Mean, stats.Min, stats.Max, result.Artifact), nil
+	}
+
+	if count, sides, ok := extractDiceNotation(messageLower); ok && strings.Contains(messageLower, "roll") {
+		result, err := e.callTool(ctx, "roll_dice_multi", map[string]interface{}{"count": count, "sides": sides}, data)
 		if err != nil {
-			return "", fmt.Errorf("error rolling dice: %w", err)
+			return "", err
 		}
+		rolls := result.Output.(tools.MultiRollResult)
+		return fmt.Sprintf("I rolled %dd%d and got: %s (sum %d)", count, sides, joinInts(rolls.Rolls), rolls.Sum), nil
+	}
+
+	if strings.Contains(messageLower, "roll") && strings.Contains(messageLower, "dice") {
+		sides := extractDiceSides(messageText)
+		result, err := e.callTool(ctx, "roll_dice", map[string]interface{}{"sides": sides}, data)
+		if err != nil {
+			return "", err
+		}
+		roll := result.Output.(tools.IntResult).Result
 		if strings.Contains(messageLower, "prime") {
-			primeResult := CheckPrime([]int{result})
-			return fmt.Sprintf("I rolled a %d-sided dice and got: %d. %s", sides, result, primeResult), nil
+			prime, err := e.callTool(ctx, "check_prime", map[string]interface{}{"numbers": []interface{}{roll}}, data)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("I rolled a %d-sided dice and got: %d. %s", sides, roll, prime.Output.(tools.TextResult).Result), nil
 		}
-		return fmt.Sprintf("I rolled a %d-sided dice and got: %d", sides, result), nil
+		return fmt.Sprintf("I rolled a %d-sided dice and got: %d", sides, roll), nil
 	}
 
 	if strings.Contains(messageLower, "prime") {
 		numbers := extractNumbers(messageText)
 		if len(numbers) > 0 {
-			if len(numbers) > 1000 {
-				return "", &ValidationError{Message: fmt.Sprintf("'numbers' list too large (max 1000), got %d", len(numbers))}
-			}
-			for _, num := range numbers {
-				if num < 0 {
-					return "", &ValidationError{Message: fmt.Sprintf("All numbers must be non-negative, got %d", num)}
-				}
+			result, err := e.callTool(ctx, "check_prime", map[string]interface{}{"numbers": numbers}, data)
+			if err != nil {
+				return "", err
 			}
-			return CheckPrime(numbers), nil
+			return result.Output.(tools.TextResult).Result, nil
 		}
 		return "Please provide numbers to check for primality.", nil
 	}
 
-	return "I can roll dice and check if numbers are prime. What would you like me to do?", nil
+	if strings.Contains(messageLower, "random") && containsAny(messageLower, "number", "integer") {
+		minValue, maxValue, ok := extractRange(messageLower)
+		if !ok {
+			return "Please give the range, e.g. \"a random number between 1 and 100\".", nil
+		}
+		result, err := e.callTool(ctx, "random_int", map[string]interface{}{"min": minValue, "max": maxValue}, data)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Your random number between %d and %d is: %d", minValue, maxValue, result.Output.(tools.IntResult).Result), nil
+	}
+
+	if strings.Contains(messageLower, "uuid") {
+		args := map[string]interface{}{}
+		if numbers := extractNumbers(messageText); len(numbers) > 0 {
+			args["count"] = numbers[0]
+		}
+		result, err := e.callTool(ctx, "generate_uuid", args, data)
+		if err != nil {
+			return "", err
+		}
+		return "Here are your UUIDs:\n" + strings.Join(result.Output.(tools.UUIDResult).UUIDs, "\n"), nil
+	}
+
+	if containsAny(messageLower, "heads or tails", "flip a coin", "toss a coin", "coin flip", "coin toss") {
+		result, err := e.callTool(ctx, "flip_coin", nil, data)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("I flipped a coin and got: %s", result.Output.(tools.TextResult).Result), nil
+	}
+
+	if containsAny(messageLower, "pick", "choose") {
+		options := extractOptions(messageText)
+		if len(options) == 0 {
+			return "Please list the options to choose from, e.g. \"pick one of A/B/C\".", nil
+		}
+		result, err := e.callTool(ctx, "choose", map[string]interface{}{"options": options}, data)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("I picked: %s", result.Output.(tools.TextResult).Result), nil
+	}
+
+	return "I can roll dice, check if numbers are prime, flip a coin, pick one of several options, and generate random numbers and UUIDs. What would you like me to do?", nil
 }
 
 // extractTextFromA2AMessage extracts text content from an a2a.Message
@@ -443,6 +785,31 @@ func extractTextFromA2AMessage(message *a2a.Message) string {
 	return strings.Join(textParts, "")
 }
 
+// chunkText splits text into chunks of at most size bytes without breaking UTF-8 sequences.
+// It always returns at least one chunk.
+func chunkText(text string, size int) []string {
+	if size <= 0 || len(text) <= size {
+		return []string{text}
+	}
+
+	var chunks []string
+	for len(text) > size {
+		end := size
+		for end > 0 && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		if end == 0 {
+			end = size
+		}
+		chunks = append(chunks, text[:end])
+		text = text[end:]
+	}
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+	return chunks
+}
+
 // extractDiceSides extracts the number of dice sides from the message
 func extractDiceSides(message string) int {
 	patterns := []string{
@@ -463,15 +830,122 @@ func extractDiceSides(message string) int {
 	return 6
 }
 
-// extractNumbers extracts all numbers from the message
-func extractNumbers(message string) []int {
+// diceNotation matches XdY dice notation such as 3d6
+var diceNotation = regexp.MustCompile(`\b(\d+)d(\d+)\b`)
+
+// extractDiceNotation extracts the dice count and sides from XdY notation in a lowercase message
+func extractDiceNotation(message string) (count, sides int, ok bool) {
+	matches := diceNotation.FindStringSubmatch(message)
+	if matches == nil {
+		return 0, 0, false
+	}
+	count, countErr := strconv.Atoi(matches[1])
+	sides, sidesErr := strconv.Atoi(matches[2])
+	return count, sides, countErr == nil && sidesErr == nil
+}
+
+// diceCount matches a number of dice such as "1000 dice" or "500 rolls"
+var diceCount = regexp.MustCompile(`\b(\d+)\s+(?:dice|rolls)\b`)
+
+// extractDiceCount extracts how many dice to roll from a lowercase message, 100 if it gives none
+func extractDiceCount(message string) int {
+	if matches := diceCount.FindStringSubmatch(message); matches != nil {
+		if count, err := strconv.Atoi(matches[1]); err == nil {
+			return count
+		}
+	}
+	return 100
+}
+
+// optionsIntro matches the words that introduce the options in "pick one of A/B/C"
+var optionsIntro = regexp.MustCompile(`(?i)\b(?:one of|between|from|among)\s+(.+)`)
+
+// optionSeparator splits a list of options written as "A/B/C", "A, B or C" or "A, B and C"
+var optionSeparator = regexp.MustCompile(`(?i)\s*(?:/|,|\bor\b|\band\b)\s*`)
+
+// extractOptions extracts the options of a "pick one of ..." request
+func extractOptions(message string) []string {
+	matches := optionsIntro.FindStringSubmatch(message)
+	if matches == nil {
+		return nil
+	}
+	var options []string
+	for _, option := range optionSeparator.Split(matches[1], -1) {
+		option = strings.Trim(option, " .?!:;\"'")
+		if option != "" {
+			options = append(options, option)
+		}
+	}
+	if len(options) < 2 {
+		return nil
+	}
+	return options
+}
+
+// numberRange matches a range such as "between 1 and 100" or "from -5 to 5"
+var numberRange = regexp.MustCompile(`(?:between|from)\s+(-?\d+)\s+(?:and|to)\s+(-?\d+)`)
+
+// extractRange extracts the bounds of a range from a lowercase message
+func extractRange(message string) (minValue, maxValue int64, ok bool) {
+	matches := numberRange.FindStringSubmatch(message)
+	if matches == nil {
+		return 0, 0, false
+	}
+	minValue, minErr := strconv.ParseInt(matches[1], 10, 64)
+	maxValue, maxErr := strconv.ParseInt(matches[2], 10, 64)
+	return minValue, maxValue, minErr == nil && maxErr == nil
+}
+
+// containsAny reports whether s contains any of the substrings
+func containsAny(s string, substrings ...string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// toolArtifact is a structured tool result returned as a named data artifact
+type toolArtifact struct {
+	name string
+	part a2a.DataPart
+}
+
+// toolData collects the structured results of the tools called for one message
+type toolData []toolArtifact
+
+// add records a tool result, converted to the JSON object of a DataPart
+func (d *toolData) add(name string, result any) error {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	var object map[string]any
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return fmt.Errorf("%s is not a JSON object: %w", name, err)
+	}
+	*d = append(*d, toolArtifact{name: name, part: a2a.DataPart{Data: object}})
+	return nil
+}
+
+// joinInts formats numbers as a comma-separated list
+func joinInts(numbers []int) string {
+	strs := make([]string, len(numbers))
+	for i, n := range numbers {
+		strs[i] = strconv.Itoa(n)
+	}
+	return strings.Join(strs, ", ")
+}
+
+// extractNumbers extracts all numbers from the message, whatever their size
+func extractNumbers(message string) []*big.Int {
 	re := regexp.MustCompile(`\b(\d+)\b`)
 	matches := re.FindAllStringSubmatch(message, -1)
-	var numbers []int
+	var numbers []*big.Int
 	for _, match := range matches {
 		if len(match) > 1 {
-			num, err := strconv.Atoi(match[1])
-			if err == nil {
+			if num, ok := new(big.Int).SetString(match[1], 10); ok {
 				numbers = append(numbers, num)
 			}
 		}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"os"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/flags"
+	"github.com/aloha/a2a-go/pkg/tools"
+)
+
+// Feature flags gating experimental behaviors of the server
+const (
+	featureStreamingTokens   = "streaming-tokens"
+	featurePushNotifications = "push-notifications"
+	featureExtendedCard      = "extended-card"
+)
+
+// newFeatureFlags returns the server's feature flags at their defaults
+func newFeatureFlags() *flags.Set {
+	return flags.NewSet(
+		flags.Flag{Name: featureStreamingTokens, Description: "Stream the LLM's answer as artifact updates while it is generated"},
+		flags.Flag{Name: featurePushNotifications, Description: "Accept push notification configs and post task updates to them", Default: true},
+		flags.Flag{Name: featureExtendedCard, Description: "Serve an authenticated extended agent card listing the LLM's tools"},
+	)
+}
+
+// loadFeatureFlags returns the server's feature flags with the values of file, if any,
+// then of the ALOHA_FEATURE_* environment variables
+func loadFeatureFlags(file string) (*flags.Set, error) {
+	features := newFeatureFlags()
+	if file != "" {
+		if err := features.LoadFile(file); err != nil {
+			return nil, err
+		}
+	}
+	if err := features.LoadEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+	return features, nil
+}
+
+// ToolLister is implemented by executors answering with tools, so that the extended
+// agent card lists them
+type ToolLister interface {
+	Tools() []tools.Tool
+}
+
+// extendedAgentCard returns the agent card with a skill for each tool the executors'
+// LLM can call, including those of MCP servers
+func (a *AlohaServer) extendedAgentCard(ctx context.Context) (*a2a.AgentCard, error) {
+	public, err := a.AgentCard(ctx)
+	if err != nil {
+		return nil, err
+	}
+	card := *public
+	card.Skills = append([]a2a.AgentSkill(nil), public.Skills...)
+	for _, tool := range a.router.Tools() {
+		card.Skills = append(card.Skills, a2a.AgentSkill{
+			ID:          "tool:" + tool.Name(),
+			Name:        tool.Name(),
+			Description: tool.Description(),
+			Tags:        []string{"tool"},
+		})
+	}
+	return &card, nil
+}
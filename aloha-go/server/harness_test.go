@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2aclient"
+	"github.com/aloha/a2a-go/pkg/mockllm"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// harnessStartTimeout bounds how long startHarness waits for the transports to answer
+const harnessStartTimeout = 5 * time.Second
+
+// harness runs an AlohaServer in process on ephemeral localhost ports, so that
+// end-to-end tests can run under go test without Ollama or docker. With a mock LLM
+// the executor answers through it; without one it uses pattern matching.
+type harness struct {
+	Server *AlohaServer
+	LLM    *mockllm.Server // nil when the executor uses pattern matching
+
+	// Endpoints of the transports; CardURL serves the agent card
+	GRPCAddr   string
+	JSONRPCURL string
+	RESTURL    string
+	CardURL    string
+
+	cancel context.CancelFunc
+	done   chan error
+}
+
+// startHarness starts a server answering with llm, or with pattern matching if llm is nil.
+// The harness owns llm and closes it with the server.
+func startHarness(llm mockllm.Handler) (*harness, error) {
+	h := &harness{done: make(chan error, 1)}
+
+	executor := newDiceAgentExecutor("", mockllm.Model)
+	if llm != nil {
+		h.LLM = mockllm.New(llm)
+		executor.baseURL = h.LLM.URL()
+		executor.connectOllama(h.LLM.Client())
+	}
+
+	h.Server = newAlohaServer(0, 0, 0, "127.0.0.1", "jsonrpc", "", nil, executor, nil)
+	if err := h.Server.RegisterExecutor(NewPrimeAgentExecutor(executor)); err != nil {
+		h.closeLLM()
+		return nil, err
+	}
+	if err := h.Server.Listen(); err != nil {
+		h.closeLLM()
+		return nil, err
+	}
+	h.GRPCAddr = fmt.Sprintf("localhost:%d", h.Server.grpcPort)
+	h.JSONRPCURL = fmt.Sprintf("http://localhost:%d", h.Server.jsonrpcPort)
+	h.RESTURL = fmt.Sprintf("http://localhost:%d", h.Server.restPort)
+	h.CardURL = h.JSONRPCURL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	go func() {
+		h.done <- h.Server.Start(ctx)
+	}()
+
+	if err := h.waitReady(); err != nil {
+		h.Close()
+		return nil, err
+	}
+	return h, nil
+}
+
+// waitReady waits until the agent card is served, which means the HTTP transports are up
+func (h *harness) waitReady() error {
+	deadline := time.Now().Add(harnessStartTimeout)
+	for {
+		resp, err := http.Get(h.CardURL + "/.well-known/agent-card.json")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("server did not start within %s: %v", harnessStartTimeout, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// Client returns an SDK client talking to the server over a JSON-RPC or gRPC transport
+func (h *harness) Client(ctx context.Context, transport a2a.TransportProtocol) (*a2aclient.Client, error) {
+	card, err := h.Server.AgentCard(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a2aclient.NewFromCard(ctx, card,
+		a2aclient.WithConfig(a2aclient.Config{PreferredTransports: []a2a.TransportProtocol{transport}}),
+		a2aclient.WithGRPCTransport(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+}
+
+// Close stops the server, waits for its transports to shut down and stops the mock LLM
+func (h *harness) Close() error {
+	h.cancel()
+	err := <-h.done
+	h.closeLLM()
+	return err
+}
+
+func (h *harness) closeLLM() {
+	if h.LLM != nil {
+		h.LLM.Close()
+	}
+}
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+)
+
+// Health statuses of the server and its subsystems
+const (
+	healthUp       = "up"
+	healthDegraded = "degraded" // a dependency with a fallback is down; the agent still answers
+	healthDown     = "down"
+	healthLameDuck = "lame-duck" // shutting down; reported by /readyz without checking the subsystems
+)
+
+// healthCheckTimeout bounds each subsystem check
+const healthCheckTimeout = 2 * time.Second
+
+// healthProbeTaskID names a task that is never created, looked up to check the task store
+const healthProbeTaskID = a2a.TaskID("healthz-probe")
+
+// subsystemHealth is the status of one subsystem in the /healthz report
+type subsystemHealth struct {
+	Status    string  `json:"status"`
+	Detail    string  `json:"detail,omitempty"`
+	LatencyMs float64 `json:"latencyMs"`
+	// Critical subsystems take the server down when they fail; the others degrade it
+	Critical bool `json:"critical"`
+}
+
+// healthReport is the /healthz response
+type healthReport struct {
+	Status     string                     `json:"status"`
+	Subsystems map[string]subsystemHealth `json:"subsystems,omitempty"`
+}
+
+// HealthChecker is implemented by executors that depend on other services, so that
+// /healthz reports them. Executors answer without them, so they are not critical.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) map[string]subsystemHealth
+}
+
+// checkSubsystem runs check with the health check timeout and reports its outcome.
+// check returns a detail for a healthy subsystem, or the error that makes it unhealthy.
+func checkSubsystem(ctx context.Context, critical bool, check func(ctx context.Context) (string, error)) subsystemHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	type outcome struct {
+		detail string
+		err    error
+	}
+	done := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		detail, err := check(ctx)
+		done <- outcome{detail, err}
+	}()
+
+	health := subsystemHealth{Status: healthUp, Critical: critical}
+	select {
+	case result := <-done:
+		health.Detail = result.detail
+		if result.err != nil {
+			health.Status, health.Detail = healthDown, result.err.Error()
+		}
+	case <-ctx.Done():
+		health.Status, health.Detail = healthDown, fmt.Sprintf("no answer within %s", healthCheckTimeout)
+	}
+	health.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	return health
+}
+
+// checkListener checks that a transport is bound and accepts connections
+func checkListener(listener net.Listener) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		if listener == nil {
+			return "", errors.New("not listening")
+		}
+		addr := listener.Addr().(*net.TCPAddr)
+		target := &net.TCPAddr{IP: addr.IP, Port: addr.Port}
+		if addr.IP.IsUnspecified() {
+			target.IP = net.IPv4(127, 0, 0, 1)
+		}
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", target.String())
+		if err != nil {
+			return "", err
+		}
+		conn.Close()
+		return "listening on " + addr.String(), nil
+	}
+}
+
+// Health checks every transport listener, the task store and the executors' dependencies,
+// all at once
+func (a *AlohaServer) Health(ctx context.Context) healthReport {
+	checks := map[string]func(ctx context.Context) (string, error){
+		"grpc":    checkListener(a.grpcListener),
+		"jsonrpc": checkListener(a.jsonrpcListener),
+		"rest":    checkListener(a.restListener),
+		"taskStore": func(ctx context.Context) (string, error) {
+			if _, _, err := a.tasks.Get(ctx, healthProbeTaskID); !errors.Is(err, a2a.ErrTaskNotFound) {
+				return "", fmt.Errorf("lookup of an unknown task returned %v", err)
+			}
+			return fmt.Sprintf("%d tasks", a.tasks.Len()), nil
+		},
+	}
+
+	report := healthReport{Status: healthUp, Subsystems: make(map[string]subsystemHealth)}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			health := checkSubsystem(ctx, true, check)
+			mu.Lock()
+			report.Subsystems[name] = health
+			mu.Unlock()
+		}()
+	}
+	dependencies := a.router.CheckHealth(ctx)
+	wg.Wait()
+	for name, health := range dependencies {
+		report.Subsystems[name] = health
+	}
+
+	for _, health := range report.Subsystems {
+		switch {
+		case health.Status != healthDown:
+		case health.Critical:
+			report.Status = healthDown
+		case report.Status == healthUp:
+			report.Status = healthDegraded
+		}
+	}
+	return report
+}
+
+// handleHealthz handles GET /healthz: 200 while the agent can answer, even degraded,
+// and 503 when a critical subsystem is down
+func (a *AlohaServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	writeHealthReport(w, r, a.Health(r.Context()))
+}
+
+// writeHealthReport writes a health report, with 503 unless the agent can answer
+func writeHealthReport(w http.ResponseWriter, r *http.Request, report healthReport) {
+	status := http.StatusOK
+	if report.Status == healthDown || report.Status == healthLameDuck {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if r.Method == http.MethodGet {
+		json.NewEncoder(w).Encode(report)
+	}
+}
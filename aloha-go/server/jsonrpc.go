@@ -0,0 +1,162 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// streamingMethods answer with an SSE stream, which cannot be part of a batch response
+var streamingMethods = map[string]bool{
+	"message/stream":    true,
+	"tasks/resubscribe": true,
+}
+
+// jsonrpcCall is what the JSON-RPC transport needs to know of a call before serving it
+type jsonrpcCall struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+// isNotification reports whether the call has no id member, a null id being an id
+func (c jsonrpcCall) isNotification() bool {
+	return c.ID == nil
+}
+
+// serveJSONRPC2 brings the JSON-RPC 2.0 semantics the SDK lacks to the JSON-RPC
+// transport. Batches, arrays of calls, are answered with the array of their responses,
+// each call being served in turn by next as if it had been sent alone. Notifications,
+// calls without an id, are served but not answered: their errors are only logged. The
+// other calls go straight to next.
+func (a *AlohaServer) serveJSONRPC2(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isJSONRPCCall(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, ok := readCallBody(w, r, rejectJSONRPCTooLarge)
+		if !ok {
+			return
+		}
+		trimmed := bytes.TrimSpace(body)
+		if len(trimmed) == 0 || trimmed[0] != '[' {
+			var call jsonrpcCall
+			if json.Unmarshal(trimmed, &call) == nil && call.isNotification() {
+				a.serveNotification(next, r, call, trimmed)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var calls []json.RawMessage
+		if err := json.Unmarshal(trimmed, &calls); err != nil {
+			writeJSONRPCError(w, nil, protocol.ErrParse.Withf("%v", err))
+			return
+		}
+		if len(calls) == 0 {
+			writeJSONRPCError(w, nil, protocol.ErrInvalidRequest.Withf("the batch is empty"))
+			return
+		}
+		responses := make([]json.RawMessage, 0, len(calls))
+		for _, call := range calls {
+			if response := a.serveBatchedCall(next, r, call); len(response) > 0 {
+				responses = append(responses, response)
+			}
+		}
+		if len(responses) == 0 {
+			// A batch of notifications is not answered
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(responses)
+	})
+}
+
+// serveBatchedCall serves one call of a batch, returning its response, nil for a
+// notification
+func (a *AlohaServer) serveBatchedCall(next http.Handler, r *http.Request, raw json.RawMessage) json.RawMessage {
+	var call jsonrpcCall
+	if json.Unmarshal(raw, &call) != nil {
+		response, _ := json.Marshal(jsonrpcErrorResponse(nil, protocol.ErrInvalidRequest.Withf("a call must be an object")))
+		return response
+	}
+	if streamingMethods[call.Method] {
+		err := protocol.ErrInvalidRequest.Withf("%s streams its response and cannot be batched", call.Method)
+		if call.isNotification() {
+			a.logger.Warn("JSON-RPC notification %s failed: %v", call.Method, err)
+			return nil
+		}
+		response, _ := json.Marshal(jsonrpcErrorResponse(call.ID, err))
+		return response
+	}
+	if call.isNotification() {
+		a.serveNotification(next, r, call, raw)
+		return nil
+	}
+	return serveBuffered(next, r, raw)
+}
+
+// serveNotification serves a notification, logging the error it would have been
+// answered with
+func (a *AlohaServer) serveNotification(next http.Handler, r *http.Request, call jsonrpcCall, raw json.RawMessage) {
+	var response struct {
+		Error *protocol.Error `json:"error"`
+	}
+	if json.Unmarshal(serveBuffered(next, r, raw), &response) == nil && response.Error != nil {
+		a.logger.Warn("JSON-RPC notification %s failed: %v", call.Method, response.Error)
+	}
+}
+
+// serveBuffered serves a call alone, returning its response
+func serveBuffered(next http.Handler, r *http.Request, call json.RawMessage) json.RawMessage {
+	req := r.Clone(r.Context())
+	req.Body = io.NopCloser(bytes.NewReader(call))
+	req.ContentLength = int64(len(call))
+	response := &bufferedResponse{header: make(http.Header)}
+	next.ServeHTTP(response, req)
+	return bytes.TrimSpace(response.body.Bytes())
+}
+
+// jsonrpcErrorResponse returns the response reporting an error to the call with the id,
+// a null id if it has none
+func jsonrpcErrorResponse(id json.RawMessage, err *protocol.Error) map[string]any {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return map[string]any{"jsonrpc": "2.0", "id": id, "error": err}
+}
+
+// writeJSONRPCError answers a JSON-RPC call with an error
+func writeJSONRPCError(w http.ResponseWriter, id json.RawMessage, err *protocol.Error) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(jsonrpcErrorResponse(id, err))
+}
+
+// bufferedResponse keeps the response to a call of a batch, or to a notification
+type bufferedResponse struct {
+	header http.Header
+	body   bytes.Buffer
+}
+
+// Header implements http.ResponseWriter
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+// Write implements http.ResponseWriter
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+// WriteHeader implements http.ResponseWriter. The calls of a batch share the status of
+// the batch.
+func (b *bufferedResponse) WriteHeader(int) {}
+
+// Flush implements http.Flusher, for the notifications of streaming methods
+func (b *bufferedResponse) Flush() {}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// lameDuckRetryAfter is the Retry-After sent with calls rejected in lame-duck mode: by then
+// a load balancer should have moved the caller to another replica
+const lameDuckRetryAfter = "1"
+
+// errShuttingDown rejects the calls received in lame-duck mode
+var errShuttingDown = protocol.ErrInternal.Withf("the agent is shutting down, retry with another replica")
+
+// EnterLameDuck starts the lame-duck period that precedes a shutdown: /readyz fails so
+// that load balancers stop routing to the agent, and new calls are rejected with 503 or
+// UNAVAILABLE, while the calls already running, streams included, go on
+func (a *AlohaServer) EnterLameDuck() {
+	if a.lameDuck.Swap(true) {
+		return
+	}
+	a.logger.Info("Lame-duck mode: rejecting new calls, %d in flight", a.inflight.Load())
+}
+
+// LameDuck reports whether the agent is in lame-duck mode
+func (a *AlohaServer) LameDuck() bool {
+	return a.lameDuck.Load()
+}
+
+// SetDrainTimeout bounds how long a stopping transport waits for its running calls before
+// closing their connections. 0, the default, waits for them however long they take.
+func (a *AlohaServer) SetDrainTimeout(timeout time.Duration) {
+	a.drainTimeout = timeout
+}
+
+// drainContext returns the context bounding a transport shutdown by the drain timeout
+func (a *AlohaServer) drainContext() (context.Context, context.CancelFunc) {
+	if a.drainTimeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), a.drainTimeout)
+}
+
+// serveHTTP serves an HTTP transport until ctx is done, then stops accepting connections
+// and returns once the running calls have finished or the drain timeout has closed them
+func (a *AlohaServer) serveHTTP(ctx context.Context, name string, server *http.Server, listener net.Listener) error {
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		<-ctx.Done()
+		drainCtx, cancel := a.drainContext()
+		defer cancel()
+		if err := server.Shutdown(drainCtx); err != nil {
+			a.logger.Warn("%s calls still running after %s, closing them", name, a.drainTimeout)
+			server.Close()
+		}
+	}()
+
+	err := server.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		<-drained
+	}
+	return err
+}
+
+// stopGRPC stops a gRPC server once ctx is done, letting its running calls finish within
+// the drain timeout. The returned channel is closed once the server has stopped.
+func (a *AlohaServer) stopGRPC(ctx context.Context, server *grpc.Server) <-chan struct{} {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+		graceful := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(graceful)
+		}()
+		if a.drainTimeout <= 0 {
+			<-graceful
+			return
+		}
+		select {
+		case <-graceful:
+		case <-time.After(a.drainTimeout):
+			a.logger.Warn("gRPC calls still running after %s, closing them", a.drainTimeout)
+			server.Stop()
+		}
+	}()
+	return stopped
+}
+
+// admitHTTP counts the A2A calls selected by isCall while they run, and rejects them in
+// lame-duck mode with 503, closing the connection so that the caller reconnects elsewhere.
+// Cards, health checks and the dashboard are still served.
+func (a *AlohaServer) admitHTTP(next http.Handler, isCall func(r *http.Request) bool, reject func(w http.ResponseWriter)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isCall(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if a.lameDuck.Load() {
+			w.Header().Set("Connection", "close")
+			w.Header().Set("Retry-After", lameDuckRetryAfter)
+			reject(w)
+			return
+		}
+		a.inflight.Add(1)
+		defer a.inflight.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isJSONRPCCall selects the JSON-RPC calls, all POSTed to the root
+func isJSONRPCCall(r *http.Request) bool {
+	return r.URL.Path == "/" && r.Method == http.MethodPost
+}
+
+// isRESTCall selects the calls of the REST binding, all under /v1/
+func isRESTCall(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, "/v1/")
+}
+
+// rejectJSONRPC answers a JSON-RPC call received in lame-duck mode. The request is not
+// read, so the response has a null id.
+func rejectJSONRPC(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": nil, "error": errShuttingDown})
+}
+
+// rejectREST answers a REST call received in lame-duck mode
+func rejectREST(w http.ResponseWriter) {
+	writeRESTErrorStatus(w, http.StatusServiceUnavailable, errShuttingDown)
+}
+
+// grpcLifecycleOptions count the gRPC calls while they run and reject them with
+// UNAVAILABLE in lame-duck mode
+func (a *AlohaServer) grpcLifecycleOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if a.lameDuck.Load() {
+				return nil, status.Error(codes.Unavailable, errShuttingDown.Message)
+			}
+			a.inflight.Add(1)
+			defer a.inflight.Add(-1)
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if a.lameDuck.Load() {
+				return status.Error(codes.Unavailable, errShuttingDown.Message)
+			}
+			a.inflight.Add(1)
+			defer a.inflight.Add(-1)
+			return handler(srv, ss)
+		}),
+	}
+}
+
+// handleReadyz handles GET /readyz: like /healthz, except that it fails with 503 in
+// lame-duck mode, so that Kubernetes stops routing to an agent that is shutting down
+func (a *AlohaServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	report := healthReport{Status: healthLameDuck}
+	if !a.lameDuck.Load() {
+		report = a.Health(r.Context())
+	}
+	writeHealthReport(w, r, report)
+}
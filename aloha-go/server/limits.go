@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"net/http"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/aloha/a2a-go/pkg/protocol"
+	"google.golang.org/grpc"
+)
+
+// Default size limits, in bytes
+const (
+	defaultMaxRequestSize  = 16 << 20
+	defaultMaxPartSize     = 8 << 20
+	defaultMaxArtifactSize = 8 << 20
+)
+
+// sizeLimits bounds, in bytes, what the agent reads and writes, so that large file parts
+// cannot exhaust its memory. 0 disables a limit.
+type sizeLimits struct {
+	request  int // body of an HTTP request, or gRPC message
+	part     int // each part of an incoming message, decoded
+	artifact int // each outgoing artifact, all its chunks together
+}
+
+// SetSizeLimits bounds the size of requests, of the parts of incoming messages, and of
+// the artifacts the executors write, in bytes; 0 disables a limit. It must be called
+// before Start.
+func (a *AlohaServer) SetSizeLimits(request, part, artifact int) {
+	a.limits = sizeLimits{request: request, part: part, artifact: artifact}
+	a.router.maxArtifactSize = artifact
+}
+
+// limitRequestSize rejects the HTTP requests declaring a body larger than the limit with
+// 413, and cuts the others at the limit
+func (a *AlohaServer) limitRequestSize(next http.Handler, reject func(w http.ResponseWriter, err *protocol.Error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.limits.request > 0 {
+			if r.ContentLength > int64(a.limits.request) {
+				reject(w, protocol.ErrInvalidRequest.Withf("request body is %d bytes, above the %d bytes limit", r.ContentLength, a.limits.request))
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, int64(a.limits.request))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readRESTBody reads the body of a REST request, answering 413 if it is above the limit
+// and 400 if it cannot be read
+func readRESTBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		rejectRESTTooLarge(w, protocol.ErrInvalidRequest.Withf("request body is above the %d bytes limit", tooLarge.Limit))
+		return nil, false
+	case err != nil:
+		writeRESTError(w, protocol.ErrInvalidRequest.Withf("failed to read request body: %v", err))
+		return nil, false
+	}
+	return body, true
+}
+
+// rejectJSONRPCTooLarge answers a JSON-RPC request whose body is too large. The body is
+// not read, so the response has a null id.
+func rejectJSONRPCTooLarge(w http.ResponseWriter, err *protocol.Error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+	json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": nil, "error": err})
+}
+
+// rejectRESTTooLarge answers a REST request whose body is too large
+func rejectRESTTooLarge(w http.ResponseWriter, err *protocol.Error) {
+	writeRESTErrorStatus(w, http.StatusRequestEntityTooLarge, err)
+}
+
+// grpcSizeOptions bounds the gRPC messages received by the request limit. gRPC's own
+// default, 4 MB, applies to the messages sent.
+func (a *AlohaServer) grpcSizeOptions() []grpc.ServerOption {
+	limit := a.limits.request
+	if limit <= 0 || limit > math.MaxInt32 {
+		limit = math.MaxInt32
+	}
+	return []grpc.ServerOption{grpc.MaxRecvMsgSize(limit)}
+}
+
+// checkMessageParts rejects a message with a part larger than the limit
+func (l sizeLimits) checkMessageParts(params *a2a.MessageSendParams) error {
+	if l.part <= 0 || params == nil || params.Message == nil {
+		return nil
+	}
+	for i, part := range params.Message.Parts {
+		if size := partSize(part); size > l.part {
+			return fmt.Errorf("%w: part %d is %d bytes, above the %d bytes limit", a2a.ErrInvalidParams, i, size, l.part)
+		}
+	}
+	return nil
+}
+
+// partSize returns the size of a part's content: the text, the decoded file bytes, or
+// the encoded data
+func partSize(part a2a.Part) int {
+	switch p := part.(type) {
+	case a2a.TextPart:
+		return len(p.Text)
+	case a2a.FilePart:
+		if file, ok := p.File.(a2a.FileBytes); ok {
+			return base64.StdEncoding.DecodedLen(len(file.Bytes))
+		}
+	case a2a.DataPart:
+		data, _ := json.Marshal(p.Data)
+		return len(data)
+	}
+	return 0
+}
+
+// limitsHandler rejects the messages whose parts are too large before they reach the
+// executors
+type limitsHandler struct {
+	a2asrv.RequestHandler
+	limits *sizeLimits
+}
+
+// OnSendMessage implements a2asrv.RequestHandler
+func (h *limitsHandler) OnSendMessage(ctx context.Context, params *a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	if err := h.limits.checkMessageParts(params); err != nil {
+		return nil, err
+	}
+	return h.RequestHandler.OnSendMessage(ctx, params)
+}
+
+// OnSendMessageStream implements a2asrv.RequestHandler
+func (h *limitsHandler) OnSendMessageStream(ctx context.Context, params *a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	if err := h.limits.checkMessageParts(params); err != nil {
+		return func(yield func(a2a.Event, error) bool) {
+			yield(nil, err)
+		}
+	}
+	return h.RequestHandler.OnSendMessageStream(ctx, params)
+}
+
+// artifactLimitQueue fails the writes of artifacts larger than the limit, counting the
+// chunks appended to an artifact together
+type artifactLimitQueue struct {
+	eventqueue.Queue
+	limit int
+	sizes map[a2a.ArtifactID]int
+}
+
+// withArtifactLimit wraps the queue so that artifacts larger than limit are not written;
+// 0 leaves the queue as it is
+func withArtifactLimit(queue eventqueue.Queue, limit int) eventqueue.Queue {
+	if limit <= 0 {
+		return queue
+	}
+	return &artifactLimitQueue{Queue: queue, limit: limit, sizes: make(map[a2a.ArtifactID]int)}
+}
+
+// Write implements eventqueue.Writer
+func (q *artifactLimitQueue) Write(ctx context.Context, event a2a.Event) error {
+	if err := q.check(event); err != nil {
+		return err
+	}
+	return q.Queue.Write(ctx, event)
+}
+
+// WriteVersioned implements eventqueue.Writer
+func (q *artifactLimitQueue) WriteVersioned(ctx context.Context, event a2a.Event, version a2a.TaskVersion) error {
+	if err := q.check(event); err != nil {
+		return err
+	}
+	return q.Queue.WriteVersioned(ctx, event, version)
+}
+
+// check adds the parts of an artifact update to the artifact's size
+func (q *artifactLimitQueue) check(event a2a.Event) error {
+	update, ok := event.(*a2a.TaskArtifactUpdateEvent)
+	if !ok || update.Artifact == nil {
+		return nil
+	}
+	size := 0
+	if update.Append {
+		size = q.sizes[update.Artifact.ID]
+	}
+	for _, part := range update.Artifact.Parts {
+		size += partSize(part)
+	}
+	if size > q.limit {
+		return fmt.Errorf("%w: artifact %s reaches %d bytes, above the %d bytes limit", a2a.ErrInvalidAgentResponse, update.Artifact.ID, size, q.limit)
+	}
+	q.sizes[update.Artifact.ID] = size
+	return nil
+}
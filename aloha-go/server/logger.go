@@ -1,4 +1,4 @@
-package main
+package server
 
 import (
 	"fmt"
@@ -66,30 +66,30 @@ func (l *Logger) format(level, msg string) string {
 
 // Debug logs a DEBUG level message.
 func (l *Logger) Debug(format string, args ...interface{}) {
-	log.Printf(l.format("DEBUG", fmt.Sprintf(format, args...)))
+	log.Print(l.format("DEBUG", fmt.Sprintf(format, args...)))
 }
 
 // Info logs an INFO level message.
 func (l *Logger) Info(format string, args ...interface{}) {
-	log.Printf(l.format("INFO", fmt.Sprintf(format, args...)))
+	log.Print(l.format("INFO", fmt.Sprintf(format, args...)))
 }
 
 // Warn logs a WARN level message.
 func (l *Logger) Warn(format string, args ...interface{}) {
-	log.Printf(l.format("WARN", fmt.Sprintf(format, args...)))
+	log.Print(l.format("WARN", fmt.Sprintf(format, args...)))
 }
 
 // Error logs an ERROR level message.
 func (l *Logger) Error(format string, args ...interface{}) {
-	log.Printf(l.format("ERROR", fmt.Sprintf(format, args...)))
+	log.Print(l.format("ERROR", fmt.Sprintf(format, args...)))
 }
 
 // Fatal logs an ERROR level message and exits.
 func (l *Logger) Fatal(format string, args ...interface{}) {
-	log.Fatalf(l.format("ERROR", fmt.Sprintf(format, args...)))
+	log.Fatal(l.format("ERROR", fmt.Sprintf(format, args...)))
 }
 
 // Println logs an INFO level message (for compatibility with log.Println style).
 func (l *Logger) Println(msg string) {
-	log.Printf(l.format("INFO", msg))
+	log.Print(l.format("INFO", msg))
 }
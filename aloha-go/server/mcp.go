@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/cli"
+	"github.com/aloha/a2a-go/pkg/mcp"
+	"github.com/aloha/a2a-go/pkg/tools"
+	"github.com/spf13/cobra"
+)
+
+// mcpConnectTimeout bounds connecting to an MCP server and listing its tools
+const mcpConnectTimeout = 10 * time.Second
+
+// mcpFileConfig is the part of the config file read by aloha mcp: flag values by name
+type mcpFileConfig struct {
+	MCP map[string]string `yaml:"mcp"`
+}
+
+// MCPCommand returns the aloha mcp command, which offers the dice tools to MCP clients
+// such as desktop LLM applications and IDEs
+func MCPCommand() *cobra.Command {
+	var listen string
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve the dice tools to MCP clients over stdio or streamable HTTP",
+		Long: `Serve roll_dice, check_prime and the other dice tools with the Model Context Protocol.
+
+By default the server speaks over stdin and stdout, as MCP clients expect of servers they
+start themselves; logs go to stderr. With --listen it serves streamable HTTP at /mcp.`,
+		Example: `  aloha mcp
+  aloha mcp --listen localhost:12300`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.ApplyEnv(cmd.Flags()); err != nil {
+				return err
+			}
+			var file mcpFileConfig
+			path, _, err := cli.ReadConfig(&file)
+			if err != nil {
+				return err
+			}
+			return cli.ApplyDefaults(cmd.Flags(), file.MCP, path)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMCP(cmd.Context(), listen)
+		},
+	}
+	cmd.Flags().StringVar(&listen, "listen", "", "Serve streamable HTTP at /mcp on this address instead of stdio")
+	cli.BindEnv(cmd.Flags(), "listen", "MCP_LISTEN")
+	return cmd
+}
+
+// runMCP serves the builtin tools until stdin ends or, over HTTP, a shutdown signal
+func runMCP(ctx context.Context, listen string) error {
+	logger := NewLogger("server.mcp")
+	server := mcp.NewServer("aloha-dice", "1.0.0", tools.NewRegistry(tools.Builtin()...))
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if listen == "" {
+		logger.Info("Serving MCP over stdio")
+		return server.ServeStdio(ctx, os.Stdin, os.Stdout)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", server)
+	httpServer := &http.Server{Addr: listen, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		logger.Info("Shutdown signal received, stopping MCP server...")
+		httpServer.Shutdown(context.Background())
+	}()
+
+	logger.Info("Serving MCP on http://%s/mcp", listen)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	logger.Info("MCP server stopped")
+	return nil
+}
+
+// ConnectMCPServers offers the tools of external MCP servers to the model alongside the
+// builtin ones. Each target is an http(s) URL or a command starting a stdio server. A
+// server that cannot be reached, or a tool whose name is taken, is skipped with a warning.
+func (e *DiceAgentExecutor) ConnectMCPServers(ctx context.Context, targets []string) {
+	info := mcp.Implementation{Name: "aloha-dice-agent", Version: "1.0.0"}
+	for _, target := range targets {
+		connectCtx, cancel := context.WithTimeout(ctx, mcpConnectTimeout)
+		client, err := mcp.Connect(connectCtx, target, info)
+		if err != nil {
+			cancel()
+			e.logger.Warn("Skipping MCP server %s: %v", target, err)
+			continue
+		}
+		remoteTools, err := client.Tools(connectCtx)
+		cancel()
+		if err != nil {
+			client.Close()
+			e.logger.Warn("Skipping MCP server %s: failed to list tools: %v", target, err)
+			continue
+		}
+
+		var registered []string
+		for _, tool := range remoteTools {
+			if err := e.tools.Register(tool); err != nil {
+				e.logger.Warn("Skipping tool %s of MCP server %s: %v", tool.Name(), client.Server().Name, err)
+				continue
+			}
+			registered = append(registered, tool.Name())
+		}
+		e.mcpClients = append(e.mcpClients, client)
+		e.logger.Info("Connected to MCP server %s (%s): tools %v", client.Server().Name, target, registered)
+	}
+	if len(e.mcpClients) > 0 && !e.useLLM {
+		e.logger.Warn("MCP tools are only offered to the LLM; pattern matching uses the builtin tools")
+	}
+}
+
+// Close disconnects from the MCP servers, stopping those started as commands
+func (e *DiceAgentExecutor) Close() error {
+	for _, client := range e.mcpClients {
+		if err := client.Close(); err != nil {
+			e.logger.Warn("Failed to close MCP server %s: %v", client.Target(), err)
+		}
+	}
+	e.mcpClients = nil
+	return nil
+}
+
+// checkMCPServers reports whether each connected MCP server answers a ping
+func (e *DiceAgentExecutor) checkMCPServers(ctx context.Context, health map[string]subsystemHealth) {
+	for i, client := range e.mcpClients {
+		// Servers are named after themselves, so the same server may be connected twice
+		name := "mcp:" + client.Server().Name
+		if _, taken := health[name]; taken {
+			name = fmt.Sprintf("%s#%d", name, i+1)
+		}
+		health[name] = checkSubsystem(ctx, false, func(ctx context.Context) (string, error) {
+			if err := client.Ping(ctx); err != nil {
+				return "", fmt.Errorf("%s: %w", client.Target(), err)
+			}
+			return client.Target(), nil
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+
+	"github.com/aloha/a2a-go/pkg/mdns"
+)
+
+// EnableMDNS makes Start advertise the agent on the local network with mDNS while the
+// server runs. instance names the agent, the card name if empty; agentURL is the card
+// URL published in the TXT record, the host's address and card port if empty.
+func (a *AlohaServer) EnableMDNS(instance, agentURL string) {
+	a.mdnsEnabled = true
+	a.mdnsInstance = instance
+	a.mdnsAgentURL = agentURL
+}
+
+// advertiseMDNS answers mDNS queries for _a2a._tcp agents until ctx is done, then
+// announces that the agent is gone
+func (a *AlohaServer) advertiseMDNS(ctx context.Context) {
+	card, _ := a.AgentCard(ctx)
+	instance := a.mdnsInstance
+	if instance == "" {
+		instance = card.Name
+	}
+	agentURL := a.mdnsAgentURL
+	if agentURL == "" {
+		agentURL = mdns.LocalCardURL(a.cardPort())
+	}
+
+	responder, err := mdns.Advertise(mdns.Service{
+		Instance: instance,
+		Port:     a.cardPort(),
+		TXT:      map[string]string{mdns.TXTCardURL: agentURL, mdns.TXTVersion: card.Version},
+	})
+	if err != nil {
+		a.logger.Warn("mDNS advertisement failed: %v", err)
+		return
+	}
+	a.logger.Info("Advertising %q as %s on the local network, card at %s", instance, mdns.ServiceType, agentURL)
+
+	<-ctx.Done()
+	responder.Close()
+}
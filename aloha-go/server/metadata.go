@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"maps"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// echoedMetadataKeys lists the message metadata keys copied back onto response events
+var echoedMetadataKeys = []string{"locale", "traceparent", "tracestate", "baggage", "correlationId"}
+
+// Ensure messageMetadataInterceptor implements a2asrv.RequestContextInterceptor
+var _ a2asrv.RequestContextInterceptor = (*messageMetadataInterceptor)(nil)
+
+// messageMetadataInterceptor surfaces a2a.Message.Metadata in RequestContext.Metadata.
+// Request-level metadata takes precedence over message metadata with the same key.
+type messageMetadataInterceptor struct{}
+
+// Intercept implements a2asrv.RequestContextInterceptor
+func (messageMetadataInterceptor) Intercept(ctx context.Context, reqCtx *a2asrv.RequestContext) (context.Context, error) {
+	if reqCtx.Message == nil || len(reqCtx.Message.Metadata) == 0 {
+		return ctx, nil
+	}
+
+	merged := maps.Clone(reqCtx.Message.Metadata)
+	maps.Copy(merged, reqCtx.Metadata)
+	reqCtx.Metadata = merged
+	return ctx, nil
+}
+
+// responseMetadata returns the subset of request metadata to echo back on response events
+func responseMetadata(reqCtx *a2asrv.RequestContext) map[string]any {
+	var echoed map[string]any
+	for _, key := range echoedMetadataKeys {
+		if value, ok := reqCtx.Metadata[key]; ok {
+			if echoed == nil {
+				echoed = make(map[string]any)
+			}
+			echoed[key] = value
+		}
+	}
+	return echoed
+}
+
+// metadataQueue decorates an event queue, attaching echoed metadata to every written event
+type metadataQueue struct {
+	eventqueue.Queue
+	metadata map[string]any
+}
+
+// withResponseMetadata wraps the queue so events carry the request's echoed metadata
+func withResponseMetadata(queue eventqueue.Queue, reqCtx *a2asrv.RequestContext) eventqueue.Queue {
+	metadata := responseMetadata(reqCtx)
+	if len(metadata) == 0 {
+		return queue
+	}
+	return &metadataQueue{Queue: queue, metadata: metadata}
+}
+
+// Write implements eventqueue.Writer
+func (q *metadataQueue) Write(ctx context.Context, event a2a.Event) error {
+	q.apply(event)
+	return q.Queue.Write(ctx, event)
+}
+
+// WriteVersioned implements eventqueue.Writer
+func (q *metadataQueue) WriteVersioned(ctx context.Context, event a2a.Event, version a2a.TaskVersion) error {
+	q.apply(event)
+	return q.Queue.WriteVersioned(ctx, event, version)
+}
+
+func (q *metadataQueue) apply(event a2a.Event) {
+	for key, value := range q.metadata {
+		if _, exists := event.Meta()[key]; !exists {
+			event.SetMeta(key, value)
+		}
+	}
+}
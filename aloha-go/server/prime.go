@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/aloha/a2a-go/pkg/tools"
+)
+
+// primePrompt is the LLM's system prompt for prime checking
+const primePrompt = `You are a math agent that checks if numbers are prime.
+
+When asked to check if numbers are prime, call the check_prime tool with a list of integers written as decimal strings, such as ["17", "170141183460469231731687303715884105727"].
+
+Always use the tool - never try to check primes yourself.
+Be conversational and friendly in your responses.`
+
+// Ensure PrimeAgentExecutor implements SkillExecutor
+var _ SkillExecutor = (*PrimeAgentExecutor)(nil)
+
+// PrimeAgentExecutor serves the check-prime skill. It answers through the LLM of the dice
+// executor it is created from, offering it the check_prime tool only.
+type PrimeAgentExecutor struct {
+	*DiceAgentExecutor
+}
+
+// NewPrimeAgentExecutor creates a prime executor sharing the LLM connection, tool cache
+// and token streaming setting of dice. MCP tools are left to the dice executor.
+func NewPrimeAgentExecutor(dice *DiceAgentExecutor) *PrimeAgentExecutor {
+	executor := *dice
+	executor.tools = tools.NewRegistry(tools.CheckPrimeTool{})
+	executor.mcpClients = nil
+	executor.logger = NewLogger("server.prime")
+	executor.prompt = primePrompt
+
+	prime := &PrimeAgentExecutor{DiceAgentExecutor: &executor}
+	executor.patterns = prime.processWithPatterns
+	return prime
+}
+
+// Skills returns the agent card skills served by this executor
+func (e *PrimeAgentExecutor) Skills() []a2a.AgentSkill {
+	return []a2a.AgentSkill{
+		{
+			ID:          "check-prime",
+			Name:        "Prime Checker",
+			Description: "Checks if numbers are prime",
+			Tags:        []string{"math", "prime"},
+			Examples:    []string{"Is 17 prime?"},
+		},
+	}
+}
+
+// processWithPatterns checks the numbers found in the message
+func (e *PrimeAgentExecutor) processWithPatterns(ctx context.Context, messageText string, data *toolData) (string, error) {
+	numbers := extractNumbers(messageText)
+	if len(numbers) == 0 {
+		return "Please provide numbers to check for primality.", nil
+	}
+	result, err := e.callTool(ctx, "check_prime", map[string]interface{}{"numbers": numbers}, data)
+	if err != nil {
+		return "", err
+	}
+	return result.Output.(tools.TextResult).Result, nil
+}
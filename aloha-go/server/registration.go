@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/registry"
+)
+
+// Timing of the registration with the agent registry
+const (
+	registrationRetryInterval = 10 * time.Second // wait before retrying a failed registration
+	registrationCallTimeout   = 5 * time.Second  // bounds each call to the registry
+)
+
+// EnableRegistration makes Start register the agent with the registry at registryURL
+// and keep it registered while the server runs. agentURL is where clients fetch the
+// agent card; empty uses the local card URL.
+func (a *AlohaServer) EnableRegistration(registryURL, agentURL string) {
+	a.registry = registry.NewClient(registryURL, nil)
+	a.registryAgentURL = agentURL
+}
+
+// cardURL returns the local URL serving the agent card
+func (a *AlohaServer) cardURL() string {
+	return fmt.Sprintf("http://localhost:%d", a.cardPort())
+}
+
+// cardPort returns the port serving the agent card: the JSON-RPC port in jsonrpc mode,
+// the REST port otherwise
+func (a *AlohaServer) cardPort() int {
+	if a.transportMode == "jsonrpc" {
+		return a.jsonrpcPort
+	}
+	return a.restPort
+}
+
+// keepRegistered registers the agent until ctx is done, then deregisters it. The
+// registration is renewed at a third of its lifetime, sending the current card so that
+// reloads reach the registry, and retried while the registry is unreachable.
+func (a *AlohaServer) keepRegistered(ctx context.Context) {
+	agentURL := a.registryAgentURL
+	if agentURL == "" {
+		agentURL = a.cardURL()
+	}
+
+	registered := false
+	for {
+		wait := registrationRetryInterval
+		card, _ := a.AgentCard(ctx)
+		callCtx, cancel := context.WithTimeout(ctx, registrationCallTimeout)
+		registration, err := a.registry.Register(callCtx, agentURL, card)
+		cancel()
+		switch {
+		case err != nil && ctx.Err() == nil:
+			a.logger.Warn("Agent registration failed, retrying in %s: %v", wait, err)
+		case err == nil:
+			if !registered {
+				a.logger.Info("Registered with the agent registry as %s", agentURL)
+				registered = true
+			}
+			wait = max(registration.TTL()/3, time.Second)
+		}
+
+		select {
+		case <-ctx.Done():
+			if registered {
+				// The server is stopping, so ctx can no longer bound the call
+				callCtx, cancel := context.WithTimeout(context.Background(), registrationCallTimeout)
+				defer cancel()
+				if err := a.registry.Deregister(callCtx, agentURL); err != nil {
+					a.logger.Warn("Agent deregistration failed: %v", err)
+				} else {
+					a.logger.Info("Deregistered from the agent registry")
+				}
+			}
+			return
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+)
+
+// defaultReplayBuffer is the number of events kept per running task for replay
+const defaultReplayBuffer = 1000
+
+// Ensure replayManager implements eventqueue.Manager
+var _ eventqueue.Manager = (*replayManager)(nil)
+
+// replayManager keeps the events of each running task, so that a client resubscribing
+// mid-task receives every event since the task started before the new ones, instead of
+// the new ones only. The events are kept until the task's execution ends. Each client
+// reads the events from its own buffer, under the backpressure policy.
+type replayManager struct {
+	eventqueue.Manager
+
+	mu   sync.Mutex
+	logs map[a2a.TaskID]*replayLog
+	// limit bounds the events kept per task; 0 disables replay
+	limit        int
+	backpressure streamBackpressure
+	logger       *Logger
+}
+
+// replayLog holds the events written to a task's queues
+type replayLog struct {
+	mu        sync.Mutex
+	events    []replayEvent
+	truncated bool // the limit was reached: the task's events are no longer replayed
+}
+
+// replayEvent is an event with the task version it moved the task to
+type replayEvent struct {
+	event   a2a.Event
+	version a2a.TaskVersion
+}
+
+// newReplayManager returns a manager of in-memory queues replaying up to limit events per
+// task. The SDK's queues hold a single event: the clients' buffers hold the others.
+func newReplayManager(limit int) *replayManager {
+	return &replayManager{
+		Manager:      eventqueue.NewInMemoryManager(eventqueue.WithQueueBufferSize(1)),
+		logs:         make(map[a2a.TaskID]*replayLog),
+		limit:        limit,
+		backpressure: streamBackpressure{buffer: defaultStreamBuffer, policy: overflowBlock},
+		logger:       NewLogger("server.replay"),
+	}
+}
+
+// SetReplayBuffer bounds the events kept per running task and replayed to resubscribing
+// clients; a task with more events is no longer replayed. 0 disables replay. It must be
+// called before Start.
+func (a *AlohaServer) SetReplayBuffer(events int) {
+	a.queues.limit = events
+}
+
+// GetOrCreate implements eventqueue.Manager. The execution of a task writes its events
+// to the queue created first.
+func (m *replayManager) GetOrCreate(ctx context.Context, taskID a2a.TaskID) (eventqueue.Queue, error) {
+	queue, err := m.Manager.GetOrCreate(ctx, taskID)
+	if err != nil || m.limit <= 0 {
+		return queue, err
+	}
+	m.mu.Lock()
+	log, ok := m.logs[taskID]
+	if !ok {
+		log = &replayLog{}
+		m.logs[taskID] = log
+	}
+	m.mu.Unlock()
+	return &replayQueue{Queue: queue, manager: m, taskID: taskID, log: log}, nil
+}
+
+// Get implements eventqueue.Manager. The queues of subscribers start with the events
+// written so far.
+func (m *replayManager) Get(ctx context.Context, taskID a2a.TaskID) (eventqueue.Queue, bool) {
+	m.mu.Lock()
+	log, ok := m.logs[taskID]
+	m.mu.Unlock()
+	if !ok {
+		queue, ok := m.Manager.Get(ctx, taskID)
+		if !ok {
+			return nil, false
+		}
+		return newStreamQueue(queue, taskID, m.backpressure), true
+	}
+
+	// Connecting and copying the log under its lock means that an event is either in
+	// the copy or received by the queue, possibly both, but never missed
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	queue, ok := m.Manager.Get(ctx, taskID)
+	if !ok {
+		return nil, false
+	}
+	replay := &replayQueue{Queue: queue, manager: m, taskID: taskID, log: log}
+	if !log.truncated && len(log.events) > 0 {
+		replay.pending = append([]replayEvent(nil), log.events...)
+		replay.replayed = make(map[a2a.Event]bool, len(log.events))
+		for _, e := range log.events {
+			replay.replayed[e.event] = true
+		}
+	}
+	return newStreamQueue(replay, taskID, m.backpressure), true
+}
+
+// Destroy implements eventqueue.Manager, dropping the task's events
+func (m *replayManager) Destroy(ctx context.Context, taskID a2a.TaskID) error {
+	m.mu.Lock()
+	delete(m.logs, taskID)
+	m.mu.Unlock()
+	return m.Manager.Destroy(ctx, taskID)
+}
+
+// replayQueue is a task queue that records the events written to it, and reads the
+// events replayed to it before the ones it receives
+type replayQueue struct {
+	eventqueue.Queue
+	manager *replayManager
+	taskID  a2a.TaskID
+	log     *replayLog
+
+	pending  []replayEvent
+	replayed map[a2a.Event]bool // received again if written while the queue connected
+}
+
+// Write implements eventqueue.Writer
+func (q *replayQueue) Write(ctx context.Context, event a2a.Event) error {
+	return q.WriteVersioned(ctx, event, a2a.TaskVersionMissing)
+}
+
+// WriteVersioned implements eventqueue.Writer, recording the event before writing it
+func (q *replayQueue) WriteVersioned(ctx context.Context, event a2a.Event, version a2a.TaskVersion) error {
+	q.log.mu.Lock()
+	switch {
+	case q.log.truncated:
+	case len(q.log.events) >= q.manager.limit:
+		q.log.truncated = true
+		q.log.events = nil
+		q.manager.logger.Warn("Task %s has more than %d events, no longer replaying them", q.taskID, q.manager.limit)
+	default:
+		q.log.events = append(q.log.events, replayEvent{event: event, version: version})
+	}
+	q.log.mu.Unlock()
+	return q.Queue.WriteVersioned(ctx, event, version)
+}
+
+// Read implements eventqueue.Reader
+func (q *replayQueue) Read(ctx context.Context) (a2a.Event, a2a.TaskVersion, error) {
+	if len(q.pending) > 0 {
+		e := q.pending[0]
+		q.pending = q.pending[1:]
+		return e.event, e.version, nil
+	}
+	for {
+		event, version, err := q.Queue.Read(ctx)
+		if err != nil || !q.replayed[event] {
+			// Events are received in order: once one was not replayed, none will be
+			q.replayed = nil
+			return event, version, err
+		}
+		delete(q.replayed, event)
+	}
+}
@@ -0,0 +1,214 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/a2aproject/a2a-go/a2asrv/eventqueue"
+	"github.com/aloha/a2a-go/pkg/tools"
+)
+
+// skillIDMetadataKey is the message metadata key clients use to request a specific skill
+const skillIDMetadataKey = "skillId"
+
+// SkillExecutor is an AgentExecutor that advertises the skills it can serve
+type SkillExecutor interface {
+	a2asrv.AgentExecutor
+	Skills() []a2a.AgentSkill
+}
+
+// Ensure SkillRouter implements a2asrv.AgentExecutor and a2asrv.AgentExecutionCleaner
+var (
+	_ a2asrv.AgentExecutor         = (*SkillRouter)(nil)
+	_ a2asrv.AgentExecutionCleaner = (*SkillRouter)(nil)
+)
+
+// SkillRouter hosts several SkillExecutors behind one request handler.
+// Requests are routed by the skill ID in message metadata when present,
+// otherwise by a keyword classifier over skill names and tags.
+type SkillRouter struct {
+	mu        sync.RWMutex
+	executors []SkillExecutor
+	bySkill   map[string]SkillExecutor
+
+	// tasks remembers which executor owns a task so follow-ups and cancels reach it
+	tasks sync.Map
+
+	// maxArtifactSize bounds the artifacts the executors write; 0 disables the limit
+	maxArtifactSize int
+
+	logger *Logger
+}
+
+// NewSkillRouter creates an empty router
+func NewSkillRouter() *SkillRouter {
+	return &SkillRouter{
+		bySkill: make(map[string]SkillExecutor),
+		logger:  NewLogger("server.router"),
+	}
+}
+
+// Register adds an executor and its skills to the router.
+// The first registered executor is the default route.
+func (r *SkillRouter) Register(executor SkillExecutor) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, skill := range executor.Skills() {
+		if _, exists := r.bySkill[skill.ID]; exists {
+			return fmt.Errorf("skill %q is already registered", skill.ID)
+		}
+	}
+	for _, skill := range executor.Skills() {
+		r.bySkill[skill.ID] = executor
+		r.logger.Info("Registered skill: %s", skill.ID)
+	}
+	r.executors = append(r.executors, executor)
+	return nil
+}
+
+// CheckHealth reports the dependencies of the executors implementing HealthChecker
+func (r *SkillRouter) CheckHealth(ctx context.Context) map[string]subsystemHealth {
+	r.mu.RLock()
+	executors := append([]SkillExecutor(nil), r.executors...)
+	r.mu.RUnlock()
+
+	report := make(map[string]subsystemHealth)
+	for _, executor := range executors {
+		if checker, ok := executor.(HealthChecker); ok {
+			for name, health := range checker.CheckHealth(ctx) {
+				report[name] = health
+			}
+		}
+	}
+	return report
+}
+
+// Tools returns the tools of the executors answering with tools, in registration order.
+// A tool offered by several executors is listed once.
+func (r *SkillRouter) Tools() []tools.Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var all []tools.Tool
+	seen := make(map[string]bool)
+	for _, executor := range r.executors {
+		if lister, ok := executor.(ToolLister); ok {
+			for _, tool := range lister.Tools() {
+				if !seen[tool.Name()] {
+					seen[tool.Name()] = true
+					all = append(all, tool)
+				}
+			}
+		}
+	}
+	return all
+}
+
+// Skills returns the merged skills of all registered executors in registration order
+func (r *SkillRouter) Skills() []a2a.AgentSkill {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var skills []a2a.AgentSkill
+	for _, executor := range r.executors {
+		skills = append(skills, executor.Skills()...)
+	}
+	return skills
+}
+
+// Execute implements a2asrv.AgentExecutor - delegates to the executor selected for the request.
+func (r *SkillRouter) Execute(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+	executor, err := r.route(reqCtx)
+	if err != nil {
+		return err
+	}
+	r.tasks.Store(reqCtx.TaskID, executor)
+	return executor.Execute(ctx, reqCtx, withArtifactLimit(queue, r.maxArtifactSize))
+}
+
+// Cancel implements a2asrv.AgentExecutor - delegates to the executor owning the task.
+func (r *SkillRouter) Cancel(ctx context.Context, reqCtx *a2asrv.RequestContext, queue eventqueue.Queue) error {
+	executor, err := r.route(reqCtx)
+	if err != nil {
+		return err
+	}
+	return executor.Cancel(ctx, reqCtx, queue)
+}
+
+// Cleanup implements a2asrv.AgentExecutionCleaner - forgets finished tasks.
+func (r *SkillRouter) Cleanup(ctx context.Context, reqCtx *a2asrv.RequestContext, result a2a.SendMessageResult, err error) {
+	if executor, ok := r.tasks.Load(reqCtx.TaskID); ok {
+		if cleaner, ok := executor.(a2asrv.AgentExecutionCleaner); ok {
+			cleaner.Cleanup(ctx, reqCtx, result, err)
+		}
+	}
+	if task, ok := result.(*a2a.Task); ok && err == nil && !task.Status.State.Terminal() {
+		return
+	}
+	r.tasks.Delete(reqCtx.TaskID)
+}
+
+// route selects the executor for a request
+func (r *SkillRouter) route(reqCtx *a2asrv.RequestContext) (SkillExecutor, error) {
+	if executor, ok := r.tasks.Load(reqCtx.TaskID); ok {
+		return executor.(SkillExecutor), nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.executors) == 0 {
+		return nil, fmt.Errorf("no executors registered")
+	}
+
+	if skillID := requestedSkillID(reqCtx); skillID != "" {
+		executor, ok := r.bySkill[skillID]
+		if !ok {
+			return nil, fmt.Errorf("unknown skill %q: %w", skillID, a2a.ErrInvalidParams)
+		}
+		r.logger.Info("Routing task %s to skill %s (requested)", reqCtx.TaskID, skillID)
+		return executor, nil
+	}
+
+	messageText := strings.ToLower(extractTextFromA2AMessage(reqCtx.Message))
+	best, bestScore := r.executors[0], 0
+	for _, executor := range r.executors {
+		for _, skill := range executor.Skills() {
+			if score := scoreSkill(skill, messageText); score > bestScore {
+				best, bestScore = executor, score
+			}
+		}
+	}
+	r.logger.Debug("Routing task %s by classifier (score=%d)", reqCtx.TaskID, bestScore)
+	return best, nil
+}
+
+// requestedSkillID returns the skill ID requested in message or request metadata
+func requestedSkillID(reqCtx *a2asrv.RequestContext) string {
+	if reqCtx.Message != nil {
+		if id, ok := reqCtx.Message.Metadata[skillIDMetadataKey].(string); ok && id != "" {
+			return id
+		}
+	}
+	if id, ok := reqCtx.Metadata[skillIDMetadataKey].(string); ok {
+		return id
+	}
+	return ""
+}
+
+// scoreSkill counts how many of the skill's tags and name words appear in the message
+func scoreSkill(skill a2a.AgentSkill, messageText string) int {
+	score := 0
+	keywords := append(strings.Fields(strings.ToLower(skill.Name)), skill.Tags...)
+	for _, keyword := range keywords {
+		if keyword != "" && strings.Contains(messageText, strings.ToLower(keyword)) {
+			score++
+		}
+	}
+	return score
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// TestSkillRouting sends messages tagged with a skillId to a server hosting the dice and
+// prime executors, and checks that the tagged skill's executor answers them
+func TestSkillRouting(t *testing.T) {
+	h, err := startHarness(nil)
+	if err != nil {
+		t.Fatalf("startHarness: %v", err)
+	}
+	defer h.Close()
+
+	tests := []struct {
+		name    string
+		skillID string
+		text    string
+		want    string
+	}{
+		{"prime", "check-prime", "Check 17 and 18", "17 are prime numbers."},
+		{"dice", "roll-dice", "Roll a 6-sided dice", "I rolled a 6-sided dice"},
+		{"prime ignores dice requests", "check-prime", "Roll a 6-sided dice", "None of the numbers are prime."},
+		{"dice ignores bare numbers", "roll-dice", "Check 17 and 18", "I can roll dice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: tt.text})
+			msg.Metadata = map[string]any{skillIDMetadataKey: tt.skillID}
+			task, err := h.sendSDK(context.Background(), a2a.TransportProtocolJSONRPC, msg)
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+			if text := artifactText(task); !strings.Contains(text, tt.want) {
+				t.Errorf("artifact text = %q, want it to contain %q", text, tt.want)
+			}
+		})
+	}
+}
+
+// TestSkillRouterRoute checks how the router picks an executor without a task to follow up
+func TestSkillRouterRoute(t *testing.T) {
+	dice := newDiceAgentExecutor("", "")
+	prime := NewPrimeAgentExecutor(dice)
+	router := NewSkillRouter()
+	for _, executor := range []SkillExecutor{dice, prime} {
+		if err := router.Register(executor); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		skillID string
+		text    string
+		want    SkillExecutor
+		wantErr error
+	}{
+		{name: "requested prime", skillID: "check-prime", text: "Roll a dice", want: prime},
+		{name: "requested dice", skillID: "roll-dice-multi", text: "Is 17 prime?", want: dice},
+		{name: "classified prime", text: "Is 17 prime?", want: prime},
+		{name: "classified dice", text: "Roll a dice and tell me if it is prime", want: dice},
+		{name: "default", text: "Hello", want: dice},
+		{name: "unknown skill", skillID: "tarot", text: "Hello", wantErr: a2a.ErrInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := a2a.NewMessage(a2a.MessageRoleUser, a2a.TextPart{Text: tt.text})
+			if tt.skillID != "" {
+				msg.Metadata = map[string]any{skillIDMetadataKey: tt.skillID}
+			}
+			got, err := router.route(&a2asrv.RequestContext{TaskID: a2a.NewTaskID(), Message: msg})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("route error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("route = %T %p, want %T %p", got, got, tt.want, tt.want)
+			}
+		})
+	}
+
+	if err := router.Register(NewPrimeAgentExecutor(dice)); err == nil {
+		t.Error("registering check-prime twice succeeded, want an error")
+	}
+}
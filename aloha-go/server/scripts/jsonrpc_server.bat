@@ -1,4 +1,3 @@
 @echo off
-cd /d %~dp0..
-set TRANSPORT_MODE=jsonrpc
-go run .
+cd /d %~dp0..\..
+go run ./aloha serve --transport jsonrpc
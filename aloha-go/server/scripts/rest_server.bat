@@ -1,3 +1,3 @@
 @echo off
-cd /d %~dp0..
-go run .
+cd /d %~dp0..\..
+go run ./aloha serve
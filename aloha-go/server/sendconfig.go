@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// sendConfigHandler applies the parts of a message's send configuration that the SDK
+// handler leaves out: acceptedOutputModes and the historyLength of the returned task.
+// blocking and pushNotificationConfig are already honored by the SDK.
+type sendConfigHandler struct {
+	a2asrv.RequestHandler
+	card a2asrv.AgentCardProducerFn
+}
+
+// OnSendMessage implements a2asrv.RequestHandler
+func (h *sendConfigHandler) OnSendMessage(ctx context.Context, params *a2a.MessageSendParams) (a2a.SendMessageResult, error) {
+	if err := h.checkOutputModes(ctx, params); err != nil {
+		return nil, err
+	}
+	result, err := h.RequestHandler.OnSendMessage(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if task, ok := result.(*a2a.Task); ok && params.Config != nil && params.Config.HistoryLength != nil {
+		return trimHistory(task, *params.Config.HistoryLength), nil
+	}
+	return result, nil
+}
+
+// OnSendMessageStream implements a2asrv.RequestHandler
+func (h *sendConfigHandler) OnSendMessageStream(ctx context.Context, params *a2a.MessageSendParams) iter.Seq2[a2a.Event, error] {
+	if err := h.checkOutputModes(ctx, params); err != nil {
+		return func(yield func(a2a.Event, error) bool) {
+			yield(nil, err)
+		}
+	}
+	return h.RequestHandler.OnSendMessageStream(ctx, params)
+}
+
+// checkOutputModes rejects a message whose acceptedOutputModes exclude every output mode of the agent
+func (h *sendConfigHandler) checkOutputModes(ctx context.Context, params *a2a.MessageSendParams) error {
+	if params == nil || params.Config == nil || len(params.Config.AcceptedOutputModes) == 0 {
+		return nil
+	}
+	card, err := h.card(ctx)
+	if err != nil {
+		return err
+	}
+	for _, offered := range card.DefaultOutputModes {
+		for _, accepted := range params.Config.AcceptedOutputModes {
+			if outputModeMatches(accepted, offered) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: the agent produces %s, the client accepts %s", a2a.ErrUnsupportedContentType,
+		strings.Join(card.DefaultOutputModes, ", "), strings.Join(params.Config.AcceptedOutputModes, ", "))
+}
+
+// outputModeMatches reports whether an accepted media range covers an offered output mode.
+// The short "text" mode used in agent cards stands for text/plain.
+func outputModeMatches(accepted, offered string) bool {
+	normalize := func(mode string) string {
+		mode = strings.ToLower(strings.TrimSpace(mode))
+		if mode == "text" {
+			return "text/plain"
+		}
+		return mode
+	}
+	accepted, offered = normalize(accepted), normalize(offered)
+	if accepted == "*/*" || accepted == offered {
+		return true
+	}
+	kind, _, _ := strings.Cut(offered, "/")
+	return accepted == kind+"/*"
+}
+
+// trimHistory returns the task with only its last length history messages, none if length <= 0
+func trimHistory(task *a2a.Task, length int) *a2a.Task {
+	trimmed := *task
+	switch {
+	case length <= 0:
+		trimmed.History = []*a2a.Message{}
+	case length < len(task.History):
+		trimmed.History = task.History[len(task.History)-length:]
+	}
+	return &trimmed
+}
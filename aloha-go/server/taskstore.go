@@ -0,0 +1,260 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// Page sizes accepted by tasks/list, as defined by the protocol
+const (
+	defaultTaskPageSize = 50
+	maxTaskPageSize     = 100
+)
+
+// Ensure taskStore implements a2asrv.TaskStore
+var _ a2asrv.TaskStore = (*taskStore)(nil)
+
+// taskStore is an in-memory task store. Unlike the SDK default it can list tasks for
+// anonymous callers; with authentication enabled, callers only see their own tasks.
+type taskStore struct {
+	mu    sync.RWMutex
+	tasks map[a2a.TaskID]*storedTask
+}
+
+// storedTask is a task snapshot with its version, owner and last update time
+type storedTask struct {
+	task    *a2a.Task
+	version a2a.TaskVersion
+	owner   string
+	updated time.Time
+}
+
+// newTaskStore creates an empty task store
+func newTaskStore() *taskStore {
+	return &taskStore{tasks: make(map[a2a.TaskID]*storedTask)}
+}
+
+// Save implements a2asrv.TaskStore, rejecting updates based on a stale version and
+// status changes the task lifecycle does not allow, such as leaving a terminal state
+func (s *taskStore) Save(ctx context.Context, task *a2a.Task, event a2a.Event, prev *a2a.Task, prevVersion a2a.TaskVersion) (a2a.TaskVersion, error) {
+	snapshot, err := copyTask(task)
+	if err != nil {
+		return a2a.TaskVersionMissing, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	version := a2a.TaskVersion(1)
+	owner := callerName(ctx)
+	if stored, ok := s.tasks[task.ID]; ok {
+		if prevVersion != a2a.TaskVersionMissing && stored.version != prevVersion {
+			return a2a.TaskVersionMissing, a2a.ErrConcurrentTaskModification
+		}
+		if err := checkTransition(stored.task, task); err != nil {
+			return a2a.TaskVersionMissing, err
+		}
+		version = stored.version + 1
+		owner = stored.owner
+	}
+	s.tasks[task.ID] = &storedTask{task: snapshot, version: version, owner: owner, updated: time.Now()}
+	return version, nil
+}
+
+// checkTransition reports an error if the task's state may not change from that of prev.
+// Updates keeping the state, such as new artifacts, are not checked.
+func checkTransition(prev, task *a2a.Task) error {
+	from, to := protocol.TaskState(prev.Status.State), protocol.TaskState(task.Status.State)
+	if from == to {
+		return nil
+	}
+	if err := from.CheckTransition(to); err != nil {
+		return fmt.Errorf("%w: task %s: %v", a2a.ErrInvalidAgentResponse, task.ID, err)
+	}
+	return nil
+}
+
+// Len returns the number of stored tasks, of all callers
+func (s *taskStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.tasks)
+}
+
+// Get implements a2asrv.TaskStore. A task is only found by the caller who created it,
+// so that other callers can neither read, cancel, resubscribe to nor continue it.
+func (s *taskStore) Get(ctx context.Context, taskID a2a.TaskID) (*a2a.Task, a2a.TaskVersion, error) {
+	s.mu.RLock()
+	stored, ok := s.tasks[taskID]
+	s.mu.RUnlock()
+	if !ok || stored.owner != callerName(ctx) {
+		return nil, a2a.TaskVersionMissing, a2a.ErrTaskNotFound
+	}
+
+	task, err := copyTask(stored.task)
+	if err != nil {
+		return nil, a2a.TaskVersionMissing, err
+	}
+	return task, stored.version, nil
+}
+
+// List implements a2asrv.TaskStore: matching tasks, most recently updated first.
+// The page token is the offset of the first task of the page.
+func (s *taskStore) List(ctx context.Context, req *a2a.ListTasksRequest) (*a2a.ListTasksResponse, error) {
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = defaultTaskPageSize
+	}
+	if pageSize < 1 || pageSize > maxTaskPageSize {
+		return nil, fmt.Errorf("%w: page size must be between 1 and %d, got %d", a2a.ErrInvalidParams, maxTaskPageSize, pageSize)
+	}
+	if req.HistoryLength < 0 {
+		return nil, fmt.Errorf("%w: history length must not be negative, got %d", a2a.ErrInvalidParams, req.HistoryLength)
+	}
+	offset := 0
+	if req.PageToken != "" {
+		var err error
+		if offset, err = strconv.Atoi(req.PageToken); err != nil || offset < 0 {
+			return nil, fmt.Errorf("%w: invalid page token %q", a2a.ErrInvalidParams, req.PageToken)
+		}
+	}
+
+	caller := callerName(ctx)
+	s.mu.RLock()
+	var matched []*storedTask
+	for _, stored := range s.tasks {
+		if stored.owner != caller ||
+			(req.ContextID != "" && stored.task.ContextID != req.ContextID) ||
+			(req.Status != a2a.TaskStateUnspecified && stored.task.Status.State != req.Status) ||
+			(req.LastUpdatedAfter != nil && stored.updated.Before(*req.LastUpdatedAfter)) {
+			continue
+		}
+		matched = append(matched, stored)
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(matched, func(a, b *storedTask) int {
+		if c := b.updated.Compare(a.updated); c != 0 {
+			return c
+		}
+		return strings.Compare(string(b.task.ID), string(a.task.ID))
+	})
+
+	resp := &a2a.ListTasksResponse{TotalSize: len(matched), PageSize: pageSize}
+	page := matched[min(offset, len(matched)):min(offset+pageSize, len(matched))]
+	if offset+pageSize < len(matched) {
+		resp.NextPageToken = strconv.Itoa(offset + pageSize)
+	}
+	for _, stored := range page {
+		task, err := copyTask(stored.task)
+		if err != nil {
+			return nil, err
+		}
+		if !req.IncludeArtifacts {
+			task.Artifacts = nil
+		}
+		if req.HistoryLength > 0 && len(task.History) > req.HistoryLength {
+			task.History = task.History[len(task.History)-req.HistoryLength:]
+		}
+		resp.Tasks = append(resp.Tasks, task)
+	}
+	return resp, nil
+}
+
+// taskOwnerHandler applies the task store's owner check to the calls the SDK handler
+// serves without loading the task: resubscribing and push notification configs. Other
+// callers get ErrTaskNotFound, as from Get.
+type taskOwnerHandler struct {
+	a2asrv.RequestHandler
+	tasks *taskStore
+}
+
+// OnResubscribeToTask implements a2asrv.RequestHandler
+func (h *taskOwnerHandler) OnResubscribeToTask(ctx context.Context, params *a2a.TaskIDParams) iter.Seq2[a2a.Event, error] {
+	if params != nil {
+		if err := h.checkOwner(ctx, params.ID); err != nil {
+			return func(yield func(a2a.Event, error) bool) {
+				yield(nil, err)
+			}
+		}
+	}
+	return h.RequestHandler.OnResubscribeToTask(ctx, params)
+}
+
+// OnGetTaskPushConfig implements a2asrv.RequestHandler
+func (h *taskOwnerHandler) OnGetTaskPushConfig(ctx context.Context, params *a2a.GetTaskPushConfigParams) (*a2a.TaskPushConfig, error) {
+	if params != nil {
+		if err := h.checkOwner(ctx, params.TaskID); err != nil {
+			return nil, err
+		}
+	}
+	return h.RequestHandler.OnGetTaskPushConfig(ctx, params)
+}
+
+// OnListTaskPushConfig implements a2asrv.RequestHandler
+func (h *taskOwnerHandler) OnListTaskPushConfig(ctx context.Context, params *a2a.ListTaskPushConfigParams) ([]*a2a.TaskPushConfig, error) {
+	if params != nil {
+		if err := h.checkOwner(ctx, params.TaskID); err != nil {
+			return nil, err
+		}
+	}
+	return h.RequestHandler.OnListTaskPushConfig(ctx, params)
+}
+
+// OnSetTaskPushConfig implements a2asrv.RequestHandler
+func (h *taskOwnerHandler) OnSetTaskPushConfig(ctx context.Context, params *a2a.TaskPushConfig) (*a2a.TaskPushConfig, error) {
+	if params != nil {
+		if err := h.checkOwner(ctx, params.TaskID); err != nil {
+			return nil, err
+		}
+	}
+	return h.RequestHandler.OnSetTaskPushConfig(ctx, params)
+}
+
+// OnDeleteTaskPushConfig implements a2asrv.RequestHandler
+func (h *taskOwnerHandler) OnDeleteTaskPushConfig(ctx context.Context, params *a2a.DeleteTaskPushConfigParams) error {
+	if params != nil {
+		if err := h.checkOwner(ctx, params.TaskID); err != nil {
+			return err
+		}
+	}
+	return h.RequestHandler.OnDeleteTaskPushConfig(ctx, params)
+}
+
+// checkOwner returns ErrTaskNotFound unless the caller created the task
+func (h *taskOwnerHandler) checkOwner(ctx context.Context, taskID a2a.TaskID) error {
+	_, _, err := h.tasks.Get(ctx, taskID)
+	return err
+}
+
+// callerName returns the authenticated caller's name, or "" for anonymous calls
+func callerName(ctx context.Context) string {
+	if callCtx, ok := a2asrv.CallContextFrom(ctx); ok && callCtx.User != nil && callCtx.User.Authenticated() {
+		return callCtx.User.Name()
+	}
+	return ""
+}
+
+// copyTask returns a deep copy of a task, so stored snapshots are never shared with callers
+func copyTask(task *a2a.Task) (*a2a.Task, error) {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy task %s: %w", task.ID, err)
+	}
+	var copied a2a.Task
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, fmt.Errorf("failed to copy task %s: %w", task.ID, err)
+	}
+	return &copied, nil
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/a2aproject/a2a-go/a2a"
+	"github.com/a2aproject/a2a-go/a2asrv"
+)
+
+// TestTaskStoreTransitions saves a task in one state and then in another, and checks that
+// the store only accepts the changes the task lifecycle allows
+func TestTaskStoreTransitions(t *testing.T) {
+	tests := []struct {
+		from, to a2a.TaskState
+		wantErr  bool
+	}{
+		{a2a.TaskStateSubmitted, a2a.TaskStateWorking, false},
+		{a2a.TaskStateSubmitted, a2a.TaskStateRejected, false},
+		{a2a.TaskStateWorking, a2a.TaskStateCompleted, false},
+		{a2a.TaskStateWorking, a2a.TaskStateInputRequired, false},
+		{a2a.TaskStateInputRequired, a2a.TaskStateWorking, false},
+		{a2a.TaskStateWorking, a2a.TaskStateCanceled, false},
+		{a2a.TaskStateWorking, a2a.TaskStateWorking, false},
+		{a2a.TaskStateCompleted, a2a.TaskStateCompleted, false},
+		{a2a.TaskStateWorking, a2a.TaskStateSubmitted, true},
+		{a2a.TaskStateWorking, a2a.TaskStateRejected, true},
+		{a2a.TaskStateCompleted, a2a.TaskStateWorking, true},
+		{a2a.TaskStateFailed, a2a.TaskStateCompleted, true},
+		{a2a.TaskStateCanceled, a2a.TaskStateWorking, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			ctx := context.Background()
+			store := newTaskStore()
+			task := &a2a.Task{ID: a2a.NewTaskID(), ContextID: a2a.NewContextID(), Status: a2a.TaskStatus{State: tt.from}}
+			version, err := store.Save(ctx, task, task, nil, a2a.TaskVersionMissing)
+			if err != nil {
+				t.Fatalf("Save(%s): %v", tt.from, err)
+			}
+
+			next := *task
+			next.Status = a2a.TaskStatus{State: tt.to}
+			_, err = store.Save(ctx, &next, a2a.NewStatusUpdateEvent(task, tt.to, nil), task, version)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Save(%s -> %s): %v", tt.from, tt.to, err)
+				}
+				return
+			}
+			if !errors.Is(err, a2a.ErrInvalidAgentResponse) {
+				t.Fatalf("Save(%s -> %s) error = %v, want %v", tt.from, tt.to, err, a2a.ErrInvalidAgentResponse)
+			}
+			stored, storedVersion, err := store.Get(ctx, task.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Status.State != tt.from || storedVersion != version {
+				t.Errorf("after a rejected update the task is %s at version %d, want %s at version %d",
+					stored.Status.State, storedVersion, tt.from, version)
+			}
+		})
+	}
+}
+
+// callerContext returns a context of a call authenticated as name, or of an anonymous call
+func callerContext(name string) context.Context {
+	ctx, callCtx := a2asrv.WithCallContext(context.Background(), nil)
+	if name != "" {
+		callCtx.User = &a2asrv.AuthenticatedUser{UserName: name}
+	}
+	return ctx
+}
+
+// TestTaskStoreOwner checks that a task is only found by the caller who created it,
+// through the store and through the calls the SDK serves without it
+func TestTaskStoreOwner(t *testing.T) {
+	store := newTaskStore()
+	task := &a2a.Task{ID: a2a.NewTaskID(), ContextID: a2a.NewContextID(), Status: a2a.TaskStatus{State: a2a.TaskStateWorking}}
+	if _, err := store.Save(callerContext("alice"), task, task, nil, a2a.TaskVersionMissing); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	handler := &taskOwnerHandler{RequestHandler: a2asrv.NewHandler(NewSkillRouter(), a2asrv.WithTaskStore(store)), tasks: store}
+
+	tests := []struct {
+		caller string
+		found  bool
+	}{
+		{"alice", true},
+		{"bob", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run("caller "+tt.caller, func(t *testing.T) {
+			ctx := callerContext(tt.caller)
+			_, _, err := store.Get(ctx, task.ID)
+			if found := err == nil; found != tt.found {
+				t.Errorf("Get error = %v, want found %v", err, tt.found)
+			}
+			if !tt.found && !errors.Is(err, a2a.ErrTaskNotFound) {
+				t.Errorf("Get error = %v, want %v", err, a2a.ErrTaskNotFound)
+			}
+
+			_, err = handler.OnGetTask(ctx, &a2a.TaskQueryParams{ID: task.ID})
+			if found := err == nil; found != tt.found {
+				t.Errorf("OnGetTask error = %v, want found %v", err, tt.found)
+			}
+			_, err = handler.OnCancelTask(ctx, &a2a.TaskIDParams{ID: task.ID})
+			if !tt.found && !errors.Is(err, a2a.ErrTaskNotFound) {
+				t.Errorf("OnCancelTask error = %v, want %v", err, a2a.ErrTaskNotFound)
+			}
+			for _, err := range handler.OnResubscribeToTask(ctx, &a2a.TaskIDParams{ID: task.ID}) {
+				if !tt.found && !errors.Is(err, a2a.ErrTaskNotFound) {
+					t.Errorf("OnResubscribeToTask error = %v, want %v", err, a2a.ErrTaskNotFound)
+				}
+				break
+			}
+			_, err = handler.OnListTaskPushConfig(ctx, &a2a.ListTaskPushConfigParams{TaskID: task.ID})
+			if !tt.found && !errors.Is(err, a2a.ErrTaskNotFound) {
+				t.Errorf("OnListTaskPushConfig error = %v, want %v", err, a2a.ErrTaskNotFound)
+			}
+		})
+	}
+}
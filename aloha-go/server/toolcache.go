@@ -0,0 +1,90 @@
+package server
+
+import (
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/aloha/a2a-go/pkg/tools"
+)
+
+// defaultToolCacheSize is the default maximum number of cached tool results
+const defaultToolCacheSize = 1000
+
+// toolCacheMetrics counts hits, misses and evictions per tool, served at /debug/vars
+var toolCacheMetrics = expvar.NewMap("tool_cache")
+
+// toolCache keeps the results of deterministic tools such as check_prime for a TTL,
+// keyed on the tool name and its normalized arguments. A nil cache computes every result.
+type toolCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]toolCacheEntry
+}
+
+type toolCacheEntry struct {
+	tool    string
+	result  tools.Result
+	created time.Time
+}
+
+// newToolCache creates a cache of at most maxEntries results kept for ttl
+func newToolCache(ttl time.Duration, maxEntries int) *toolCache {
+	return &toolCache{ttl: ttl, maxEntries: maxEntries, entries: make(map[string]toolCacheEntry)}
+}
+
+// do returns the cached result of tool for key, or computes, caches and returns it.
+// Errors are not cached.
+func (c *toolCache) do(tool, key string, compute func() (tools.Result, error)) (tools.Result, error) {
+	if c == nil {
+		return compute()
+	}
+	cacheKey := tool + "\x00" + key
+
+	c.mu.Lock()
+	entry, ok := c.entries[cacheKey]
+	if ok && time.Since(entry.created) < c.ttl {
+		c.mu.Unlock()
+		toolCacheMetrics.Add(tool+".hits", 1)
+		return entry.result, nil
+	}
+	c.mu.Unlock()
+	toolCacheMetrics.Add(tool+".misses", 1)
+
+	result, err := compute()
+	if err != nil {
+		return tools.Result{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.entries[cacheKey]; !exists && len(c.entries) >= c.maxEntries {
+		c.evict()
+	}
+	c.entries[cacheKey] = toolCacheEntry{tool: tool, result: result, created: time.Now()}
+	return result, nil
+}
+
+// evict drops the expired entries, or the oldest one if none has expired. Callers hold c.mu.
+func (c *toolCache) evict() {
+	var oldestKey string
+	var oldest toolCacheEntry
+	expired := false
+	for key, entry := range c.entries {
+		if time.Since(entry.created) >= c.ttl {
+			delete(c.entries, key)
+			toolCacheMetrics.Add(entry.tool+".evictions", 1)
+			expired = true
+			continue
+		}
+		if oldestKey == "" || entry.created.Before(oldest.created) {
+			oldestKey, oldest = key, entry
+		}
+	}
+	if !expired && oldestKey != "" {
+		delete(c.entries, oldestKey)
+		toolCacheMetrics.Add(oldest.tool+".evictions", 1)
+	}
+}
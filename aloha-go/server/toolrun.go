@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// defaultToolTimeout bounds a tool call unless TOOL_TIMEOUT or TOOL_TIMEOUT_<TOOL> says otherwise
+const defaultToolTimeout = 10 * time.Second
+
+// toolMetrics counts calls, errors, timeouts and panics per tool, and the total seconds
+// spent in each, served at /debug/vars
+var toolMetrics = expvar.NewMap("tools")
+
+// toolTimeout returns the time limit of a tool: TOOL_TIMEOUT_<TOOL> (e.g. TOOL_TIMEOUT_CHECK_PRIME),
+// then TOOL_TIMEOUT, then defaultToolTimeout
+func toolTimeout(tool string) time.Duration {
+	for _, key := range []string{"TOOL_TIMEOUT_" + strings.ToUpper(tool), "TOOL_TIMEOUT"} {
+		if value := os.Getenv(key); value != "" {
+			if timeout, err := time.ParseDuration(value); err == nil && timeout > 0 {
+				return timeout
+			}
+		}
+	}
+	return defaultToolTimeout
+}
+
+// toolOutcome is the outcome of a tool call run by runTool
+type toolOutcome struct {
+	output string
+	data   toolData
+	err    error
+}
+
+// runTool runs a tool call within the tool's time limit, turning a panic into an error
+// and recording the call in toolMetrics. A tool that times out keeps running in the
+// background, but its result and data are discarded.
+func (e *DiceAgentExecutor) runTool(ctx context.Context, tool string, data *toolData, call func(data *toolData) (string, error)) (string, error) {
+	timeout := toolTimeout(tool)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan toolOutcome, 1)
+	go func() {
+		var result toolOutcome
+		defer func() {
+			if r := recover(); r != nil {
+				e.logger.Error("Tool %s panicked: %v\n%s", tool, r, debug.Stack())
+				toolMetrics.Add(tool+".panics", 1)
+				result = toolOutcome{err: fmt.Errorf("tool %s failed unexpectedly: %v", tool, r)}
+			}
+			done <- result
+		}()
+		result.output, result.err = call(&result.data)
+	}()
+
+	var result toolOutcome
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			toolMetrics.Add(tool+".timeouts", 1)
+			result.err = fmt.Errorf("tool %s timed out after %s", tool, timeout)
+		} else {
+			result.err = fmt.Errorf("tool %s canceled: %w", tool, ctx.Err())
+		}
+	}
+
+	elapsed := time.Since(start)
+	toolMetrics.Add(tool+".calls", 1)
+	toolMetrics.AddFloat(tool+".seconds", elapsed.Seconds())
+	if result.err != nil {
+		toolMetrics.Add(tool+".errors", 1)
+		e.logger.Warn("Tool %s failed after %s: %v", tool, elapsed, result.err)
+		return "", result.err
+	}
+	e.logger.Debug("Tool %s finished in %s", tool, elapsed)
+	*data = append(*data, result.data...)
+	return result.output, nil
+}
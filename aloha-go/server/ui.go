@@ -0,0 +1,22 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// uiFiles holds the web dashboard
+//
+//go:embed ui
+var uiFiles embed.FS
+
+// uiHandler serves the web dashboard under /ui/: recent tasks, the live events of a
+// task and a chat box. The page only calls the REST endpoints of the server serving it.
+func uiHandler() http.Handler {
+	files, err := fs.Sub(uiFiles, "ui")
+	if err != nil {
+		panic(err)
+	}
+	return http.StripPrefix("/ui/", http.FileServerFS(files))
+}
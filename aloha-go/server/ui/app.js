@@ -0,0 +1,340 @@
+// Dashboard for the dice agent. It only uses the REST endpoints of the server serving it:
+// the agent card, /v1/tasks, /v1/tasks/{id}[:subscribe|:cancel] and /v1/message:stream.
+'use strict';
+
+const ACTIVE_STATES = ['submitted', 'working', 'input-required', 'auth-required'];
+const TASK_REFRESH_MS = 3000;
+
+const $ = (id) => document.getElementById(id);
+
+let contextId = '';
+let selectedTask = '';
+let watch = null; // AbortController of the watched task's event stream
+
+// headers returns the request headers, with the bearer token if one is set
+function headers(extra) {
+  const result = Object.assign({}, extra);
+  const token = $('token').value.trim();
+  if (token) {
+    result.Authorization = 'Bearer ' + token;
+  }
+  return result;
+}
+
+// request calls a JSON endpoint and returns its body, throwing the agent's error message on failure
+async function request(path, options) {
+  const resp = await fetch(path, Object.assign({}, options, { headers: headers(options && options.headers) }));
+  const body = await resp.json().catch(() => null);
+  if (!resp.ok) {
+    throw new Error(body && body.error ? body.error.message : resp.status + ' ' + resp.statusText);
+  }
+  return body;
+}
+
+// stream calls a server-sent events endpoint and passes each event to onEvent until the stream ends
+async function stream(path, options, onEvent) {
+  const resp = await fetch(path, Object.assign({}, options, { headers: headers(options.headers) }));
+  if (!resp.ok) {
+    const body = await resp.json().catch(() => null);
+    throw new Error(body && body.error ? body.error.message : resp.status + ' ' + resp.statusText);
+  }
+  const reader = resp.body.getReader();
+  const decoder = new TextDecoder();
+  let buffer = '';
+  for (;;) {
+    const { done, value } = await reader.read();
+    if (done) {
+      return;
+    }
+    buffer += decoder.decode(value, { stream: true });
+    let end;
+    while ((end = buffer.indexOf('\n\n')) >= 0) {
+      const block = buffer.slice(0, end);
+      buffer = buffer.slice(end + 2);
+      const data = block.split('\n')
+        .filter((line) => line.startsWith('data:'))
+        .map((line) => line.slice(5).trimStart())
+        .join('\n');
+      if (!data) {
+        continue;
+      }
+      const event = JSON.parse(data);
+      if (event.error) {
+        throw new Error(event.error.message);
+      }
+      onEvent(event);
+    }
+  }
+}
+
+function newMessageId() {
+  if (window.crypto && crypto.randomUUID) {
+    return crypto.randomUUID();
+  }
+  return Date.now().toString(16) + '-' + Math.random().toString(16).slice(2);
+}
+
+// partsText joins the text parts of a message or artifact
+function partsText(parts) {
+  return (parts || []).filter((part) => part.kind === 'text').map((part) => part.text).join('\n');
+}
+
+function shortID(id) {
+  return id ? id.slice(-8) : '';
+}
+
+function formatTime(timestamp) {
+  return timestamp ? new Date(timestamp).toLocaleTimeString() : '';
+}
+
+function appendLine(log, className, role, text) {
+  const line = document.createElement('div');
+  line.className = 'message ' + className;
+  if (role) {
+    const label = document.createElement('span');
+    label.className = 'role';
+    label.textContent = role;
+    line.appendChild(label);
+  }
+  line.appendChild(document.createTextNode(text));
+  log.appendChild(line);
+  log.scrollTop = log.scrollHeight;
+}
+
+// logEvent appends an event to the events log
+function logEvent(event) {
+  let summary = event.kind;
+  switch (event.kind) {
+    case 'status-update':
+      summary += ' ' + event.status.state + (event.final ? ' (final)' : '');
+      break;
+    case 'artifact-update':
+      summary += ' ' + (event.artifact.name || shortID(event.artifact.artifactId)) + ': ' + partsText(event.artifact.parts);
+      break;
+    case 'task':
+      summary += ' ' + event.status.state;
+      break;
+    case 'message':
+      summary += ' ' + event.role + ': ' + partsText(event.parts);
+      break;
+  }
+  const taskID = event.taskId || event.id || '';
+  appendLine($('events'), '', formatTime(new Date().toISOString()), ' [' + shortID(taskID) + '] ' + summary);
+}
+
+// Agent card
+
+async function loadCard() {
+  try {
+    const card = await request('/.well-known/agent-card.json');
+    $('agent-name').textContent = card.name + ' v' + card.version;
+    $('agent-description').textContent = card.description || '';
+    document.title = card.name;
+  } catch (err) {
+    $('agent-description').textContent = 'Failed to load the agent card: ' + err.message;
+  }
+}
+
+// Tasks
+
+async function refreshTasks() {
+  const params = new URLSearchParams({ pageSize: '50' });
+  const state = $('state-filter').value;
+  if (state) {
+    params.set('status', state);
+  }
+  let resp;
+  try {
+    resp = await request('/v1/tasks?' + params);
+  } catch (err) {
+    $('tasks-summary').textContent = 'Failed to list tasks: ' + err.message;
+    return;
+  }
+
+  const tasks = resp.tasks || [];
+  const active = tasks.filter((task) => ACTIVE_STATES.includes(task.status.state)).length;
+  $('tasks-summary').textContent = `${resp.total_size} tasks, ${active} of the ${tasks.length} most recent active`;
+
+  const rows = tasks.map((task) => {
+    const row = document.createElement('tr');
+    row.dataset.id = task.id;
+    if (task.id === selectedTask) {
+      row.className = 'selected';
+    }
+    const state = document.createElement('span');
+    state.className = 'state ' + (ACTIVE_STATES.includes(task.status.state) ? 'active' : task.status.state);
+    state.textContent = task.status.state;
+    const first = (task.history || []).find((message) => message.role === 'user');
+    const cells = [shortID(task.id), state, formatTime(task.status.timestamp), first ? partsText(first.parts) : ''];
+    for (const value of cells) {
+      const cell = document.createElement('td');
+      if (value instanceof Node) {
+        cell.appendChild(value);
+      } else {
+        cell.textContent = value;
+      }
+      row.appendChild(cell);
+    }
+    row.title = task.id;
+    row.addEventListener('click', () => selectTask(task.id));
+    return row;
+  });
+  $('tasks').replaceChildren(...rows);
+}
+
+async function selectTask(id) {
+  selectedTask = id;
+  stopWatching();
+  $('task-id').textContent = id;
+  for (const row of $('tasks').children) {
+    row.classList.toggle('selected', row.dataset.id === id);
+  }
+  await showTask();
+}
+
+async function showTask() {
+  let task;
+  try {
+    task = await request('/v1/tasks/' + encodeURIComponent(selectedTask));
+  } catch (err) {
+    $('task-detail').textContent = 'Failed to get task: ' + err.message;
+    return;
+  }
+
+  const lines = [
+    'State:   ' + task.status.state + ' at ' + task.status.timestamp,
+    'Context: ' + task.contextId,
+  ];
+  if (task.status.message) {
+    lines.push('Status:  ' + partsText(task.status.message.parts));
+  }
+  lines.push('', 'History:');
+  for (const message of task.history || []) {
+    lines.push('  ' + message.role + ': ' + partsText(message.parts));
+  }
+  lines.push('', 'Artifacts:');
+  for (const artifact of task.artifacts || []) {
+    const data = (artifact.parts || []).filter((part) => part.kind === 'data').map((part) => JSON.stringify(part.data));
+    lines.push('  ' + (artifact.name || shortID(artifact.artifactId)) + ': ' + [partsText(artifact.parts), ...data].filter(Boolean).join(' '));
+  }
+  $('task-detail').textContent = lines.join('\n');
+
+  const active = ACTIVE_STATES.includes(task.status.state);
+  $('watch-task').disabled = !active || watch !== null;
+  $('cancel-task').disabled = !active;
+}
+
+// watchTask follows the live events of the selected task until it ends
+async function watchTask() {
+  const id = selectedTask;
+  watch = new AbortController();
+  $('watch-task').disabled = true;
+  try {
+    await stream('/v1/tasks/' + encodeURIComponent(id) + ':subscribe', { method: 'POST', signal: watch.signal }, logEvent);
+  } catch (err) {
+    if (err.name !== 'AbortError') {
+      appendLine($('events'), 'error', '', 'Watching ' + shortID(id) + ' failed: ' + err.message);
+    }
+  }
+  if (selectedTask === id) {
+    watch = null;
+    showTask();
+  }
+}
+
+function stopWatching() {
+  if (watch) {
+    watch.abort();
+    watch = null;
+  }
+}
+
+async function cancelTask() {
+  try {
+    await request('/v1/tasks/' + encodeURIComponent(selectedTask) + ':cancel', { method: 'POST' });
+  } catch (err) {
+    appendLine($('events'), 'error', '', 'Cancel failed: ' + err.message);
+  }
+  showTask();
+  refreshTasks();
+}
+
+// Chat
+
+async function sendChat(text) {
+  const message = {
+    kind: 'message',
+    messageId: newMessageId(),
+    role: 'user',
+    parts: [{ kind: 'text', text }],
+  };
+  if (contextId) {
+    message.contextId = contextId;
+  }
+  appendLine($('chat-log'), 'user', 'You', text);
+
+  try {
+    await stream('/v1/message:stream', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify({ message }),
+    }, (event) => {
+      logEvent(event);
+      if (event.contextId && event.contextId !== contextId) {
+        contextId = event.contextId;
+        $('context-id').textContent = contextId;
+      }
+      switch (event.kind) {
+        case 'status-update':
+          if (event.status.message) {
+            appendLine($('chat-log'), 'agent', 'Agent', partsText(event.status.message.parts));
+          } else if (event.final && event.status.state !== 'completed') {
+            appendLine($('chat-log'), 'status', '', 'Task ' + event.status.state);
+          }
+          break;
+        case 'artifact-update':
+          appendLine($('chat-log'), 'agent', 'Agent', partsText(event.artifact.parts));
+          break;
+        case 'message':
+          appendLine($('chat-log'), 'agent', 'Agent', partsText(event.parts));
+          break;
+      }
+    });
+  } catch (err) {
+    appendLine($('chat-log'), 'error', '', 'Error: ' + err.message);
+  }
+  refreshTasks();
+}
+
+// Wiring
+
+$('chat-form').addEventListener('submit', (e) => {
+  e.preventDefault();
+  const input = $('chat-input');
+  const text = input.value.trim();
+  if (text) {
+    input.value = '';
+    sendChat(text);
+  }
+});
+
+$('new-chat').addEventListener('click', () => {
+  contextId = '';
+  $('context-id').textContent = 'new';
+  $('chat-log').replaceChildren();
+});
+
+$('token').value = sessionStorage.getItem('aloha-token') || '';
+$('token').addEventListener('change', () => {
+  sessionStorage.setItem('aloha-token', $('token').value.trim());
+  loadCard();
+  refreshTasks();
+});
+
+$('state-filter').addEventListener('change', refreshTasks);
+$('watch-task').addEventListener('click', watchTask);
+$('cancel-task').addEventListener('click', cancelTask);
+
+loadCard();
+refreshTasks();
+setInterval(refreshTasks, TASK_REFRESH_MS);
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aloha/a2a-go/pkg/protocol"
+)
+
+// validationErrorsKey is the key of the violations in the data of a validation error
+const validationErrorsKey = "errors"
+
+// validateMessage checks an incoming message against the protocol: its kind, messageId
+// and role, and each of its parts. The SDK accepts messages without a kind or with an
+// unknown role, and reports unknown part kinds as parse errors.
+func validateMessage(raw json.RawMessage) *protocol.Error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return invalidMessage(protocol.ValidationErrors{{Field: "message", Reason: "is required"}})
+	}
+	var msg protocol.Message
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return invalidMessage(protocol.ValidationErrors{{Field: "message", Reason: err.Error()}})
+	}
+	var violations protocol.ValidationErrors
+	if err := msg.Validate(); errors.As(err, &violations) {
+		return invalidMessage(violations)
+	}
+	return nil
+}
+
+// invalidMessage returns the invalid params error listing the violations, also given in
+// its data under errors
+func invalidMessage(violations protocol.ValidationErrors) *protocol.Error {
+	reasons := make([]string, len(violations))
+	for i, violation := range violations {
+		reasons[i] = violation.Error()
+	}
+	return protocol.ErrInvalidParams.WithData(map[string]any{validationErrorsKey: violations}).Withf("%s", strings.Join(reasons, "; "))
+}
+
+// readCallBody reads the body of a call and puts it back for the next handler. ok is
+// false, and the call answered, if the body is above the size limit.
+func readCallBody(w http.ResponseWriter, r *http.Request, reject func(w http.ResponseWriter, err *protocol.Error)) ([]byte, bool) {
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		reject(w, protocol.ErrInvalidRequest.Withf("request body is above the %d bytes limit", tooLarge.Limit))
+		return nil, false
+	}
+	// Other read errors are left to the next handler, which reads a truncated body
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, true
+}
+
+// validateJSONRPC rejects the message/send and message/stream calls whose message is
+// malformed with an invalid params error (-32602), before they reach the SDK. Malformed
+// requests are left to the SDK, which reports them as such.
+func validateJSONRPC(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isJSONRPCCall(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, ok := readCallBody(w, r, rejectJSONRPCTooLarge)
+		if !ok {
+			return
+		}
+		var call struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params struct {
+				Message json.RawMessage `json:"message"`
+			} `json:"params"`
+		}
+		if json.Unmarshal(body, &call) != nil || (call.Method != "message/send" && call.Method != "message/stream") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := validateMessage(call.Params.Message); err != nil {
+			writeJSONRPCError(w, call.ID, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validateREST rejects the message sends whose message is malformed with 400 and an
+// invalid params error. The message is either wrapped in MessageSendParams or bare.
+func validateREST(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || (r.URL.Path != "/v1/message:send" && r.URL.Path != "/v1/message:stream") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, ok := readCallBody(w, r, rejectRESTTooLarge)
+		if !ok {
+			return
+		}
+		var params struct {
+			Message json.RawMessage `json:"message"`
+		}
+		if err := json.Unmarshal(body, &params); err != nil {
+			writeRESTError(w, protocol.ErrParse.Withf("%v", err))
+			return
+		}
+		// Like the handlers, accept a bare message without the wrapper
+		message := params.Message
+		if message == nil {
+			message = body
+		}
+		if err := validateMessage(message); err != nil {
+			writeRESTError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// validMessage is a message that passes validation
+const validMessage = `{"kind":"message","messageId":"m1","role":"user","parts":[{"kind":"text","text":"Roll a dice"}]}`
+
+func TestValidateREST(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{"wrapped message", "/v1/message:send", `{"message":` + validMessage + `}`, http.StatusOK},
+		{"bare message", "/v1/message:send", validMessage, http.StatusOK},
+		{"bare message stream", "/v1/message:stream", validMessage, http.StatusOK},
+		{"wrapped message without role", "/v1/message:send", `{"message":{"kind":"message","messageId":"m1","parts":[]}}`, http.StatusBadRequest},
+		{"bare message without role", "/v1/message:send", `{"kind":"message","messageId":"m1","parts":[]}`, http.StatusBadRequest},
+		{"empty body", "/v1/message:send", `{}`, http.StatusBadRequest},
+		{"not JSON", "/v1/message:send", `roll`, http.StatusBadRequest},
+		{"other path", "/v1/tasks", `roll`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := validateREST(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestDecodeRESTSendParams(t *testing.T) {
+	for _, body := range []string{validMessage, `{"message":` + validMessage + `}`} {
+		params, err := decodeRESTSendParams([]byte(body))
+		if err != nil {
+			t.Fatalf("decodeRESTSendParams(%s): %v", body, err)
+		}
+		if params.Message == nil || params.Message.ID != "m1" {
+			t.Errorf("decodeRESTSendParams(%s) message = %+v, want m1", body, params.Message)
+		}
+	}
+}
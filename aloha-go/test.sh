@@ -16,8 +16,7 @@ RED='\033[0;31m'
 YELLOW='\033[1;33m'
 NC='\033[0m' # No Color
 
-AGENT_BIN=""
-HOST_BIN=""
+ALOHA_BIN=""
 PYTHON_CMD=""
 
 resolve_binary() {
@@ -49,35 +48,24 @@ resolve_python() {
     return 1
 }
 
-# Check if binaries exist (or can be built)
-if ! AGENT_BIN="$(resolve_binary "server/server")"; then
+# Check if the binary exists (or can be built)
+if ! ALOHA_BIN="$(resolve_binary "aloha/aloha")"; then
     if command -v go >/dev/null 2>&1; then
-        echo -e "${YELLOW}Building server...${NC}"
-        (cd server && go build -o server)
-        AGENT_BIN="$(resolve_binary "server/server")"
+        echo -e "${YELLOW}Building aloha...${NC}"
+        (cd aloha && go build -o aloha)
+        ALOHA_BIN="$(resolve_binary "aloha/aloha")"
     else
-        echo -e "${RED}✗ Agent binary not found and 'go' command is unavailable${NC}"
+        echo -e "${RED}✗ aloha binary not found and 'go' command is unavailable${NC}"
         exit 1
     fi
 fi
 
-if ! HOST_BIN="$(resolve_binary "client/client")"; then
-    if command -v go >/dev/null 2>&1; then
-        echo -e "${YELLOW}Building client...${NC}"
-        (cd client && go build -o client)
-        HOST_BIN="$(resolve_binary "client/client")"
-    else
-        echo -e "${RED}✗ Host binary not found and 'go' command is unavailable${NC}"
-        exit 1
-    fi
-fi
-
-echo -e "${GREEN}✓ Binaries ready${NC}"
+echo -e "${GREEN}✓ Binary ready${NC}"
 echo ""
 
 # Start agent in background
 echo "Starting agent..."
-"$AGENT_BIN" > /tmp/go-agent.log 2>&1 &
+"$ALOHA_BIN" serve > /tmp/go-agent.log 2>&1 &
 AGENT_PID=$!
 
 # Wait for agent to start
@@ -96,7 +84,7 @@ echo ""
 test_rest_transport() {
     echo "Testing rest transport (port 12002)..."
 
-    if "$HOST_BIN" --transport "rest" --port "12002" --message "Roll a 6-sided dice" > /tmp/go-host-rest.log 2>&1; then
+    if "$ALOHA_BIN" send --transport "rest" --port "12002" --message "Roll a 6-sided dice" > /tmp/go-host-rest.log 2>&1; then
         echo -e "${GREEN}✓ rest test passed${NC}"
         return 0
     else
@@ -188,7 +176,7 @@ echo ""
 # Optional experimental JSON-RPC send test
 if [ "${A2A_EXPERIMENTAL_TRANSPORTS:-0}" = "1" ]; then
     echo "Testing experimental JSON-RPC send path (port 12001)..."
-    if "$HOST_BIN" --transport "jsonrpc" --port "12001" --message "Is 17 prime?" > /tmp/go-host-jsonrpc.log 2>&1; then
+    if "$ALOHA_BIN" send --transport "jsonrpc" --port "12001" --message "Is 17 prime?" > /tmp/go-host-jsonrpc.log 2>&1; then
         echo -e "${GREEN}✓ Experimental JSON-RPC send test passed${NC}"
     else
         echo -e "${RED}✗ Experimental JSON-RPC send test failed${NC}"